@@ -0,0 +1,199 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/helpers"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+const (
+	// accessTokenPrefix marks the personal access tokens, so that they are easy to recognize (ex. by secret
+	// scanners).
+	accessTokenPrefix = "mkp_"
+	// accessTokenLength is the number of random bytes in a personal access token.
+	accessTokenLength = 32
+	// accessTokenDisplayLength is the number of characters of the token kept for identification.
+	accessTokenDisplayLength = 12
+)
+
+// generateAccessToken generates a random personal access token.
+func generateAccessToken() (string, error) {
+	b := make([]byte, accessTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return accessTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreateToken creates a personal access token for the authenticated user. Only the hash of the token is
+// stored, so the token is returned only in this response.
+func (c *UserController) CreateToken(ctx *app.CreateTokenUserContext) error {
+	if !auth.HasAuth(ctx.Context) {
+		return ctx.InternalServerError(goa.ErrBadRequest("no-auth"))
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", auth.GetAuth(ctx.Context).UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	var expiresAt int64
+	if ctx.Payload.ExpiresAt != nil {
+		expiresAt = int64(*ctx.Payload.ExpiresAt)
+		if expiresAt <= now {
+			return ctx.BadRequest(goa.ErrBadRequest("expiresAt must be in the future"))
+		}
+	}
+
+	token, err := generateAccessToken()
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	record := &store.AccessTokenRecord{
+		UserID:    user.ID.Hex(),
+		Name:      ctx.Payload.Name,
+		Prefix:    token[:accessTokenDisplayLength],
+		Hash:      c.TokenHasher.Hash(token),
+		Scopes:    ctx.Payload.Scopes,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	result, err := c.Store.AccessTokens.Save(record, nil)
+	if err != nil {
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	created := &store.AccessTokenRecord{}
+	if err = backends.MapToInterface(result, created); err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	accessToken := created.ToAppAccessToken()
+	accessToken.Token = &token
+
+	return ctx.Created(accessToken)
+}
+
+// ListTokens lists the personal access tokens of the authenticated user.
+func (c *UserController) ListTokens(ctx *app.ListTokensUserContext) error {
+	if !auth.HasAuth(ctx.Context) {
+		return ctx.InternalServerError(goa.ErrBadRequest("no-auth"))
+	}
+
+	var typeHint map[string]interface{}
+	result, err := c.Store.AccessTokens.GetAll(backends.NewFilter().Match("userId", auth.GetAuth(ctx.Context).UserID), typeHint, "createdAt", "desc", 0, 0)
+	if err != nil && !backends.IsErrNotFound(err) {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	records := []*store.AccessTokenRecord{}
+	if err == nil && result != nil {
+		if err = backends.MapToInterface(result, &records); err != nil {
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+	}
+
+	tokens := app.AccessTokenCollection{}
+	for _, record := range records {
+		tokens = append(tokens, record.ToAppAccessToken())
+	}
+
+	return ctx.OK(tokens)
+}
+
+// RevokeToken deletes a personal access token of the authenticated user.
+func (c *UserController) RevokeToken(ctx *app.RevokeTokenUserContext) error {
+	if !auth.HasAuth(ctx.Context) {
+		return ctx.InternalServerError(goa.ErrBadRequest("no-auth"))
+	}
+
+	record := &store.AccessTokenRecord{}
+	if _, err := c.Store.AccessTokens.GetOne(backends.NewFilter().Match("id", ctx.TokenID), record); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	// Tokens of other users are reported as not found.
+	if record.UserID != auth.GetAuth(ctx.Context).UserID {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	if err := c.Store.AccessTokens.DeleteOne(backends.NewFilter().Match("id", ctx.TokenID)); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.NoContent()
+}
+
+// FindByToken looks up a user by personal access token and records the time of use. The scopes of the
+// token are returned in the X-Token-Scopes header. Intended for internal use.
+func (c *UserController) FindByToken(ctx *app.FindByTokenUserContext) error {
+
+	record := &store.AccessTokenRecord{}
+	if _, err := c.Store.AccessTokens.GetOne(backends.NewFilter().Match("hash", c.TokenHasher.Hash(ctx.Payload.Token)), record); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	if record.Hash == "" || record.IsExpired(now) {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", record.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if !user.CanLogin() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	update := map[string]interface{}{
+		"lastUsedAt": now,
+	}
+	if _, err := c.Store.AccessTokens.Save(&update, backends.NewFilter().Match("id", record.ID)); err != nil {
+		c.Service.LogError("User: failed to record access token use.", "err", err.Error())
+	}
+
+	ctx.ResponseData.Header().Set("X-Token-Scopes", strings.Join(record.Scopes, ","))
+	return ctx.OK(c.userMedia(ctx, user))
+}
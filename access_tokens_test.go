@@ -0,0 +1,24 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAccessToken(t *testing.T) {
+	token, err := generateAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := generateAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(token, accessTokenPrefix) {
+		t.Errorf("Expected the token to start with %s, got %s", accessTokenPrefix, token)
+	}
+	if token == other {
+		t.Error("Expected random tokens")
+	}
+}
@@ -17,28 +17,28 @@ import (
 	"strconv"
 )
 
-// CreateUserContext provides the user create action context.
-type CreateUserContext struct {
+// AcceptInvitationUserContext provides the user acceptInvitation action context.
+type AcceptInvitationUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	Payload *CreateUserPayload
+	Payload *AcceptInvitationPayload
 }
 
-// NewCreateUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller create action.
-func NewCreateUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*CreateUserContext, error) {
+// NewAcceptInvitationUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller acceptInvitation action.
+func NewAcceptInvitationUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*AcceptInvitationUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := CreateUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := AcceptInvitationUserContext{Context: ctx, ResponseData: resp, RequestData: req}
 	return &rctx, err
 }
 
 // Created sends a HTTP response with status code 201.
-func (ctx *CreateUserContext) Created(r *Users) error {
+func (ctx *AcceptInvitationUserContext) Created(r *Users) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
 	}
@@ -46,59 +46,77 @@ func (ctx *CreateUserContext) Created(r *Users) error {
 }
 
 // BadRequest sends a HTTP response with status code 400.
-func (ctx *CreateUserContext) BadRequest(r error) error {
+func (ctx *AcceptInvitationUserContext) BadRequest(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
 }
 
+// NotFound sends a HTTP response with status code 404.
+func (ctx *AcceptInvitationUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *CreateUserContext) InternalServerError(r error) error {
+func (ctx *AcceptInvitationUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// FindUserContext provides the user find action context.
-type FindUserContext struct {
+// ChangePasswordUserContext provides the user changePassword action context.
+type ChangePasswordUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	Payload *Credentials
+	Payload *ChangePasswordPayload
 }
 
-// NewFindUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller find action.
-func NewFindUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*FindUserContext, error) {
+// NewChangePasswordUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller changePassword action.
+func NewChangePasswordUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ChangePasswordUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := FindUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := ChangePasswordUserContext{Context: ctx, ResponseData: resp, RequestData: req}
 	return &rctx, err
 }
 
 // OK sends a HTTP response with status code 200.
-func (ctx *FindUserContext) OK(r *Users) error {
+func (ctx *ChangePasswordUserContext) OK(resp []byte) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
 	}
-	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+	ctx.ResponseData.WriteHeader(200)
+	_, err := ctx.ResponseData.Write(resp)
+	return err
 }
 
 // BadRequest sends a HTTP response with status code 400.
-func (ctx *FindUserContext) BadRequest(r error) error {
+func (ctx *ChangePasswordUserContext) BadRequest(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
 }
 
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *ChangePasswordUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
 // NotFound sends a HTTP response with status code 404.
-func (ctx *FindUserContext) NotFound(r error) error {
+func (ctx *ChangePasswordUserContext) NotFound(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -106,43 +124,51 @@ func (ctx *FindUserContext) NotFound(r error) error {
 }
 
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *FindUserContext) InternalServerError(r error) error {
+func (ctx *ChangePasswordUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// FindByEmailUserContext provides the user findByEmail action context.
-type FindByEmailUserContext struct {
+// ConfirmEmailChangeUserContext provides the user confirmEmailChange action context.
+type ConfirmEmailChangeUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	Payload *EmailPayload
+	Payload *TokenPayload
 }
 
-// NewFindByEmailUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller findByEmail action.
-func NewFindByEmailUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*FindByEmailUserContext, error) {
+// NewConfirmEmailChangeUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller confirmEmailChange action.
+func NewConfirmEmailChangeUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ConfirmEmailChangeUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := FindByEmailUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := ConfirmEmailChangeUserContext{Context: ctx, ResponseData: resp, RequestData: req}
 	return &rctx, err
 }
 
 // OK sends a HTTP response with status code 200.
-func (ctx *FindByEmailUserContext) OK(r *Users) error {
+func (ctx *ConfirmEmailChangeUserContext) OK(r *Users) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
 }
 
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *ConfirmEmailChangeUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
 // NotFound sends a HTTP response with status code 404.
-func (ctx *FindByEmailUserContext) NotFound(r error) error {
+func (ctx *ConfirmEmailChangeUserContext) NotFound(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -150,89 +176,95 @@ func (ctx *FindByEmailUserContext) NotFound(r error) error {
 }
 
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *FindByEmailUserContext) InternalServerError(r error) error {
+func (ctx *ConfirmEmailChangeUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// FindUsersUserContext provides the user findUsers action context.
-type FindUsersUserContext struct {
+// ConfirmTotpUserContext provides the user confirmTotp action context.
+type ConfirmTotpUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	Payload *FilterPayload
+	Payload *MFACodePayload
 }
 
-// NewFindUsersUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller findUsers action.
-func NewFindUsersUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*FindUsersUserContext, error) {
+// NewConfirmTotpUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller confirmTotp action.
+func NewConfirmTotpUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ConfirmTotpUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := FindUsersUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := ConfirmTotpUserContext{Context: ctx, ResponseData: resp, RequestData: req}
 	return &rctx, err
 }
 
 // OK sends a HTTP response with status code 200.
-func (ctx *FindUsersUserContext) OK(r *UsersPage) error {
+func (ctx *ConfirmTotpUserContext) OK(r *RecoveryCodes) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "application/mt.ckan.users-page+json")
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.recovery-codes+json")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
 }
 
 // BadRequest sends a HTTP response with status code 400.
-func (ctx *FindUsersUserContext) BadRequest(r error) error {
+func (ctx *ConfirmTotpUserContext) BadRequest(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
 }
 
+// NotFound sends a HTTP response with status code 404.
+func (ctx *ConfirmTotpUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *FindUsersUserContext) InternalServerError(r error) error {
+func (ctx *ConfirmTotpUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// ForgotPasswordUserContext provides the user forgotPassword action context.
-type ForgotPasswordUserContext struct {
+// CreateUserContext provides the user create action context.
+type CreateUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	Payload *EmailPayload
+	Payload *CreateUserPayload
 }
 
-// NewForgotPasswordUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller forgotPassword action.
-func NewForgotPasswordUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ForgotPasswordUserContext, error) {
+// NewCreateUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller create action.
+func NewCreateUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*CreateUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := ForgotPasswordUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := CreateUserContext{Context: ctx, ResponseData: resp, RequestData: req}
 	return &rctx, err
 }
 
-// OK sends a HTTP response with status code 200.
-func (ctx *ForgotPasswordUserContext) OK(resp []byte) error {
+// Created sends a HTTP response with status code 201.
+func (ctx *CreateUserContext) Created(r *Users) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
 	}
-	ctx.ResponseData.WriteHeader(200)
-	_, err := ctx.ResponseData.Write(resp)
-	return err
+	return ctx.ResponseData.Service.Send(ctx.Context, 201, r)
 }
 
 // BadRequest sends a HTTP response with status code 400.
-func (ctx *ForgotPasswordUserContext) BadRequest(r error) error {
+func (ctx *CreateUserContext) BadRequest(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -240,102 +272,87 @@ func (ctx *ForgotPasswordUserContext) BadRequest(r error) error {
 }
 
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *ForgotPasswordUserContext) InternalServerError(r error) error {
+func (ctx *CreateUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// ForgotPasswordUpdateUserContext provides the user forgotPasswordUpdate action context.
-type ForgotPasswordUpdateUserContext struct {
+// CreateInvitationUserContext provides the user createInvitation action context.
+type CreateInvitationUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	Payload *ForgotPasswordPayload
+	Payload *InvitationPayload
 }
 
-// NewForgotPasswordUpdateUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller forgotPasswordUpdate action.
-func NewForgotPasswordUpdateUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ForgotPasswordUpdateUserContext, error) {
+// NewCreateInvitationUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller createInvitation action.
+func NewCreateInvitationUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*CreateInvitationUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := ForgotPasswordUpdateUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := CreateInvitationUserContext{Context: ctx, ResponseData: resp, RequestData: req}
 	return &rctx, err
 }
 
-// OK sends a HTTP response with status code 200.
-func (ctx *ForgotPasswordUpdateUserContext) OK(resp []byte) error {
+// Created sends a HTTP response with status code 201.
+func (ctx *CreateInvitationUserContext) Created(r *Invitation) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.invitation+json")
 	}
-	ctx.ResponseData.WriteHeader(200)
-	_, err := ctx.ResponseData.Write(resp)
-	return err
+	return ctx.ResponseData.Service.Send(ctx.Context, 201, r)
 }
 
 // BadRequest sends a HTTP response with status code 400.
-func (ctx *ForgotPasswordUpdateUserContext) BadRequest(r error) error {
+func (ctx *CreateInvitationUserContext) BadRequest(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
 }
 
-// NotFound sends a HTTP response with status code 404.
-func (ctx *ForgotPasswordUpdateUserContext) NotFound(r error) error {
-	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
-	}
-	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
-}
-
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *ForgotPasswordUpdateUserContext) InternalServerError(r error) error {
+func (ctx *CreateInvitationUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// GetUserContext provides the user get action context.
-type GetUserContext struct {
+// CreateTokenUserContext provides the user createToken action context.
+type CreateTokenUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	UserID string
+	Payload *CreateAccessTokenPayload
 }
 
-// NewGetUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller get action.
-func NewGetUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetUserContext, error) {
+// NewCreateTokenUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller createToken action.
+func NewCreateTokenUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*CreateTokenUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := GetUserContext{Context: ctx, ResponseData: resp, RequestData: req}
-	paramUserID := req.Params["userId"]
-	if len(paramUserID) > 0 {
-		rawUserID := paramUserID[0]
-		rctx.UserID = rawUserID
-	}
+	rctx := CreateTokenUserContext{Context: ctx, ResponseData: resp, RequestData: req}
 	return &rctx, err
 }
 
-// OK sends a HTTP response with status code 200.
-func (ctx *GetUserContext) OK(r *Users) error {
+// Created sends a HTTP response with status code 201.
+func (ctx *CreateTokenUserContext) Created(r *AccessToken) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.access-token+json")
 	}
-	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+	return ctx.ResponseData.Service.Send(ctx.Context, 201, r)
 }
 
 // BadRequest sends a HTTP response with status code 400.
-func (ctx *GetUserContext) BadRequest(r error) error {
+func (ctx *CreateTokenUserContext) BadRequest(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -343,7 +360,7 @@ func (ctx *GetUserContext) BadRequest(r error) error {
 }
 
 // NotFound sends a HTTP response with status code 404.
-func (ctx *GetUserContext) NotFound(r error) error {
+func (ctx *CreateTokenUserContext) NotFound(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -351,85 +368,57 @@ func (ctx *GetUserContext) NotFound(r error) error {
 }
 
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *GetUserContext) InternalServerError(r error) error {
+func (ctx *CreateTokenUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// GetAllUserContext provides the user getAll action context.
-type GetAllUserContext struct {
+// DeactivateUserContext provides the user deactivate action context.
+type DeactivateUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	Limit   *int
-	Offset  *int
-	Order   *string
-	Sorting *string
+	UserID  string
+	Payload *StatusChangePayload
 }
 
-// NewGetAllUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller getAll action.
-func NewGetAllUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetAllUserContext, error) {
+// NewDeactivateUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller deactivate action.
+func NewDeactivateUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*DeactivateUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := GetAllUserContext{Context: ctx, ResponseData: resp, RequestData: req}
-	paramLimit := req.Params["limit"]
-	if len(paramLimit) > 0 {
-		rawLimit := paramLimit[0]
-		if limit, err2 := strconv.Atoi(rawLimit); err2 == nil {
-			tmp2 := limit
-			tmp1 := &tmp2
-			rctx.Limit = tmp1
-		} else {
-			err = goa.MergeErrors(err, goa.InvalidParamTypeError("limit", rawLimit, "integer"))
-		}
-	}
-	paramOffset := req.Params["offset"]
-	if len(paramOffset) > 0 {
-		rawOffset := paramOffset[0]
-		if offset, err2 := strconv.Atoi(rawOffset); err2 == nil {
-			tmp4 := offset
-			tmp3 := &tmp4
-			rctx.Offset = tmp3
-		} else {
-			err = goa.MergeErrors(err, goa.InvalidParamTypeError("offset", rawOffset, "integer"))
-		}
-	}
-	paramOrder := req.Params["order"]
-	if len(paramOrder) > 0 {
-		rawOrder := paramOrder[0]
-		rctx.Order = &rawOrder
-	}
-	paramSorting := req.Params["sorting"]
-	if len(paramSorting) > 0 {
-		rawSorting := paramSorting[0]
-		rctx.Sorting = &rawSorting
-		if rctx.Sorting != nil {
-			if !(*rctx.Sorting == "asc" || *rctx.Sorting == "desc") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError(`sorting`, *rctx.Sorting, []interface{}{"asc", "desc"}))
-			}
-		}
+	rctx := DeactivateUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
 	}
 	return &rctx, err
 }
 
 // OK sends a HTTP response with status code 200.
-func (ctx *GetAllUserContext) OK(resp []byte) error {
+func (ctx *DeactivateUserContext) OK(r *Users) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
 	}
-	ctx.ResponseData.WriteHeader(200)
-	_, err := ctx.ResponseData.Write(resp)
-	return err
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *DeactivateUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
 }
 
 // NotFound sends a HTTP response with status code 404.
-func (ctx *GetAllUserContext) NotFound(r error) error {
+func (ctx *DeactivateUserContext) NotFound(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -437,42 +426,46 @@ func (ctx *GetAllUserContext) NotFound(r error) error {
 }
 
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *GetAllUserContext) InternalServerError(r error) error {
+func (ctx *DeactivateUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// GetMeUserContext provides the user getMe action context.
-type GetMeUserContext struct {
+// DeleteUserContext provides the user delete action context.
+type DeleteUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
+	UserID string
 }
 
-// NewGetMeUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller getMe action.
-func NewGetMeUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetMeUserContext, error) {
+// NewDeleteUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller delete action.
+func NewDeleteUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*DeleteUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := GetMeUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := DeleteUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
 	return &rctx, err
 }
 
-// OK sends a HTTP response with status code 200.
-func (ctx *GetMeUserContext) OK(r *Users) error {
-	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
-	}
-	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+// NoContent sends a HTTP response with status code 204.
+func (ctx *DeleteUserContext) NoContent() error {
+	ctx.ResponseData.WriteHeader(204)
+	return nil
 }
 
 // BadRequest sends a HTTP response with status code 400.
-func (ctx *GetMeUserContext) BadRequest(r error) error {
+func (ctx *DeleteUserContext) BadRequest(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -480,7 +473,7 @@ func (ctx *GetMeUserContext) BadRequest(r error) error {
 }
 
 // NotFound sends a HTTP response with status code 404.
-func (ctx *GetMeUserContext) NotFound(r error) error {
+func (ctx *DeleteUserContext) NotFound(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -488,43 +481,42 @@ func (ctx *GetMeUserContext) NotFound(r error) error {
 }
 
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *GetMeUserContext) InternalServerError(r error) error {
+func (ctx *DeleteUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// ResetVerificationTokenUserContext provides the user resetVerificationToken action context.
-type ResetVerificationTokenUserContext struct {
+// EnrollTotpUserContext provides the user enrollTotp action context.
+type EnrollTotpUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	Payload *EmailPayload
 }
 
-// NewResetVerificationTokenUserContext parses the incoming request URL and body, performs validations and creates the
-// context used by the user controller resetVerificationToken action.
-func NewResetVerificationTokenUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ResetVerificationTokenUserContext, error) {
+// NewEnrollTotpUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller enrollTotp action.
+func NewEnrollTotpUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*EnrollTotpUserContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := ResetVerificationTokenUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := EnrollTotpUserContext{Context: ctx, ResponseData: resp, RequestData: req}
 	return &rctx, err
 }
 
 // OK sends a HTTP response with status code 200.
-func (ctx *ResetVerificationTokenUserContext) OK(r *ResetToken) error {
+func (ctx *EnrollTotpUserContext) OK(r *TOTPEnrollment) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
-		ctx.ResponseData.Header().Set("Content-Type", "resettokenmedia")
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.totp-enrollment+json")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
 }
 
 // BadRequest sends a HTTP response with status code 400.
-func (ctx *ResetVerificationTokenUserContext) BadRequest(r error) error {
+func (ctx *EnrollTotpUserContext) BadRequest(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -532,7 +524,7 @@ func (ctx *ResetVerificationTokenUserContext) BadRequest(r error) error {
 }
 
 // NotFound sends a HTTP response with status code 404.
-func (ctx *ResetVerificationTokenUserContext) NotFound(r error) error {
+func (ctx *EnrollTotpUserContext) NotFound(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
@@ -540,23 +532,1765 @@ func (ctx *ResetVerificationTokenUserContext) NotFound(r error) error {
 }
 
 // InternalServerError sends a HTTP response with status code 500.
-func (ctx *ResetVerificationTokenUserContext) InternalServerError(r error) error {
+func (ctx *EnrollTotpUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
 		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
-// UpdateUserContext provides the user update action context.
-type UpdateUserContext struct {
+// FindUserContext provides the user find action context.
+type FindUserContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
-	UserID  string
-	Payload *UpdateUserPayload
+	Payload *Credentials
 }
 
-// NewUpdateUserContext parses the incoming request URL and body, performs validations and creates the
+// NewFindUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller find action.
+func NewFindUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*FindUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := FindUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *FindUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *FindUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *FindUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// Locked sends a HTTP response with status code 423.
+func (ctx *FindUserContext) Locked(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 423, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *FindUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// FindByEmailUserContext provides the user findByEmail action context.
+type FindByEmailUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *EmailPayload
+}
+
+// NewFindByEmailUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller findByEmail action.
+func NewFindByEmailUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*FindByEmailUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := FindByEmailUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *FindByEmailUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *FindByEmailUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *FindByEmailUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// FindByMagicLinkUserContext provides the user findByMagicLink action context.
+type FindByMagicLinkUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *TokenPayload
+}
+
+// NewFindByMagicLinkUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller findByMagicLink action.
+func NewFindByMagicLinkUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*FindByMagicLinkUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := FindByMagicLinkUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *FindByMagicLinkUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *FindByMagicLinkUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *FindByMagicLinkUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// Locked sends a HTTP response with status code 423.
+func (ctx *FindByMagicLinkUserContext) Locked(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 423, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *FindByMagicLinkUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// FindByTokenUserContext provides the user findByToken action context.
+type FindByTokenUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *AccessTokenPayload
+}
+
+// NewFindByTokenUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller findByToken action.
+func NewFindByTokenUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*FindByTokenUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := FindByTokenUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *FindByTokenUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *FindByTokenUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *FindByTokenUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *FindByTokenUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// FindUsersUserContext provides the user findUsers action context.
+type FindUsersUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *FilterPayload
+}
+
+// NewFindUsersUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller findUsers action.
+func NewFindUsersUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*FindUsersUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := FindUsersUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *FindUsersUserContext) OK(r *UsersPage) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/mt.ckan.users-page+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *FindUsersUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *FindUsersUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// ForgotPasswordUserContext provides the user forgotPassword action context.
+type ForgotPasswordUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *EmailPayload
+}
+
+// NewForgotPasswordUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller forgotPassword action.
+func NewForgotPasswordUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ForgotPasswordUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ForgotPasswordUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ForgotPasswordUserContext) OK(resp []byte) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+	}
+	ctx.ResponseData.WriteHeader(200)
+	_, err := ctx.ResponseData.Write(resp)
+	return err
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *ForgotPasswordUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *ForgotPasswordUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// ForgotPasswordUpdateUserContext provides the user forgotPasswordUpdate action context.
+type ForgotPasswordUpdateUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *ForgotPasswordPayload
+}
+
+// NewForgotPasswordUpdateUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller forgotPasswordUpdate action.
+func NewForgotPasswordUpdateUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ForgotPasswordUpdateUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ForgotPasswordUpdateUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ForgotPasswordUpdateUserContext) OK(resp []byte) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+	}
+	ctx.ResponseData.WriteHeader(200)
+	_, err := ctx.ResponseData.Write(resp)
+	return err
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *ForgotPasswordUpdateUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *ForgotPasswordUpdateUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *ForgotPasswordUpdateUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// GetUserContext provides the user get action context.
+type GetUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	UserID string
+}
+
+// NewGetUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller get action.
+func NewGetUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := GetUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *GetUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *GetUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *GetUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *GetUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// GetAllUserContext provides the user getAll action context.
+type GetAllUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Limit   *int
+	Offset  *int
+	Order   *string
+	Sorting *string
+}
+
+// NewGetAllUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller getAll action.
+func NewGetAllUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetAllUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := GetAllUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramLimit := req.Params["limit"]
+	if len(paramLimit) > 0 {
+		rawLimit := paramLimit[0]
+		if limit, err2 := strconv.Atoi(rawLimit); err2 == nil {
+			tmp2 := limit
+			tmp1 := &tmp2
+			rctx.Limit = tmp1
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("limit", rawLimit, "integer"))
+		}
+	}
+	paramOffset := req.Params["offset"]
+	if len(paramOffset) > 0 {
+		rawOffset := paramOffset[0]
+		if offset, err2 := strconv.Atoi(rawOffset); err2 == nil {
+			tmp4 := offset
+			tmp3 := &tmp4
+			rctx.Offset = tmp3
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("offset", rawOffset, "integer"))
+		}
+	}
+	paramOrder := req.Params["order"]
+	if len(paramOrder) > 0 {
+		rawOrder := paramOrder[0]
+		rctx.Order = &rawOrder
+	}
+	paramSorting := req.Params["sorting"]
+	if len(paramSorting) > 0 {
+		rawSorting := paramSorting[0]
+		rctx.Sorting = &rawSorting
+		if rctx.Sorting != nil {
+			if !(*rctx.Sorting == "asc" || *rctx.Sorting == "desc") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError(`sorting`, *rctx.Sorting, []interface{}{"asc", "desc"}))
+			}
+		}
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *GetAllUserContext) OK(resp []byte) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+	}
+	ctx.ResponseData.WriteHeader(200)
+	_, err := ctx.ResponseData.Write(resp)
+	return err
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *GetAllUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *GetAllUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// GetDuplicatesUserContext provides the user getDuplicates action context.
+type GetDuplicatesUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+}
+
+// NewGetDuplicatesUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller getDuplicates action.
+func NewGetDuplicatesUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetDuplicatesUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := GetDuplicatesUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *GetDuplicatesUserContext) OK(r DuplicateUsersCollection) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.duplicate-users+json; type=collection")
+	}
+	if r == nil {
+		r = DuplicateUsersCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *GetDuplicatesUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// GetLoginsUserContext provides the user getLogins action context.
+type GetLoginsUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Limit  *int
+	Offset *int
+	UserID string
+}
+
+// NewGetLoginsUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller getLogins action.
+func NewGetLoginsUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetLoginsUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := GetLoginsUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramLimit := req.Params["limit"]
+	if len(paramLimit) > 0 {
+		rawLimit := paramLimit[0]
+		if limit, err2 := strconv.Atoi(rawLimit); err2 == nil {
+			tmp6 := limit
+			tmp5 := &tmp6
+			rctx.Limit = tmp5
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("limit", rawLimit, "integer"))
+		}
+	}
+	paramOffset := req.Params["offset"]
+	if len(paramOffset) > 0 {
+		rawOffset := paramOffset[0]
+		if offset, err2 := strconv.Atoi(rawOffset); err2 == nil {
+			tmp8 := offset
+			tmp7 := &tmp8
+			rctx.Offset = tmp7
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("offset", rawOffset, "integer"))
+		}
+	}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *GetLoginsUserContext) OK(r LoginCollection) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.login+json; type=collection")
+	}
+	if r == nil {
+		r = LoginCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *GetLoginsUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *GetLoginsUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *GetLoginsUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// GetMeUserContext provides the user getMe action context.
+type GetMeUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+}
+
+// NewGetMeUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller getMe action.
+func NewGetMeUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetMeUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := GetMeUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *GetMeUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *GetMeUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *GetMeUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *GetMeUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// GetMyLoginsUserContext provides the user getMyLogins action context.
+type GetMyLoginsUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Limit  *int
+	Offset *int
+}
+
+// NewGetMyLoginsUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller getMyLogins action.
+func NewGetMyLoginsUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetMyLoginsUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := GetMyLoginsUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramLimit := req.Params["limit"]
+	if len(paramLimit) > 0 {
+		rawLimit := paramLimit[0]
+		if limit, err2 := strconv.Atoi(rawLimit); err2 == nil {
+			tmp10 := limit
+			tmp9 := &tmp10
+			rctx.Limit = tmp9
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("limit", rawLimit, "integer"))
+		}
+	}
+	paramOffset := req.Params["offset"]
+	if len(paramOffset) > 0 {
+		rawOffset := paramOffset[0]
+		if offset, err2 := strconv.Atoi(rawOffset); err2 == nil {
+			tmp12 := offset
+			tmp11 := &tmp12
+			rctx.Offset = tmp11
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("offset", rawOffset, "integer"))
+		}
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *GetMyLoginsUserContext) OK(r LoginCollection) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.login+json; type=collection")
+	}
+	if r == nil {
+		r = LoginCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *GetMyLoginsUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *GetMyLoginsUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *GetMyLoginsUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// ListInvitationsUserContext provides the user listInvitations action context.
+type ListInvitationsUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+}
+
+// NewListInvitationsUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller listInvitations action.
+func NewListInvitationsUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ListInvitationsUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ListInvitationsUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ListInvitationsUserContext) OK(r InvitationCollection) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.invitation+json; type=collection")
+	}
+	if r == nil {
+		r = InvitationCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *ListInvitationsUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// ListTokensUserContext provides the user listTokens action context.
+type ListTokensUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+}
+
+// NewListTokensUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller listTokens action.
+func NewListTokensUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ListTokensUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ListTokensUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ListTokensUserContext) OK(r AccessTokenCollection) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user.access-token+json; type=collection")
+	}
+	if r == nil {
+		r = AccessTokenCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *ListTokensUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// MergeUsersUserContext provides the user mergeUsers action context.
+type MergeUsersUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *MergeUsersPayload
+}
+
+// NewMergeUsersUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller mergeUsers action.
+func NewMergeUsersUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*MergeUsersUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := MergeUsersUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *MergeUsersUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *MergeUsersUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *MergeUsersUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *MergeUsersUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *MergeUsersUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// PatchUserContext provides the user patch action context.
+type PatchUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	IfMatch *string
+	UserID  string
+	Payload PatchUserPayload
+}
+
+// NewPatchUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller patch action.
+func NewPatchUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*PatchUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := PatchUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	headerIfMatch := req.Header["If-Match"]
+	if len(headerIfMatch) > 0 {
+		rawIfMatch := headerIfMatch[0]
+		req.Params["If-Match"] = []string{rawIfMatch}
+		rctx.IfMatch = &rawIfMatch
+	}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// PatchUserPayload is the user patch action payload.
+type PatchUserPayload map[string]interface{}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *PatchUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *PatchUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *PatchUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *PatchUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// PreconditionFailed sends a HTTP response with status code 412.
+func (ctx *PatchUserContext) PreconditionFailed(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 412, r)
+}
+
+// UnsupportedMediaType sends a HTTP response with status code 415.
+func (ctx *PatchUserContext) UnsupportedMediaType(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 415, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *PatchUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// PatchMeUserContext provides the user patchMe action context.
+type PatchMeUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	IfMatch *string
+	Payload PatchMeUserPayload
+}
+
+// NewPatchMeUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller patchMe action.
+func NewPatchMeUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*PatchMeUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := PatchMeUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	headerIfMatch := req.Header["If-Match"]
+	if len(headerIfMatch) > 0 {
+		rawIfMatch := headerIfMatch[0]
+		req.Params["If-Match"] = []string{rawIfMatch}
+		rctx.IfMatch = &rawIfMatch
+	}
+	return &rctx, err
+}
+
+// PatchMeUserPayload is the user patchMe action payload.
+type PatchMeUserPayload map[string]interface{}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *PatchMeUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *PatchMeUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *PatchMeUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *PatchMeUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// PreconditionFailed sends a HTTP response with status code 412.
+func (ctx *PatchMeUserContext) PreconditionFailed(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 412, r)
+}
+
+// UnsupportedMediaType sends a HTTP response with status code 415.
+func (ctx *PatchMeUserContext) UnsupportedMediaType(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 415, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *PatchMeUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// PurgeUserContext provides the user purge action context.
+type PurgeUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	UserID string
+}
+
+// NewPurgeUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller purge action.
+func NewPurgeUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*PurgeUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := PurgeUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// NoContent sends a HTTP response with status code 204.
+func (ctx *PurgeUserContext) NoContent() error {
+	ctx.ResponseData.WriteHeader(204)
+	return nil
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *PurgeUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *PurgeUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *PurgeUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *PurgeUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// ReactivateUserContext provides the user reactivate action context.
+type ReactivateUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	UserID  string
+	Payload *StatusChangePayload
+}
+
+// NewReactivateUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller reactivate action.
+func NewReactivateUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ReactivateUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ReactivateUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ReactivateUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *ReactivateUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *ReactivateUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *ReactivateUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// RegisterUserContext provides the user register action context.
+type RegisterUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *RegisterPayload
+}
+
+// NewRegisterUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller register action.
+func NewRegisterUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*RegisterUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RegisterUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// Created sends a HTTP response with status code 201.
+func (ctx *RegisterUserContext) Created(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 201, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *RegisterUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *RegisterUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *RegisterUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// RequestEmailChangeUserContext provides the user requestEmailChange action context.
+type RequestEmailChangeUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *ChangeEmailPayload
+}
+
+// NewRequestEmailChangeUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller requestEmailChange action.
+func NewRequestEmailChangeUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*RequestEmailChangeUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RequestEmailChangeUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *RequestEmailChangeUserContext) OK(resp []byte) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+	}
+	ctx.ResponseData.WriteHeader(200)
+	_, err := ctx.ResponseData.Write(resp)
+	return err
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *RequestEmailChangeUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *RequestEmailChangeUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *RequestEmailChangeUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *RequestEmailChangeUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// RequestMagicLinkUserContext provides the user requestMagicLink action context.
+type RequestMagicLinkUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *EmailPayload
+}
+
+// NewRequestMagicLinkUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller requestMagicLink action.
+func NewRequestMagicLinkUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*RequestMagicLinkUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RequestMagicLinkUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *RequestMagicLinkUserContext) OK(resp []byte) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+	}
+	ctx.ResponseData.WriteHeader(200)
+	_, err := ctx.ResponseData.Write(resp)
+	return err
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *RequestMagicLinkUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *RequestMagicLinkUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// ResetMfaUserContext provides the user resetMfa action context.
+type ResetMfaUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	UserID string
+}
+
+// NewResetMfaUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller resetMfa action.
+func NewResetMfaUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ResetMfaUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ResetMfaUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ResetMfaUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *ResetMfaUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *ResetMfaUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *ResetMfaUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// ResetVerificationTokenUserContext provides the user resetVerificationToken action context.
+type ResetVerificationTokenUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *EmailPayload
+}
+
+// NewResetVerificationTokenUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller resetVerificationToken action.
+func NewResetVerificationTokenUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*ResetVerificationTokenUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ResetVerificationTokenUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ResetVerificationTokenUserContext) OK(r *ResetToken) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "resettokenmedia")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *ResetVerificationTokenUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *ResetVerificationTokenUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *ResetVerificationTokenUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// RestoreUserContext provides the user restore action context.
+type RestoreUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	UserID string
+}
+
+// NewRestoreUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller restore action.
+func NewRestoreUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*RestoreUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RestoreUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *RestoreUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *RestoreUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *RestoreUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *RestoreUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// RevokeInvitationUserContext provides the user revokeInvitation action context.
+type RevokeInvitationUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	InvitationID string
+}
+
+// NewRevokeInvitationUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller revokeInvitation action.
+func NewRevokeInvitationUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*RevokeInvitationUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RevokeInvitationUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramInvitationID := req.Params["invitationId"]
+	if len(paramInvitationID) > 0 {
+		rawInvitationID := paramInvitationID[0]
+		rctx.InvitationID = rawInvitationID
+	}
+	return &rctx, err
+}
+
+// NoContent sends a HTTP response with status code 204.
+func (ctx *RevokeInvitationUserContext) NoContent() error {
+	ctx.ResponseData.WriteHeader(204)
+	return nil
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *RevokeInvitationUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *RevokeInvitationUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *RevokeInvitationUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// RevokeTokenUserContext provides the user revokeToken action context.
+type RevokeTokenUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	TokenID string
+}
+
+// NewRevokeTokenUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller revokeToken action.
+func NewRevokeTokenUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*RevokeTokenUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RevokeTokenUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramTokenID := req.Params["tokenId"]
+	if len(paramTokenID) > 0 {
+		rawTokenID := paramTokenID[0]
+		rctx.TokenID = rawTokenID
+	}
+	return &rctx, err
+}
+
+// NoContent sends a HTTP response with status code 204.
+func (ctx *RevokeTokenUserContext) NoContent() error {
+	ctx.ResponseData.WriteHeader(204)
+	return nil
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *RevokeTokenUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *RevokeTokenUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *RevokeTokenUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// SuspendUserContext provides the user suspend action context.
+type SuspendUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	UserID  string
+	Payload *StatusChangePayload
+}
+
+// NewSuspendUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller suspend action.
+func NewSuspendUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*SuspendUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := SuspendUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *SuspendUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *SuspendUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *SuspendUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *SuspendUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// UnlockUserContext provides the user unlock action context.
+type UnlockUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	UserID  string
+	Payload *StatusChangePayload
+}
+
+// NewUnlockUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller unlock action.
+func NewUnlockUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*UnlockUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := UnlockUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramUserID := req.Params["userId"]
+	if len(paramUserID) > 0 {
+		rawUserID := paramUserID[0]
+		rctx.UserID = rawUserID
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *UnlockUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *UnlockUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *UnlockUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *UnlockUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
+// UpdateUserContext provides the user update action context.
+type UpdateUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	IfMatch *string
+	UserID  string
+	Payload *UpdateUserPayload
+}
+
+// NewUpdateUserContext parses the incoming request URL and body, performs validations and creates the
 // context used by the user controller update action.
 func NewUpdateUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*UpdateUserContext, error) {
 	var err error
@@ -565,6 +2299,12 @@ func NewUpdateUserContext(ctx context.Context, r *http.Request, service *goa.Ser
 	req := goa.ContextRequest(ctx)
 	req.Request = r
 	rctx := UpdateUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	headerIfMatch := req.Header["If-Match"]
+	if len(headerIfMatch) > 0 {
+		rawIfMatch := headerIfMatch[0]
+		req.Params["If-Match"] = []string{rawIfMatch}
+		rctx.IfMatch = &rawIfMatch
+	}
 	paramUserID := req.Params["userId"]
 	if len(paramUserID) > 0 {
 		rawUserID := paramUserID[0]
@@ -589,6 +2329,14 @@ func (ctx *UpdateUserContext) BadRequest(r error) error {
 	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
 }
 
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *UpdateUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
 // NotFound sends a HTTP response with status code 404.
 func (ctx *UpdateUserContext) NotFound(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
@@ -597,6 +2345,14 @@ func (ctx *UpdateUserContext) NotFound(r error) error {
 	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
 }
 
+// PreconditionFailed sends a HTTP response with status code 412.
+func (ctx *UpdateUserContext) PreconditionFailed(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 412, r)
+}
+
 // InternalServerError sends a HTTP response with status code 500.
 func (ctx *UpdateUserContext) InternalServerError(r error) error {
 	if ctx.ResponseData.Header().Get("Content-Type") == "" {
@@ -605,6 +2361,81 @@ func (ctx *UpdateUserContext) InternalServerError(r error) error {
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
 
+// UpdateMeUserContext provides the user updateMe action context.
+type UpdateMeUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	IfMatch *string
+	Payload *UpdateMePayload
+}
+
+// NewUpdateMeUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller updateMe action.
+func NewUpdateMeUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*UpdateMeUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := UpdateMeUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	headerIfMatch := req.Header["If-Match"]
+	if len(headerIfMatch) > 0 {
+		rawIfMatch := headerIfMatch[0]
+		req.Params["If-Match"] = []string{rawIfMatch}
+		rctx.IfMatch = &rawIfMatch
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *UpdateMeUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *UpdateMeUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *UpdateMeUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *UpdateMeUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// PreconditionFailed sends a HTTP response with status code 412.
+func (ctx *UpdateMeUserContext) PreconditionFailed(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 412, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *UpdateMeUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
+
 // VerifyUserContext provides the user verify action context.
 type VerifyUserContext struct {
 	context.Context
@@ -663,3 +2494,71 @@ func (ctx *VerifyUserContext) InternalServerError(r error) error {
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
 }
+
+// VerifyMfaUserContext provides the user verifyMfa action context.
+type VerifyMfaUserContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Payload *MFAVerifyPayload
+}
+
+// NewVerifyMfaUserContext parses the incoming request URL and body, performs validations and creates the
+// context used by the user controller verifyMfa action.
+func NewVerifyMfaUserContext(ctx context.Context, r *http.Request, service *goa.Service) (*VerifyMfaUserContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := VerifyMfaUserContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *VerifyMfaUserContext) OK(r *Users) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.user+json")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *VerifyMfaUserContext) BadRequest(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// Forbidden sends a HTTP response with status code 403.
+func (ctx *VerifyMfaUserContext) Forbidden(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 403, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *VerifyMfaUserContext) NotFound(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 404, r)
+}
+
+// Locked sends a HTTP response with status code 423.
+func (ctx *VerifyMfaUserContext) Locked(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 423, r)
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *VerifyMfaUserContext) InternalServerError(r error) error {
+	if ctx.ResponseData.Header().Get("Content-Type") == "" {
+		ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 500, r)
+}
@@ -59,33 +59,179 @@ func MountSwaggerController(service *goa.Service, ctrl SwaggerController) {
 // UserController is the controller interface for the User actions.
 type UserController interface {
 	goa.Muxer
+	AcceptInvitation(*AcceptInvitationUserContext) error
+	ChangePassword(*ChangePasswordUserContext) error
+	ConfirmEmailChange(*ConfirmEmailChangeUserContext) error
+	ConfirmTotp(*ConfirmTotpUserContext) error
 	Create(*CreateUserContext) error
+	CreateInvitation(*CreateInvitationUserContext) error
+	CreateToken(*CreateTokenUserContext) error
+	Deactivate(*DeactivateUserContext) error
+	Delete(*DeleteUserContext) error
+	EnrollTotp(*EnrollTotpUserContext) error
 	Find(*FindUserContext) error
 	FindByEmail(*FindByEmailUserContext) error
+	FindByMagicLink(*FindByMagicLinkUserContext) error
+	FindByToken(*FindByTokenUserContext) error
 	FindUsers(*FindUsersUserContext) error
 	ForgotPassword(*ForgotPasswordUserContext) error
 	ForgotPasswordUpdate(*ForgotPasswordUpdateUserContext) error
 	Get(*GetUserContext) error
 	GetAll(*GetAllUserContext) error
+	GetDuplicates(*GetDuplicatesUserContext) error
+	GetLogins(*GetLoginsUserContext) error
 	GetMe(*GetMeUserContext) error
+	GetMyLogins(*GetMyLoginsUserContext) error
+	ListInvitations(*ListInvitationsUserContext) error
+	ListTokens(*ListTokensUserContext) error
+	MergeUsers(*MergeUsersUserContext) error
+	Patch(*PatchUserContext) error
+	PatchMe(*PatchMeUserContext) error
+	Purge(*PurgeUserContext) error
+	Reactivate(*ReactivateUserContext) error
+	Register(*RegisterUserContext) error
+	RequestEmailChange(*RequestEmailChangeUserContext) error
+	RequestMagicLink(*RequestMagicLinkUserContext) error
+	ResetMfa(*ResetMfaUserContext) error
 	ResetVerificationToken(*ResetVerificationTokenUserContext) error
+	Restore(*RestoreUserContext) error
+	RevokeInvitation(*RevokeInvitationUserContext) error
+	RevokeToken(*RevokeTokenUserContext) error
+	Suspend(*SuspendUserContext) error
+	Unlock(*UnlockUserContext) error
 	Update(*UpdateUserContext) error
+	UpdateMe(*UpdateMeUserContext) error
 	Verify(*VerifyUserContext) error
+	VerifyMfa(*VerifyMfaUserContext) error
 }
 
 // MountUserController "mounts" a User resource controller on the given service.
 func MountUserController(service *goa.Service, ctrl UserController) {
 	initService(service)
 	var h goa.Handler
+	service.Mux.Handle("OPTIONS", "/users/invitations/accept", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/me/password", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/email/confirm", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/me/mfa/totp/confirm", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
 	service.Mux.Handle("OPTIONS", "/users", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/invitations", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/me/tokens", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId/deactivate", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/me/mfa/totp", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
 	service.Mux.Handle("OPTIONS", "/users/find", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
 	service.Mux.Handle("OPTIONS", "/users/find/email", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/find/magic-link", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/find/token", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
 	service.Mux.Handle("OPTIONS", "/users/list", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
 	service.Mux.Handle("OPTIONS", "/users/password/forgot", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
-	service.Mux.Handle("OPTIONS", "/users/:userId", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/duplicates", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId/logins", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
 	service.Mux.Handle("OPTIONS", "/users/me", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/me/logins", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/duplicates/merge", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId/purge", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId/reactivate", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/register", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/me/email", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/magic-link", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId/mfa", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
 	service.Mux.Handle("OPTIONS", "/users/verification/reset", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId/restore", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/invitations/:invitationId", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/me/tokens/:tokenId", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId/suspend", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/:userId/unlock", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
 	service.Mux.Handle("OPTIONS", "/users/verify", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+	service.Mux.Handle("OPTIONS", "/users/mfa/verify", ctrl.MuxHandler("preflight", handleUserOrigin(cors.HandlePreflight()), nil))
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewAcceptInvitationUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*AcceptInvitationPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.AcceptInvitation(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/invitations/accept", ctrl.MuxHandler("acceptInvitation", h, unmarshalAcceptInvitationUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "AcceptInvitation", "route", "POST /users/invitations/accept")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewChangePasswordUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*ChangePasswordPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.ChangePassword(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/me/password", ctrl.MuxHandler("changePassword", h, unmarshalChangePasswordUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "ChangePassword", "route", "POST /users/me/password")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewConfirmEmailChangeUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*TokenPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.ConfirmEmailChange(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/email/confirm", ctrl.MuxHandler("confirmEmailChange", h, unmarshalConfirmEmailChangeUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "ConfirmEmailChange", "route", "POST /users/email/confirm")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewConfirmTotpUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*MFACodePayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.ConfirmTotp(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/me/mfa/totp/confirm", ctrl.MuxHandler("confirmTotp", h, unmarshalConfirmTotpUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "ConfirmTotp", "route", "POST /users/me/mfa/totp/confirm")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -109,6 +255,102 @@ func MountUserController(service *goa.Service, ctrl UserController) {
 	service.Mux.Handle("POST", "/users", ctrl.MuxHandler("create", h, unmarshalCreateUserPayload))
 	service.LogInfo("mount", "ctrl", "User", "action", "Create", "route", "POST /users")
 
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewCreateInvitationUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*InvitationPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.CreateInvitation(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/invitations", ctrl.MuxHandler("createInvitation", h, unmarshalCreateInvitationUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "CreateInvitation", "route", "POST /users/invitations")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewCreateTokenUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*CreateAccessTokenPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.CreateToken(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/me/tokens", ctrl.MuxHandler("createToken", h, unmarshalCreateTokenUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "CreateToken", "route", "POST /users/me/tokens")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewDeactivateUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*StatusChangePayload)
+		}
+		return ctrl.Deactivate(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/:userId/deactivate", ctrl.MuxHandler("deactivate", h, unmarshalDeactivateUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "Deactivate", "route", "POST /users/:userId/deactivate")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewDeleteUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Delete(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("DELETE", "/users/:userId", ctrl.MuxHandler("delete", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "Delete", "route", "DELETE /users/:userId")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewEnrollTotpUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.EnrollTotp(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/me/mfa/totp", ctrl.MuxHandler("enrollTotp", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "EnrollTotp", "route", "POST /users/me/mfa/totp")
+
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
 		if err := goa.ContextError(ctx); err != nil {
@@ -153,6 +395,50 @@ func MountUserController(service *goa.Service, ctrl UserController) {
 	service.Mux.Handle("POST", "/users/find/email", ctrl.MuxHandler("findByEmail", h, unmarshalFindByEmailUserPayload))
 	service.LogInfo("mount", "ctrl", "User", "action", "FindByEmail", "route", "POST /users/find/email")
 
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewFindByMagicLinkUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*TokenPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.FindByMagicLink(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/find/magic-link", ctrl.MuxHandler("findByMagicLink", h, unmarshalFindByMagicLinkUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "FindByMagicLink", "route", "POST /users/find/magic-link")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewFindByTokenUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*AccessTokenPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.FindByToken(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/find/token", ctrl.MuxHandler("findByToken", h, unmarshalFindByTokenUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "FindByToken", "route", "POST /users/find/token")
+
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
 		if err := goa.ContextError(ctx); err != nil {
@@ -251,6 +537,38 @@ func MountUserController(service *goa.Service, ctrl UserController) {
 	service.Mux.Handle("GET", "/users", ctrl.MuxHandler("getAll", h, nil))
 	service.LogInfo("mount", "ctrl", "User", "action", "GetAll", "route", "GET /users")
 
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewGetDuplicatesUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.GetDuplicates(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("GET", "/users/duplicates", ctrl.MuxHandler("getDuplicates", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "GetDuplicates", "route", "GET /users/duplicates")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewGetLoginsUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.GetLogins(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("GET", "/users/:userId/logins", ctrl.MuxHandler("getLogins", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "GetLogins", "route", "GET /users/:userId/logins")
+
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
 		if err := goa.ContextError(ctx); err != nil {
@@ -273,21 +591,69 @@ func MountUserController(service *goa.Service, ctrl UserController) {
 			return err
 		}
 		// Build the context
-		rctx, err := NewResetVerificationTokenUserContext(ctx, req, service)
+		rctx, err := NewGetMyLoginsUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.GetMyLogins(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("GET", "/users/me/logins", ctrl.MuxHandler("getMyLogins", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "GetMyLogins", "route", "GET /users/me/logins")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewListInvitationsUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.ListInvitations(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("GET", "/users/invitations", ctrl.MuxHandler("listInvitations", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "ListInvitations", "route", "GET /users/invitations")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewListTokensUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.ListTokens(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("GET", "/users/me/tokens", ctrl.MuxHandler("listTokens", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "ListTokens", "route", "GET /users/me/tokens")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewMergeUsersUserContext(ctx, req, service)
 		if err != nil {
 			return err
 		}
 		// Build the payload
 		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
-			rctx.Payload = rawPayload.(*EmailPayload)
+			rctx.Payload = rawPayload.(*MergeUsersPayload)
 		} else {
 			return goa.MissingPayloadError()
 		}
-		return ctrl.ResetVerificationToken(rctx)
+		return ctrl.MergeUsers(rctx)
 	}
 	h = handleUserOrigin(h)
-	service.Mux.Handle("POST", "/users/verification/reset", ctrl.MuxHandler("resetVerificationToken", h, unmarshalResetVerificationTokenUserPayload))
-	service.LogInfo("mount", "ctrl", "User", "action", "ResetVerificationToken", "route", "POST /users/verification/reset")
+	service.Mux.Handle("POST", "/users/duplicates/merge", ctrl.MuxHandler("mergeUsers", h, unmarshalMergeUsersUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "MergeUsers", "route", "POST /users/duplicates/merge")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -295,21 +661,21 @@ func MountUserController(service *goa.Service, ctrl UserController) {
 			return err
 		}
 		// Build the context
-		rctx, err := NewUpdateUserContext(ctx, req, service)
+		rctx, err := NewPatchUserContext(ctx, req, service)
 		if err != nil {
 			return err
 		}
 		// Build the payload
 		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
-			rctx.Payload = rawPayload.(*UpdateUserPayload)
+			rctx.Payload = rawPayload.(PatchUserPayload)
 		} else {
 			return goa.MissingPayloadError()
 		}
-		return ctrl.Update(rctx)
+		return ctrl.Patch(rctx)
 	}
 	h = handleUserOrigin(h)
-	service.Mux.Handle("PUT", "/users/:userId", ctrl.MuxHandler("update", h, unmarshalUpdateUserPayload))
-	service.LogInfo("mount", "ctrl", "User", "action", "Update", "route", "PUT /users/:userId")
+	service.Mux.Handle("PATCH", "/users/:userId", ctrl.MuxHandler("patch", h, unmarshalPatchUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "Patch", "route", "PATCH /users/:userId")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -317,15 +683,331 @@ func MountUserController(service *goa.Service, ctrl UserController) {
 			return err
 		}
 		// Build the context
-		rctx, err := NewVerifyUserContext(ctx, req, service)
+		rctx, err := NewPatchMeUserContext(ctx, req, service)
 		if err != nil {
 			return err
 		}
-		return ctrl.Verify(rctx)
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(PatchMeUserPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.PatchMe(rctx)
 	}
 	h = handleUserOrigin(h)
-	service.Mux.Handle("GET", "/users/verify", ctrl.MuxHandler("verify", h, nil))
-	service.LogInfo("mount", "ctrl", "User", "action", "Verify", "route", "GET /users/verify")
+	service.Mux.Handle("PATCH", "/users/me", ctrl.MuxHandler("patchMe", h, unmarshalPatchMeUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "PatchMe", "route", "PATCH /users/me")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewPurgeUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Purge(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("DELETE", "/users/:userId/purge", ctrl.MuxHandler("purge", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "Purge", "route", "DELETE /users/:userId/purge")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewReactivateUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*StatusChangePayload)
+		}
+		return ctrl.Reactivate(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/:userId/reactivate", ctrl.MuxHandler("reactivate", h, unmarshalReactivateUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "Reactivate", "route", "POST /users/:userId/reactivate")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRegisterUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*RegisterPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.Register(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/register", ctrl.MuxHandler("register", h, unmarshalRegisterUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "Register", "route", "POST /users/register")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRequestEmailChangeUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*ChangeEmailPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.RequestEmailChange(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/me/email", ctrl.MuxHandler("requestEmailChange", h, unmarshalRequestEmailChangeUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "RequestEmailChange", "route", "POST /users/me/email")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRequestMagicLinkUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*EmailPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.RequestMagicLink(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/magic-link", ctrl.MuxHandler("requestMagicLink", h, unmarshalRequestMagicLinkUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "RequestMagicLink", "route", "POST /users/magic-link")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewResetMfaUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.ResetMfa(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("DELETE", "/users/:userId/mfa", ctrl.MuxHandler("resetMfa", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "ResetMfa", "route", "DELETE /users/:userId/mfa")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewResetVerificationTokenUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*EmailPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.ResetVerificationToken(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/verification/reset", ctrl.MuxHandler("resetVerificationToken", h, unmarshalResetVerificationTokenUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "ResetVerificationToken", "route", "POST /users/verification/reset")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRestoreUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Restore(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/:userId/restore", ctrl.MuxHandler("restore", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "Restore", "route", "POST /users/:userId/restore")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRevokeInvitationUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.RevokeInvitation(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("DELETE", "/users/invitations/:invitationId", ctrl.MuxHandler("revokeInvitation", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "RevokeInvitation", "route", "DELETE /users/invitations/:invitationId")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRevokeTokenUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.RevokeToken(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("DELETE", "/users/me/tokens/:tokenId", ctrl.MuxHandler("revokeToken", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "RevokeToken", "route", "DELETE /users/me/tokens/:tokenId")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewSuspendUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*StatusChangePayload)
+		}
+		return ctrl.Suspend(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/:userId/suspend", ctrl.MuxHandler("suspend", h, unmarshalSuspendUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "Suspend", "route", "POST /users/:userId/suspend")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewUnlockUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*StatusChangePayload)
+		}
+		return ctrl.Unlock(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/:userId/unlock", ctrl.MuxHandler("unlock", h, unmarshalUnlockUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "Unlock", "route", "POST /users/:userId/unlock")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewUpdateUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*UpdateUserPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.Update(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("PUT", "/users/:userId", ctrl.MuxHandler("update", h, unmarshalUpdateUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "Update", "route", "PUT /users/:userId")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewUpdateMeUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*UpdateMePayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.UpdateMe(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("PUT", "/users/me", ctrl.MuxHandler("updateMe", h, unmarshalUpdateMeUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "UpdateMe", "route", "PUT /users/me")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewVerifyUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Verify(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("GET", "/users/verify", ctrl.MuxHandler("verify", h, nil))
+	service.LogInfo("mount", "ctrl", "User", "action", "Verify", "route", "GET /users/verify")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewVerifyMfaUserContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*MFAVerifyPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.VerifyMfa(rctx)
+	}
+	h = handleUserOrigin(h)
+	service.Mux.Handle("POST", "/users/mfa/verify", ctrl.MuxHandler("verifyMfa", h, unmarshalVerifyMfaUserPayload))
+	service.LogInfo("mount", "ctrl", "User", "action", "VerifyMfa", "route", "POST /users/mfa/verify")
 }
 
 // handleUserOrigin applies the CORS response headers corresponding to the origin.
@@ -352,6 +1034,66 @@ func handleUserOrigin(h goa.Handler) goa.Handler {
 	}
 }
 
+// unmarshalAcceptInvitationUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalAcceptInvitationUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &acceptInvitationPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalChangePasswordUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalChangePasswordUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &changePasswordPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalConfirmEmailChangeUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalConfirmEmailChangeUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &tokenPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalConfirmTotpUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalConfirmTotpUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &mFACodePayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
 // unmarshalCreateUserPayload unmarshals the request body into the context request data Payload field.
 func unmarshalCreateUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
 	payload := &createUserPayload{}
@@ -368,6 +1110,51 @@ func unmarshalCreateUserPayload(ctx context.Context, service *goa.Service, req *
 	return nil
 }
 
+// unmarshalCreateInvitationUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalCreateInvitationUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &invitationPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalCreateTokenUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalCreateTokenUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &createAccessTokenPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalDeactivateUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalDeactivateUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &statusChangePayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
 // unmarshalFindUserPayload unmarshals the request body into the context request data Payload field.
 func unmarshalFindUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
 	payload := &credentials{}
@@ -398,12 +1185,43 @@ func unmarshalFindByEmailUserPayload(ctx context.Context, service *goa.Service,
 	return nil
 }
 
+// unmarshalFindByMagicLinkUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalFindByMagicLinkUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &tokenPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalFindByTokenUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalFindByTokenUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &accessTokenPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
 // unmarshalFindUsersUserPayload unmarshals the request body into the context request data Payload field.
 func unmarshalFindUsersUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
 	payload := &filterPayload{}
 	if err := service.DecodeRequest(req, payload); err != nil {
 		return err
 	}
+	payload.Finalize()
 	if err := payload.Validate(); err != nil {
 		// Initialize payload with private data structure so it can be logged
 		goa.ContextRequest(ctx).Payload = payload
@@ -443,6 +1261,101 @@ func unmarshalForgotPasswordUpdateUserPayload(ctx context.Context, service *goa.
 	return nil
 }
 
+// unmarshalMergeUsersUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalMergeUsersUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &mergeUsersPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalPatchUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalPatchUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	var payload PatchUserPayload
+	if err := service.DecodeRequest(req, &payload); err != nil {
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload
+	return nil
+}
+
+// unmarshalPatchMeUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalPatchMeUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	var payload PatchMeUserPayload
+	if err := service.DecodeRequest(req, &payload); err != nil {
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload
+	return nil
+}
+
+// unmarshalReactivateUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalReactivateUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &statusChangePayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalRegisterUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalRegisterUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &registerPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalRequestEmailChangeUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalRequestEmailChangeUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &changeEmailPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalRequestMagicLinkUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalRequestMagicLinkUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &emailPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
 // unmarshalResetVerificationTokenUserPayload unmarshals the request body into the context request data Payload field.
 func unmarshalResetVerificationTokenUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
 	payload := &emailPayload{}
@@ -458,6 +1371,36 @@ func unmarshalResetVerificationTokenUserPayload(ctx context.Context, service *go
 	return nil
 }
 
+// unmarshalSuspendUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalSuspendUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &statusChangePayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalUnlockUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalUnlockUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &statusChangePayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
 // unmarshalUpdateUserPayload unmarshals the request body into the context request data Payload field.
 func unmarshalUpdateUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
 	payload := &updateUserPayload{}
@@ -473,3 +1416,33 @@ func unmarshalUpdateUserPayload(ctx context.Context, service *goa.Service, req *
 	goa.ContextRequest(ctx).Payload = payload.Publicize()
 	return nil
 }
+
+// unmarshalUpdateMeUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalUpdateMeUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &updateMePayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// unmarshalVerifyMfaUserPayload unmarshals the request body into the context request data Payload field.
+func unmarshalVerifyMfaUserPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &mFAVerifyPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
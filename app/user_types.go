@@ -15,6 +15,275 @@ import (
 	"unicode/utf8"
 )
 
+// Accept invitation payload
+type acceptInvitationPayload struct {
+	// Password of the new user
+	Password *string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Profile attributes of the new user, as declared in the profile schema of the service
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
+	// Invitation token
+	Token *string `form:"token,omitempty" json:"token,omitempty" yaml:"token,omitempty" xml:"token,omitempty"`
+}
+
+// Validate validates the acceptInvitationPayload type instance.
+func (ut *acceptInvitationPayload) Validate() (err error) {
+	if ut.Token == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "token"))
+	}
+	if ut.Password == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "password"))
+	}
+	return
+}
+
+// Publicize creates AcceptInvitationPayload from acceptInvitationPayload
+func (ut *acceptInvitationPayload) Publicize() *AcceptInvitationPayload {
+	var pub AcceptInvitationPayload
+	if ut.Password != nil {
+		pub.Password = *ut.Password
+	}
+	if ut.Profile != nil {
+		pub.Profile = ut.Profile
+	}
+	if ut.Token != nil {
+		pub.Token = *ut.Token
+	}
+	return &pub
+}
+
+// Accept invitation payload
+type AcceptInvitationPayload struct {
+	// Password of the new user
+	Password string `form:"password" json:"password" yaml:"password" xml:"password"`
+	// Profile attributes of the new user, as declared in the profile schema of the service
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
+	// Invitation token
+	Token string `form:"token" json:"token" yaml:"token" xml:"token"`
+}
+
+// Validate validates the AcceptInvitationPayload type instance.
+func (ut *AcceptInvitationPayload) Validate() (err error) {
+	if ut.Token == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "token"))
+	}
+	if ut.Password == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "password"))
+	}
+	return
+}
+
+// Access token payload
+type accessTokenPayload struct {
+	// Personal access token
+	Token *string `form:"token,omitempty" json:"token,omitempty" yaml:"token,omitempty" xml:"token,omitempty"`
+}
+
+// Validate validates the accessTokenPayload type instance.
+func (ut *accessTokenPayload) Validate() (err error) {
+	if ut.Token == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "token"))
+	}
+	return
+}
+
+// Publicize creates AccessTokenPayload from accessTokenPayload
+func (ut *accessTokenPayload) Publicize() *AccessTokenPayload {
+	var pub AccessTokenPayload
+	if ut.Token != nil {
+		pub.Token = *ut.Token
+	}
+	return &pub
+}
+
+// Access token payload
+type AccessTokenPayload struct {
+	// Personal access token
+	Token string `form:"token" json:"token" yaml:"token" xml:"token"`
+}
+
+// Validate validates the AccessTokenPayload type instance.
+func (ut *AccessTokenPayload) Validate() (err error) {
+	if ut.Token == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "token"))
+	}
+	return
+}
+
+// Change email payload
+type changeEmailPayload struct {
+	// Current password
+	CurrentPassword *string `form:"currentPassword,omitempty" json:"currentPassword,omitempty" yaml:"currentPassword,omitempty" xml:"currentPassword,omitempty"`
+	// New email
+	Email *string `form:"email,omitempty" json:"email,omitempty" yaml:"email,omitempty" xml:"email,omitempty"`
+}
+
+// Validate validates the changeEmailPayload type instance.
+func (ut *changeEmailPayload) Validate() (err error) {
+	if ut.Email == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "email"))
+	}
+	if ut.CurrentPassword == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "currentPassword"))
+	}
+	if ut.Email != nil {
+		if err2 := goa.ValidateFormat(goa.FormatEmail, *ut.Email); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFormatError(`request.email`, *ut.Email, goa.FormatEmail, err2))
+		}
+	}
+	return
+}
+
+// Publicize creates ChangeEmailPayload from changeEmailPayload
+func (ut *changeEmailPayload) Publicize() *ChangeEmailPayload {
+	var pub ChangeEmailPayload
+	if ut.CurrentPassword != nil {
+		pub.CurrentPassword = *ut.CurrentPassword
+	}
+	if ut.Email != nil {
+		pub.Email = *ut.Email
+	}
+	return &pub
+}
+
+// Change email payload
+type ChangeEmailPayload struct {
+	// Current password
+	CurrentPassword string `form:"currentPassword" json:"currentPassword" yaml:"currentPassword" xml:"currentPassword"`
+	// New email
+	Email string `form:"email" json:"email" yaml:"email" xml:"email"`
+}
+
+// Validate validates the ChangeEmailPayload type instance.
+func (ut *ChangeEmailPayload) Validate() (err error) {
+	if ut.Email == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "email"))
+	}
+	if ut.CurrentPassword == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "currentPassword"))
+	}
+	if err2 := goa.ValidateFormat(goa.FormatEmail, ut.Email); err2 != nil {
+		err = goa.MergeErrors(err, goa.InvalidFormatError(`type.email`, ut.Email, goa.FormatEmail, err2))
+	}
+	return
+}
+
+// Change password payload
+type changePasswordPayload struct {
+	// Current password
+	CurrentPassword *string `form:"currentPassword,omitempty" json:"currentPassword,omitempty" yaml:"currentPassword,omitempty" xml:"currentPassword,omitempty"`
+	// New password
+	NewPassword *string `form:"newPassword,omitempty" json:"newPassword,omitempty" yaml:"newPassword,omitempty" xml:"newPassword,omitempty"`
+}
+
+// Validate validates the changePasswordPayload type instance.
+func (ut *changePasswordPayload) Validate() (err error) {
+	if ut.CurrentPassword == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "currentPassword"))
+	}
+	if ut.NewPassword == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "newPassword"))
+	}
+	return
+}
+
+// Publicize creates ChangePasswordPayload from changePasswordPayload
+func (ut *changePasswordPayload) Publicize() *ChangePasswordPayload {
+	var pub ChangePasswordPayload
+	if ut.CurrentPassword != nil {
+		pub.CurrentPassword = *ut.CurrentPassword
+	}
+	if ut.NewPassword != nil {
+		pub.NewPassword = *ut.NewPassword
+	}
+	return &pub
+}
+
+// Change password payload
+type ChangePasswordPayload struct {
+	// Current password
+	CurrentPassword string `form:"currentPassword" json:"currentPassword" yaml:"currentPassword" xml:"currentPassword"`
+	// New password
+	NewPassword string `form:"newPassword" json:"newPassword" yaml:"newPassword" xml:"newPassword"`
+}
+
+// Validate validates the ChangePasswordPayload type instance.
+func (ut *ChangePasswordPayload) Validate() (err error) {
+	if ut.CurrentPassword == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "currentPassword"))
+	}
+	if ut.NewPassword == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "newPassword"))
+	}
+	return
+}
+
+// Create access token payload
+type createAccessTokenPayload struct {
+	// Expiry time (milliseconds since epoch). The token does not expire if not set.
+	ExpiresAt *int `form:"expiresAt,omitempty" json:"expiresAt,omitempty" yaml:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+	// Name of the access token
+	Name *string `form:"name,omitempty" json:"name,omitempty" yaml:"name,omitempty" xml:"name,omitempty"`
+	// Scopes of the access token
+	Scopes []string `form:"scopes,omitempty" json:"scopes,omitempty" yaml:"scopes,omitempty" xml:"scopes,omitempty"`
+}
+
+// Validate validates the createAccessTokenPayload type instance.
+func (ut *createAccessTokenPayload) Validate() (err error) {
+	if ut.Name == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "name"))
+	}
+	if ut.Name != nil {
+		if utf8.RuneCountInString(*ut.Name) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.name`, *ut.Name, utf8.RuneCountInString(*ut.Name), 1, true))
+		}
+	}
+	if ut.Name != nil {
+		if utf8.RuneCountInString(*ut.Name) > 100 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.name`, *ut.Name, utf8.RuneCountInString(*ut.Name), 100, false))
+		}
+	}
+	return
+}
+
+// Publicize creates CreateAccessTokenPayload from createAccessTokenPayload
+func (ut *createAccessTokenPayload) Publicize() *CreateAccessTokenPayload {
+	var pub CreateAccessTokenPayload
+	if ut.ExpiresAt != nil {
+		pub.ExpiresAt = ut.ExpiresAt
+	}
+	if ut.Name != nil {
+		pub.Name = *ut.Name
+	}
+	if ut.Scopes != nil {
+		pub.Scopes = ut.Scopes
+	}
+	return &pub
+}
+
+// Create access token payload
+type CreateAccessTokenPayload struct {
+	// Expiry time (milliseconds since epoch). The token does not expire if not set.
+	ExpiresAt *int `form:"expiresAt,omitempty" json:"expiresAt,omitempty" yaml:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+	// Name of the access token
+	Name string `form:"name" json:"name" yaml:"name" xml:"name"`
+	// Scopes of the access token
+	Scopes []string `form:"scopes,omitempty" json:"scopes,omitempty" yaml:"scopes,omitempty" xml:"scopes,omitempty"`
+}
+
+// Validate validates the CreateAccessTokenPayload type instance.
+func (ut *CreateAccessTokenPayload) Validate() (err error) {
+	if ut.Name == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "name"))
+	}
+	if utf8.RuneCountInString(ut.Name) < 1 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError(`type.name`, ut.Name, utf8.RuneCountInString(ut.Name), 1, true))
+	}
+	if utf8.RuneCountInString(ut.Name) > 100 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError(`type.name`, ut.Name, utf8.RuneCountInString(ut.Name), 100, false))
+	}
+	return
+}
+
 // CreateUserPayload
 type createUserPayload struct {
 	// Status of user account
@@ -29,6 +298,8 @@ type createUserPayload struct {
 	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
 	// Password of user
 	Password *string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Profile attributes of user, as declared in the profile schema of the service
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
 	// Roles of user
 	Roles []string `form:"roles,omitempty" json:"roles,omitempty" yaml:"roles,omitempty" xml:"roles,omitempty"`
 	// Token for email verification
@@ -53,16 +324,6 @@ func (ut *createUserPayload) Validate() (err error) {
 			err = goa.MergeErrors(err, goa.InvalidFormatError(`request.email`, *ut.Email, goa.FormatEmail, err2))
 		}
 	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) < 6 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 6, true))
-		}
-	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) > 30 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 30, false))
-		}
-	}
 	return
 }
 
@@ -87,6 +348,9 @@ func (ut *createUserPayload) Publicize() *CreateUserPayload {
 	if ut.Password != nil {
 		pub.Password = ut.Password
 	}
+	if ut.Profile != nil {
+		pub.Profile = ut.Profile
+	}
 	if ut.Roles != nil {
 		pub.Roles = ut.Roles
 	}
@@ -110,6 +374,8 @@ type CreateUserPayload struct {
 	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
 	// Password of user
 	Password *string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Profile attributes of user, as declared in the profile schema of the service
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
 	// Roles of user
 	Roles []string `form:"roles,omitempty" json:"roles,omitempty" yaml:"roles,omitempty" xml:"roles,omitempty"`
 	// Token for email verification
@@ -124,16 +390,6 @@ func (ut *CreateUserPayload) Validate() (err error) {
 	if err2 := goa.ValidateFormat(goa.FormatEmail, ut.Email); err2 != nil {
 		err = goa.MergeErrors(err, goa.InvalidFormatError(`type.email`, ut.Email, goa.FormatEmail, err2))
 	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) < 6 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`type.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 6, true))
-		}
-	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) > 30 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`type.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 30, false))
-		}
-	}
 	return
 }
 
@@ -158,16 +414,6 @@ func (ut *credentials) Validate() (err error) {
 			err = goa.MergeErrors(err, goa.InvalidFormatError(`request.email`, *ut.Email, goa.FormatEmail, err2))
 		}
 	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) < 6 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 6, true))
-		}
-	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) > 30 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 30, false))
-		}
-	}
 	return
 }
 
@@ -202,12 +448,6 @@ func (ut *Credentials) Validate() (err error) {
 	if err2 := goa.ValidateFormat(goa.FormatEmail, ut.Email); err2 != nil {
 		err = goa.MergeErrors(err, goa.InvalidFormatError(`type.email`, ut.Email, goa.FormatEmail, err2))
 	}
-	if utf8.RuneCountInString(ut.Password) < 6 {
-		err = goa.MergeErrors(err, goa.InvalidLengthError(`type.password`, ut.Password, utf8.RuneCountInString(ut.Password), 6, true))
-	}
-	if utf8.RuneCountInString(ut.Password) > 30 {
-		err = goa.MergeErrors(err, goa.InvalidLengthError(`type.password`, ut.Password, utf8.RuneCountInString(ut.Password), 30, false))
-	}
 	return
 }
 
@@ -256,26 +496,126 @@ func (ut *EmailPayload) Validate() (err error) {
 	return
 }
 
+// filterGroup user type.
+type filterGroup struct {
+	// Filters that must all match
+	Filter []*filterProperty `form:"filter,omitempty" json:"filter,omitempty" yaml:"filter,omitempty" xml:"filter,omitempty"`
+}
+
+// Finalize sets the default values for filterGroup type instance.
+func (ut *filterGroup) Finalize() {
+	for _, e := range ut.Filter {
+		var defaultOperator = "eq"
+		if e.Operator == nil {
+			e.Operator = &defaultOperator
+		}
+	}
+}
+
+// Validate validates the filterGroup type instance.
+func (ut *filterGroup) Validate() (err error) {
+	if ut.Filter == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "filter"))
+	}
+	for _, e := range ut.Filter {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// Publicize creates FilterGroup from filterGroup
+func (ut *filterGroup) Publicize() *FilterGroup {
+	var pub FilterGroup
+	if ut.Filter != nil {
+		pub.Filter = make([]*FilterProperty, len(ut.Filter))
+		for i2, elem2 := range ut.Filter {
+			pub.Filter[i2] = elem2.Publicize()
+		}
+	}
+	return &pub
+}
+
+// FilterGroup user type.
+type FilterGroup struct {
+	// Filters that must all match
+	Filter []*FilterProperty `form:"filter" json:"filter" yaml:"filter" xml:"filter"`
+}
+
+// Validate validates the FilterGroup type instance.
+func (ut *FilterGroup) Validate() (err error) {
+	if ut.Filter == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "filter"))
+	}
+	for _, e := range ut.Filter {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
 // filterPayload user type.
 type filterPayload struct {
-	// Users filter.
+	// Filter groups, at least one of which must match.
+	AnyOf []*filterGroup `form:"anyOf,omitempty" json:"anyOf,omitempty" yaml:"anyOf,omitempty" xml:"anyOf,omitempty"`
+	// Cursor of the page, the nextCursor of the previous page. An empty cursor starts iterating in cursor mode, where the users are ordered by id.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty" yaml:"cursor,omitempty" xml:"cursor,omitempty"`
+	// Users filter. All the filters must match.
 	Filter []*filterProperty `form:"filter,omitempty" json:"filter,omitempty" yaml:"filter,omitempty" xml:"filter,omitempty"`
-	// Page number (1-based).
+	// Count the users matching the filter into the total. Counting goes over all the matching users, so it is off by default.
+	IncludeTotal *bool `form:"includeTotal,omitempty" json:"includeTotal,omitempty" yaml:"includeTotal,omitempty" xml:"includeTotal,omitempty"`
+	// Page number (1-based). Not used in cursor mode.
 	Page *int `form:"page,omitempty" json:"page,omitempty" yaml:"page,omitempty" xml:"page,omitempty"`
 	// Items per page.
 	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty" yaml:"pageSize,omitempty" xml:"pageSize,omitempty"`
-	// Sort specification.
+	// Sort specification. In cursor mode, only the direction of the id.
 	Sort *orderSpec `form:"sort,omitempty" json:"sort,omitempty" yaml:"sort,omitempty" xml:"sort,omitempty"`
 }
 
-// Validate validates the filterPayload type instance.
-func (ut *filterPayload) Validate() (err error) {
+// Finalize sets the default values for filterPayload type instance.
+func (ut *filterPayload) Finalize() {
+	for _, e := range ut.AnyOf {
+		for _, e := range e.Filter {
+			var defaultOperator = "eq"
+			if e.Operator == nil {
+				e.Operator = &defaultOperator
+			}
+		}
+	}
+	for _, e := range ut.Filter {
+		var defaultOperator = "eq"
+		if e.Operator == nil {
+			e.Operator = &defaultOperator
+		}
+	}
+	var defaultIncludeTotal = false
+	if ut.IncludeTotal == nil {
+		ut.IncludeTotal = &defaultIncludeTotal
+	}
+	var defaultPage = 1
 	if ut.Page == nil {
-		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "page"))
+		ut.Page = &defaultPage
 	}
+}
+
+// Validate validates the filterPayload type instance.
+func (ut *filterPayload) Validate() (err error) {
 	if ut.PageSize == nil {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "pageSize"))
 	}
+	for _, e := range ut.AnyOf {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
 	for _, e := range ut.Filter {
 		if e != nil {
 			if err2 := e.Validate(); err2 != nil {
@@ -294,12 +634,24 @@ func (ut *filterPayload) Validate() (err error) {
 // Publicize creates FilterPayload from filterPayload
 func (ut *filterPayload) Publicize() *FilterPayload {
 	var pub FilterPayload
+	if ut.AnyOf != nil {
+		pub.AnyOf = make([]*FilterGroup, len(ut.AnyOf))
+		for i2, elem2 := range ut.AnyOf {
+			pub.AnyOf[i2] = elem2.Publicize()
+		}
+	}
+	if ut.Cursor != nil {
+		pub.Cursor = ut.Cursor
+	}
 	if ut.Filter != nil {
 		pub.Filter = make([]*FilterProperty, len(ut.Filter))
 		for i2, elem2 := range ut.Filter {
 			pub.Filter[i2] = elem2.Publicize()
 		}
 	}
+	if ut.IncludeTotal != nil {
+		pub.IncludeTotal = *ut.IncludeTotal
+	}
 	if ut.Page != nil {
 		pub.Page = *ut.Page
 	}
@@ -314,19 +666,31 @@ func (ut *filterPayload) Publicize() *FilterPayload {
 
 // FilterPayload user type.
 type FilterPayload struct {
-	// Users filter.
+	// Filter groups, at least one of which must match.
+	AnyOf []*FilterGroup `form:"anyOf,omitempty" json:"anyOf,omitempty" yaml:"anyOf,omitempty" xml:"anyOf,omitempty"`
+	// Cursor of the page, the nextCursor of the previous page. An empty cursor starts iterating in cursor mode, where the users are ordered by id.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty" yaml:"cursor,omitempty" xml:"cursor,omitempty"`
+	// Users filter. All the filters must match.
 	Filter []*FilterProperty `form:"filter,omitempty" json:"filter,omitempty" yaml:"filter,omitempty" xml:"filter,omitempty"`
-	// Page number (1-based).
+	// Count the users matching the filter into the total. Counting goes over all the matching users, so it is off by default.
+	IncludeTotal bool `form:"includeTotal" json:"includeTotal" yaml:"includeTotal" xml:"includeTotal"`
+	// Page number (1-based). Not used in cursor mode.
 	Page int `form:"page" json:"page" yaml:"page" xml:"page"`
 	// Items per page.
 	PageSize int `form:"pageSize" json:"pageSize" yaml:"pageSize" xml:"pageSize"`
-	// Sort specification.
+	// Sort specification. In cursor mode, only the direction of the id.
 	Sort *OrderSpec `form:"sort,omitempty" json:"sort,omitempty" yaml:"sort,omitempty" xml:"sort,omitempty"`
 }
 
 // Validate validates the FilterPayload type instance.
 func (ut *FilterPayload) Validate() (err error) {
-
+	for _, e := range ut.AnyOf {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
 	for _, e := range ut.Filter {
 		if e != nil {
 			if err2 := e.Validate(); err2 != nil {
@@ -344,10 +708,22 @@ func (ut *FilterPayload) Validate() (err error) {
 
 // filterProperty user type.
 type filterProperty struct {
-	// Property name
+	// Operator. The gt, gte, lt and lte operators apply to createdAt and modifiedAt.
+	Operator *string `form:"operator,omitempty" json:"operator,omitempty" yaml:"operator,omitempty" xml:"operator,omitempty"`
+	// Property name. Profile attributes are matched as profile.<name>.
 	Property *string `form:"property,omitempty" json:"property,omitempty" yaml:"property,omitempty" xml:"property,omitempty"`
-	// Property value to match
+	// Property value to match. For the exists operator, true (default) or false.
 	Value *string `form:"value,omitempty" json:"value,omitempty" yaml:"value,omitempty" xml:"value,omitempty"`
+	// Property values to match, for the in and nin operators
+	Values []string `form:"values,omitempty" json:"values,omitempty" yaml:"values,omitempty" xml:"values,omitempty"`
+}
+
+// Finalize sets the default values for filterProperty type instance.
+func (ut *filterProperty) Finalize() {
+	var defaultOperator = "eq"
+	if ut.Operator == nil {
+		ut.Operator = &defaultOperator
+	}
 }
 
 // Validate validates the filterProperty type instance.
@@ -355,8 +731,10 @@ func (ut *filterProperty) Validate() (err error) {
 	if ut.Property == nil {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "property"))
 	}
-	if ut.Value == nil {
-		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "value"))
+	if ut.Operator != nil {
+		if !(*ut.Operator == "eq" || *ut.Operator == "ne" || *ut.Operator == "in" || *ut.Operator == "nin" || *ut.Operator == "prefix" || *ut.Operator == "contains" || *ut.Operator == "gt" || *ut.Operator == "gte" || *ut.Operator == "lt" || *ut.Operator == "lte" || *ut.Operator == "exists") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(`request.operator`, *ut.Operator, []interface{}{"eq", "ne", "in", "nin", "prefix", "contains", "gt", "gte", "lt", "lte", "exists"}))
+		}
 	}
 	return
 }
@@ -364,21 +742,31 @@ func (ut *filterProperty) Validate() (err error) {
 // Publicize creates FilterProperty from filterProperty
 func (ut *filterProperty) Publicize() *FilterProperty {
 	var pub FilterProperty
+	if ut.Operator != nil {
+		pub.Operator = *ut.Operator
+	}
 	if ut.Property != nil {
 		pub.Property = *ut.Property
 	}
 	if ut.Value != nil {
-		pub.Value = *ut.Value
+		pub.Value = ut.Value
+	}
+	if ut.Values != nil {
+		pub.Values = ut.Values
 	}
 	return &pub
 }
 
 // FilterProperty user type.
 type FilterProperty struct {
-	// Property name
+	// Operator. The gt, gte, lt and lte operators apply to createdAt and modifiedAt.
+	Operator string `form:"operator" json:"operator" yaml:"operator" xml:"operator"`
+	// Property name. Profile attributes are matched as profile.<name>.
 	Property string `form:"property" json:"property" yaml:"property" xml:"property"`
-	// Property value to match
-	Value string `form:"value" json:"value" yaml:"value" xml:"value"`
+	// Property value to match. For the exists operator, true (default) or false.
+	Value *string `form:"value,omitempty" json:"value,omitempty" yaml:"value,omitempty" xml:"value,omitempty"`
+	// Property values to match, for the in and nin operators
+	Values []string `form:"values,omitempty" json:"values,omitempty" yaml:"values,omitempty" xml:"values,omitempty"`
 }
 
 // Validate validates the FilterProperty type instance.
@@ -386,8 +774,8 @@ func (ut *FilterProperty) Validate() (err error) {
 	if ut.Property == "" {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "property"))
 	}
-	if ut.Value == "" {
-		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "value"))
+	if !(ut.Operator == "eq" || ut.Operator == "ne" || ut.Operator == "in" || ut.Operator == "nin" || ut.Operator == "prefix" || ut.Operator == "contains" || ut.Operator == "gt" || ut.Operator == "gte" || ut.Operator == "lt" || ut.Operator == "lte" || ut.Operator == "exists") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`type.operator`, ut.Operator, []interface{}{"eq", "ne", "in", "nin", "prefix", "contains", "gt", "gte", "lt", "lte", "exists"}))
 	}
 	return
 }
@@ -415,16 +803,6 @@ func (ut *forgotPasswordPayload) Validate() (err error) {
 			err = goa.MergeErrors(err, goa.InvalidFormatError(`request.email`, *ut.Email, goa.FormatEmail, err2))
 		}
 	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) < 6 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 6, true))
-		}
-	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) > 30 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 30, false))
-		}
-	}
 	return
 }
 
@@ -466,11 +844,223 @@ func (ut *ForgotPasswordPayload) Validate() (err error) {
 			err = goa.MergeErrors(err, goa.InvalidFormatError(`type.email`, *ut.Email, goa.FormatEmail, err2))
 		}
 	}
-	if utf8.RuneCountInString(ut.Password) < 6 {
-		err = goa.MergeErrors(err, goa.InvalidLengthError(`type.password`, ut.Password, utf8.RuneCountInString(ut.Password), 6, true))
+	return
+}
+
+// Invitation payload
+type invitationPayload struct {
+	// Email of the invitee
+	Email *string `form:"email,omitempty" json:"email,omitempty" yaml:"email,omitempty" xml:"email,omitempty"`
+	// Expiry time (milliseconds since epoch). Defaults to the configured invitation TTL.
+	ExpiresAt *int `form:"expiresAt,omitempty" json:"expiresAt,omitempty" yaml:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+	// Namespaces of the invited user
+	Namespaces []string `form:"namespaces,omitempty" json:"namespaces,omitempty" yaml:"namespaces,omitempty" xml:"namespaces,omitempty"`
+	// Organizations of the invited user
+	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
+	// Roles of the invited user. Defaults to the user role.
+	Roles []string `form:"roles,omitempty" json:"roles,omitempty" yaml:"roles,omitempty" xml:"roles,omitempty"`
+}
+
+// Validate validates the invitationPayload type instance.
+func (ut *invitationPayload) Validate() (err error) {
+	if ut.Email == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "email"))
+	}
+	if ut.Email != nil {
+		if err2 := goa.ValidateFormat(goa.FormatEmail, *ut.Email); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFormatError(`request.email`, *ut.Email, goa.FormatEmail, err2))
+		}
+	}
+	return
+}
+
+// Publicize creates InvitationPayload from invitationPayload
+func (ut *invitationPayload) Publicize() *InvitationPayload {
+	var pub InvitationPayload
+	if ut.Email != nil {
+		pub.Email = *ut.Email
+	}
+	if ut.ExpiresAt != nil {
+		pub.ExpiresAt = ut.ExpiresAt
+	}
+	if ut.Namespaces != nil {
+		pub.Namespaces = ut.Namespaces
+	}
+	if ut.Organizations != nil {
+		pub.Organizations = ut.Organizations
+	}
+	if ut.Roles != nil {
+		pub.Roles = ut.Roles
+	}
+	return &pub
+}
+
+// Invitation payload
+type InvitationPayload struct {
+	// Email of the invitee
+	Email string `form:"email" json:"email" yaml:"email" xml:"email"`
+	// Expiry time (milliseconds since epoch). Defaults to the configured invitation TTL.
+	ExpiresAt *int `form:"expiresAt,omitempty" json:"expiresAt,omitempty" yaml:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+	// Namespaces of the invited user
+	Namespaces []string `form:"namespaces,omitempty" json:"namespaces,omitempty" yaml:"namespaces,omitempty" xml:"namespaces,omitempty"`
+	// Organizations of the invited user
+	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
+	// Roles of the invited user. Defaults to the user role.
+	Roles []string `form:"roles,omitempty" json:"roles,omitempty" yaml:"roles,omitempty" xml:"roles,omitempty"`
+}
+
+// Validate validates the InvitationPayload type instance.
+func (ut *InvitationPayload) Validate() (err error) {
+	if ut.Email == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "email"))
+	}
+	if err2 := goa.ValidateFormat(goa.FormatEmail, ut.Email); err2 != nil {
+		err = goa.MergeErrors(err, goa.InvalidFormatError(`type.email`, ut.Email, goa.FormatEmail, err2))
+	}
+	return
+}
+
+// MFA code payload
+type mFACodePayload struct {
+	// Code generated by the authenticator
+	Code *string `form:"code,omitempty" json:"code,omitempty" yaml:"code,omitempty" xml:"code,omitempty"`
+}
+
+// Validate validates the mFACodePayload type instance.
+func (ut *mFACodePayload) Validate() (err error) {
+	if ut.Code == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "code"))
+	}
+	return
+}
+
+// Publicize creates MFACodePayload from mFACodePayload
+func (ut *mFACodePayload) Publicize() *MFACodePayload {
+	var pub MFACodePayload
+	if ut.Code != nil {
+		pub.Code = *ut.Code
+	}
+	return &pub
+}
+
+// MFA code payload
+type MFACodePayload struct {
+	// Code generated by the authenticator
+	Code string `form:"code" json:"code" yaml:"code" xml:"code"`
+}
+
+// Validate validates the MFACodePayload type instance.
+func (ut *MFACodePayload) Validate() (err error) {
+	if ut.Code == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "code"))
+	}
+	return
+}
+
+// MFA verification payload
+type mFAVerifyPayload struct {
+	// TOTP code or recovery code
+	Code *string `form:"code,omitempty" json:"code,omitempty" yaml:"code,omitempty" xml:"code,omitempty"`
+	// User ID
+	UserID *string `form:"userId,omitempty" json:"userId,omitempty" yaml:"userId,omitempty" xml:"userId,omitempty"`
+}
+
+// Validate validates the mFAVerifyPayload type instance.
+func (ut *mFAVerifyPayload) Validate() (err error) {
+	if ut.UserID == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "userId"))
 	}
-	if utf8.RuneCountInString(ut.Password) > 30 {
-		err = goa.MergeErrors(err, goa.InvalidLengthError(`type.password`, ut.Password, utf8.RuneCountInString(ut.Password), 30, false))
+	if ut.Code == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "code"))
+	}
+	return
+}
+
+// Publicize creates MFAVerifyPayload from mFAVerifyPayload
+func (ut *mFAVerifyPayload) Publicize() *MFAVerifyPayload {
+	var pub MFAVerifyPayload
+	if ut.Code != nil {
+		pub.Code = *ut.Code
+	}
+	if ut.UserID != nil {
+		pub.UserID = *ut.UserID
+	}
+	return &pub
+}
+
+// MFA verification payload
+type MFAVerifyPayload struct {
+	// TOTP code or recovery code
+	Code string `form:"code" json:"code" yaml:"code" xml:"code"`
+	// User ID
+	UserID string `form:"userId" json:"userId" yaml:"userId" xml:"userId"`
+}
+
+// Validate validates the MFAVerifyPayload type instance.
+func (ut *MFAVerifyPayload) Validate() (err error) {
+	if ut.UserID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "userId"))
+	}
+	if ut.Code == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "code"))
+	}
+	return
+}
+
+// Merge users payload
+type mergeUsersPayload struct {
+	// IDs of the duplicate users to merge and delete
+	DuplicateIds []string `form:"duplicateIds,omitempty" json:"duplicateIds,omitempty" yaml:"duplicateIds,omitempty" xml:"duplicateIds,omitempty"`
+	// ID of the user to keep
+	UserID *string `form:"userId,omitempty" json:"userId,omitempty" yaml:"userId,omitempty" xml:"userId,omitempty"`
+}
+
+// Validate validates the mergeUsersPayload type instance.
+func (ut *mergeUsersPayload) Validate() (err error) {
+	if ut.UserID == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "userId"))
+	}
+	if ut.DuplicateIds == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "duplicateIds"))
+	}
+	if ut.DuplicateIds != nil {
+		if len(ut.DuplicateIds) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.duplicateIds`, ut.DuplicateIds, len(ut.DuplicateIds), 1, true))
+		}
+	}
+	return
+}
+
+// Publicize creates MergeUsersPayload from mergeUsersPayload
+func (ut *mergeUsersPayload) Publicize() *MergeUsersPayload {
+	var pub MergeUsersPayload
+	if ut.DuplicateIds != nil {
+		pub.DuplicateIds = ut.DuplicateIds
+	}
+	if ut.UserID != nil {
+		pub.UserID = *ut.UserID
+	}
+	return &pub
+}
+
+// Merge users payload
+type MergeUsersPayload struct {
+	// IDs of the duplicate users to merge and delete
+	DuplicateIds []string `form:"duplicateIds" json:"duplicateIds" yaml:"duplicateIds" xml:"duplicateIds"`
+	// ID of the user to keep
+	UserID string `form:"userId" json:"userId" yaml:"userId" xml:"userId"`
+}
+
+// Validate validates the MergeUsersPayload type instance.
+func (ut *MergeUsersPayload) Validate() (err error) {
+	if ut.UserID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "userId"))
+	}
+	if ut.DuplicateIds == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "duplicateIds"))
+	}
+	if len(ut.DuplicateIds) < 1 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError(`type.duplicateIds`, ut.DuplicateIds, len(ut.DuplicateIds), 1, true))
 	}
 	return
 }
@@ -525,6 +1115,218 @@ func (ut *OrderSpec) Validate() (err error) {
 	return
 }
 
+// Self-service registration payload
+type registerPayload struct {
+	// Email of user
+	Email *string `form:"email,omitempty" json:"email,omitempty" yaml:"email,omitempty" xml:"email,omitempty"`
+	// Invitation token. Required in the invite-only registration mode.
+	InvitationToken *string `form:"invitationToken,omitempty" json:"invitationToken,omitempty" yaml:"invitationToken,omitempty" xml:"invitationToken,omitempty"`
+	// Password of user
+	Password *string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Profile attributes of user, as declared in the profile schema of the service
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
+}
+
+// Validate validates the registerPayload type instance.
+func (ut *registerPayload) Validate() (err error) {
+	if ut.Email == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "email"))
+	}
+	if ut.Password == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "password"))
+	}
+	if ut.Email != nil {
+		if err2 := goa.ValidateFormat(goa.FormatEmail, *ut.Email); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFormatError(`request.email`, *ut.Email, goa.FormatEmail, err2))
+		}
+	}
+	return
+}
+
+// Publicize creates RegisterPayload from registerPayload
+func (ut *registerPayload) Publicize() *RegisterPayload {
+	var pub RegisterPayload
+	if ut.Email != nil {
+		pub.Email = *ut.Email
+	}
+	if ut.InvitationToken != nil {
+		pub.InvitationToken = ut.InvitationToken
+	}
+	if ut.Password != nil {
+		pub.Password = *ut.Password
+	}
+	if ut.Profile != nil {
+		pub.Profile = ut.Profile
+	}
+	return &pub
+}
+
+// Self-service registration payload
+type RegisterPayload struct {
+	// Email of user
+	Email string `form:"email" json:"email" yaml:"email" xml:"email"`
+	// Invitation token. Required in the invite-only registration mode.
+	InvitationToken *string `form:"invitationToken,omitempty" json:"invitationToken,omitempty" yaml:"invitationToken,omitempty" xml:"invitationToken,omitempty"`
+	// Password of user
+	Password string `form:"password" json:"password" yaml:"password" xml:"password"`
+	// Profile attributes of user, as declared in the profile schema of the service
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
+}
+
+// Validate validates the RegisterPayload type instance.
+func (ut *RegisterPayload) Validate() (err error) {
+	if ut.Email == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "email"))
+	}
+	if ut.Password == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "password"))
+	}
+	if err2 := goa.ValidateFormat(goa.FormatEmail, ut.Email); err2 != nil {
+		err = goa.MergeErrors(err, goa.InvalidFormatError(`type.email`, ut.Email, goa.FormatEmail, err2))
+	}
+	return
+}
+
+// Status change payload
+type statusChangePayload struct {
+	// Reason for changing the status
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" yaml:"reason,omitempty" xml:"reason,omitempty"`
+}
+
+// Validate validates the statusChangePayload type instance.
+func (ut *statusChangePayload) Validate() (err error) {
+	if ut.Reason != nil {
+		if utf8.RuneCountInString(*ut.Reason) > 500 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.reason`, *ut.Reason, utf8.RuneCountInString(*ut.Reason), 500, false))
+		}
+	}
+	return
+}
+
+// Publicize creates StatusChangePayload from statusChangePayload
+func (ut *statusChangePayload) Publicize() *StatusChangePayload {
+	var pub StatusChangePayload
+	if ut.Reason != nil {
+		pub.Reason = ut.Reason
+	}
+	return &pub
+}
+
+// Status change payload
+type StatusChangePayload struct {
+	// Reason for changing the status
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" yaml:"reason,omitempty" xml:"reason,omitempty"`
+}
+
+// Validate validates the StatusChangePayload type instance.
+func (ut *StatusChangePayload) Validate() (err error) {
+	if ut.Reason != nil {
+		if utf8.RuneCountInString(*ut.Reason) > 500 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(`type.reason`, *ut.Reason, utf8.RuneCountInString(*ut.Reason), 500, false))
+		}
+	}
+	return
+}
+
+// Token payload
+type tokenPayload struct {
+	// Token
+	Token *string `form:"token,omitempty" json:"token,omitempty" yaml:"token,omitempty" xml:"token,omitempty"`
+}
+
+// Validate validates the tokenPayload type instance.
+func (ut *tokenPayload) Validate() (err error) {
+	if ut.Token == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`request`, "token"))
+	}
+	return
+}
+
+// Publicize creates TokenPayload from tokenPayload
+func (ut *tokenPayload) Publicize() *TokenPayload {
+	var pub TokenPayload
+	if ut.Token != nil {
+		pub.Token = *ut.Token
+	}
+	return &pub
+}
+
+// Token payload
+type TokenPayload struct {
+	// Token
+	Token string `form:"token" json:"token" yaml:"token" xml:"token"`
+}
+
+// Validate validates the TokenPayload type instance.
+func (ut *TokenPayload) Validate() (err error) {
+	if ut.Token == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`type`, "token"))
+	}
+	return
+}
+
+// Update the authenticated user payload
+type updateMePayload struct {
+	// Current password, needed to change the email or the password
+	CurrentPassword *string `form:"currentPassword,omitempty" json:"currentPassword,omitempty" yaml:"currentPassword,omitempty" xml:"currentPassword,omitempty"`
+	// New email, changed once confirmed
+	Email *string `form:"email,omitempty" json:"email,omitempty" yaml:"email,omitempty" xml:"email,omitempty"`
+	// New password
+	Password *string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Profile attributes of user, replacing the current ones
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
+}
+
+// Validate validates the updateMePayload type instance.
+func (ut *updateMePayload) Validate() (err error) {
+	if ut.Email != nil {
+		if err2 := goa.ValidateFormat(goa.FormatEmail, *ut.Email); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFormatError(`request.email`, *ut.Email, goa.FormatEmail, err2))
+		}
+	}
+	return
+}
+
+// Publicize creates UpdateMePayload from updateMePayload
+func (ut *updateMePayload) Publicize() *UpdateMePayload {
+	var pub UpdateMePayload
+	if ut.CurrentPassword != nil {
+		pub.CurrentPassword = ut.CurrentPassword
+	}
+	if ut.Email != nil {
+		pub.Email = ut.Email
+	}
+	if ut.Password != nil {
+		pub.Password = ut.Password
+	}
+	if ut.Profile != nil {
+		pub.Profile = ut.Profile
+	}
+	return &pub
+}
+
+// Update the authenticated user payload
+type UpdateMePayload struct {
+	// Current password, needed to change the email or the password
+	CurrentPassword *string `form:"currentPassword,omitempty" json:"currentPassword,omitempty" yaml:"currentPassword,omitempty" xml:"currentPassword,omitempty"`
+	// New email, changed once confirmed
+	Email *string `form:"email,omitempty" json:"email,omitempty" yaml:"email,omitempty" xml:"email,omitempty"`
+	// New password
+	Password *string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Profile attributes of user, replacing the current ones
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
+}
+
+// Validate validates the UpdateMePayload type instance.
+func (ut *UpdateMePayload) Validate() (err error) {
+	if ut.Email != nil {
+		if err2 := goa.ValidateFormat(goa.FormatEmail, *ut.Email); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFormatError(`type.email`, *ut.Email, goa.FormatEmail, err2))
+		}
+	}
+	return
+}
+
 // UpdateUserPayload
 type updateUserPayload struct {
 	// Status of user account
@@ -539,6 +1341,8 @@ type updateUserPayload struct {
 	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
 	// Password of user
 	Password *string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Profile attributes of user, replacing the current ones
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
 	// Roles of user
 	Roles []string `form:"roles,omitempty" json:"roles,omitempty" yaml:"roles,omitempty" xml:"roles,omitempty"`
 	// Token for email verification
@@ -560,16 +1364,6 @@ func (ut *updateUserPayload) Validate() (err error) {
 			err = goa.MergeErrors(err, goa.InvalidFormatError(`request.email`, *ut.Email, goa.FormatEmail, err2))
 		}
 	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) < 6 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 6, true))
-		}
-	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) > 30 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`request.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 30, false))
-		}
-	}
 	return
 }
 
@@ -594,6 +1388,9 @@ func (ut *updateUserPayload) Publicize() *UpdateUserPayload {
 	if ut.Password != nil {
 		pub.Password = ut.Password
 	}
+	if ut.Profile != nil {
+		pub.Profile = ut.Profile
+	}
 	if ut.Roles != nil {
 		pub.Roles = ut.Roles
 	}
@@ -617,6 +1414,8 @@ type UpdateUserPayload struct {
 	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
 	// Password of user
 	Password *string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Profile attributes of user, replacing the current ones
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
 	// Roles of user
 	Roles []string `form:"roles,omitempty" json:"roles,omitempty" yaml:"roles,omitempty" xml:"roles,omitempty"`
 	// Token for email verification
@@ -630,15 +1429,5 @@ func (ut *UpdateUserPayload) Validate() (err error) {
 			err = goa.MergeErrors(err, goa.InvalidFormatError(`type.email`, *ut.Email, goa.FormatEmail, err2))
 		}
 	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) < 6 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`type.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 6, true))
-		}
-	}
-	if ut.Password != nil {
-		if utf8.RuneCountInString(*ut.Password) > 30 {
-			err = goa.MergeErrors(err, goa.InvalidLengthError(`type.password`, *ut.Password, utf8.RuneCountInString(*ut.Password), 30, false))
-		}
-	}
 	return
 }
@@ -21,10 +21,14 @@ import (
 type UsersPage struct {
 	// Users list
 	Items []*Users `form:"items,omitempty" json:"items,omitempty" yaml:"items,omitempty" xml:"items,omitempty"`
-	// Page number (1-based).
+	// Cursor of the next page in cursor mode. Not set on the last page.
+	NextCursor *string `form:"nextCursor,omitempty" json:"nextCursor,omitempty" yaml:"nextCursor,omitempty" xml:"nextCursor,omitempty"`
+	// Page number (1-based). Not set in cursor mode.
 	Page *int `form:"page,omitempty" json:"page,omitempty" yaml:"page,omitempty" xml:"page,omitempty"`
 	// Items per page.
 	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty" yaml:"pageSize,omitempty" xml:"pageSize,omitempty"`
+	// Total number of the users matching the filter. Set only when includeTotal is requested.
+	Total *int `form:"total,omitempty" json:"total,omitempty" yaml:"total,omitempty" xml:"total,omitempty"`
 }
 
 // Validate validates the UsersPage media type instance.
@@ -59,18 +63,30 @@ func (c *Client) DecodeErrorResponse(resp *http.Response) (*goa.ErrorResponse, e
 type Users struct {
 	// Status of user account
 	Active bool `form:"active" json:"active" yaml:"active" xml:"active"`
+	// Email of user as entered, the email attribute holds the normalized form
+	DisplayEmail *string `form:"displayEmail,omitempty" json:"displayEmail,omitempty" yaml:"displayEmail,omitempty" xml:"displayEmail,omitempty"`
 	// Email of user
 	Email string `form:"email" json:"email" yaml:"email" xml:"email"`
 	// External id of user
-	ExternalID string `form:"externalId" json:"externalId" yaml:"externalId" xml:"externalId"`
+	ExternalID *string `form:"externalId,omitempty" json:"externalId,omitempty" yaml:"externalId,omitempty" xml:"externalId,omitempty"`
 	// Unique user ID
 	ID string `form:"id" json:"id" yaml:"id" xml:"id"`
+	// Time of the last successful login (milliseconds since epoch)
+	LastLoginAt *int `form:"lastLoginAt,omitempty" json:"lastLoginAt,omitempty" yaml:"lastLoginAt,omitempty" xml:"lastLoginAt,omitempty"`
+	// Whether multi-factor authentication is enabled
+	MfaEnabled *bool `form:"mfaEnabled,omitempty" json:"mfaEnabled,omitempty" yaml:"mfaEnabled,omitempty" xml:"mfaEnabled,omitempty"`
 	// List of namespaces this user belongs to
 	Namespaces []string `form:"namespaces,omitempty" json:"namespaces,omitempty" yaml:"namespaces,omitempty" xml:"namespaces,omitempty"`
 	// List of organizations to which this user belongs to
 	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
+	// New email of user, waiting for confirmation
+	PendingEmail *string `form:"pendingEmail,omitempty" json:"pendingEmail,omitempty" yaml:"pendingEmail,omitempty" xml:"pendingEmail,omitempty"`
+	// Profile attributes of user visible to the caller
+	Profile map[string]interface{} `form:"profile,omitempty" json:"profile,omitempty" yaml:"profile,omitempty" xml:"profile,omitempty"`
 	// Roles of user
 	Roles []string `form:"roles" json:"roles" yaml:"roles" xml:"roles"`
+	// Lifecycle status of user account
+	Status *string `form:"status,omitempty" json:"status,omitempty" yaml:"status,omitempty" xml:"status,omitempty"`
 }
 
 // Validate validates the Users media type instance.
@@ -84,13 +100,15 @@ func (mt *Users) Validate() (err error) {
 	if mt.Roles == nil {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "roles"))
 	}
-	if mt.ExternalID == "" {
-		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "externalId"))
-	}
 
 	if err2 := goa.ValidateFormat(goa.FormatEmail, mt.Email); err2 != nil {
 		err = goa.MergeErrors(err, goa.InvalidFormatError(`response.email`, mt.Email, goa.FormatEmail, err2))
 	}
+	if mt.Status != nil {
+		if !(*mt.Status == "pending_verification" || *mt.Status == "active" || *mt.Status == "suspended" || *mt.Status == "locked" || *mt.Status == "deactivated" || *mt.Status == "deleted") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.status`, *mt.Status, []interface{}{"pending_verification", "active", "suspended", "locked", "deactivated", "deleted"}))
+		}
+	}
 	return
 }
 
@@ -101,16 +119,351 @@ func (c *Client) DecodeUsers(resp *http.Response) (*Users, error) {
 	return &decoded, err
 }
 
+// AccessToken media type (default view)
+//
+// Identifier: application/vnd.goa.user.access-token+json; view=default
+type AccessToken struct {
+	// Time of creation (milliseconds since epoch)
+	CreatedAt int `form:"createdAt" json:"createdAt" yaml:"createdAt" xml:"createdAt"`
+	// Expiry time (milliseconds since epoch)
+	ExpiresAt *int `form:"expiresAt,omitempty" json:"expiresAt,omitempty" yaml:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
+	// Access token ID
+	ID string `form:"id" json:"id" yaml:"id" xml:"id"`
+	// Time of the last use (milliseconds since epoch)
+	LastUsedAt *int `form:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty" yaml:"lastUsedAt,omitempty" xml:"lastUsedAt,omitempty"`
+	// Name of the access token
+	Name string `form:"name" json:"name" yaml:"name" xml:"name"`
+	// First characters of the token, for identification
+	Prefix string `form:"prefix" json:"prefix" yaml:"prefix" xml:"prefix"`
+	// Scopes of the access token
+	Scopes []string `form:"scopes,omitempty" json:"scopes,omitempty" yaml:"scopes,omitempty" xml:"scopes,omitempty"`
+	// The access token. Returned only when the token is created.
+	Token *string `form:"token,omitempty" json:"token,omitempty" yaml:"token,omitempty" xml:"token,omitempty"`
+}
+
+// Validate validates the AccessToken media type instance.
+func (mt *AccessToken) Validate() (err error) {
+	if mt.ID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "id"))
+	}
+	if mt.Name == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "name"))
+	}
+	if mt.Prefix == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "prefix"))
+	}
+
+	return
+}
+
+// DecodeAccessToken decodes the AccessToken instance encoded in resp body.
+func (c *Client) DecodeAccessToken(resp *http.Response) (*AccessToken, error) {
+	var decoded AccessToken
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// AccessTokenCollection is the media type for an array of AccessToken (default view)
+//
+// Identifier: application/vnd.goa.user.access-token+json; type=collection; view=default
+type AccessTokenCollection []*AccessToken
+
+// Validate validates the AccessTokenCollection media type instance.
+func (mt AccessTokenCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeAccessTokenCollection decodes the AccessTokenCollection instance encoded in resp body.
+func (c *Client) DecodeAccessTokenCollection(resp *http.Response) (AccessTokenCollection, error) {
+	var decoded AccessTokenCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
+// DuplicateUsers media type (default view)
+//
+// Identifier: application/vnd.goa.user.duplicate-users+json; view=default
+type DuplicateUsers struct {
+	// Normalized email shared by the users
+	Email string `form:"email" json:"email" yaml:"email" xml:"email"`
+	// Users with the email
+	Users UsersCollection `form:"users" json:"users" yaml:"users" xml:"users"`
+}
+
+// Validate validates the DuplicateUsers media type instance.
+func (mt *DuplicateUsers) Validate() (err error) {
+	if mt.Email == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "email"))
+	}
+	if mt.Users == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "users"))
+	}
+	if err2 := mt.Users.Validate(); err2 != nil {
+		err = goa.MergeErrors(err, err2)
+	}
+	return
+}
+
+// DecodeDuplicateUsers decodes the DuplicateUsers instance encoded in resp body.
+func (c *Client) DecodeDuplicateUsers(resp *http.Response) (*DuplicateUsers, error) {
+	var decoded DuplicateUsers
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// DuplicateUsersCollection is the media type for an array of DuplicateUsers (default view)
+//
+// Identifier: application/vnd.goa.user.duplicate-users+json; type=collection; view=default
+type DuplicateUsersCollection []*DuplicateUsers
+
+// Validate validates the DuplicateUsersCollection media type instance.
+func (mt DuplicateUsersCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeDuplicateUsersCollection decodes the DuplicateUsersCollection instance encoded in resp body.
+func (c *Client) DecodeDuplicateUsersCollection(resp *http.Response) (DuplicateUsersCollection, error) {
+	var decoded DuplicateUsersCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
+// Invitation media type (default view)
+//
+// Identifier: application/vnd.goa.user.invitation+json; view=default
+type Invitation struct {
+	// Time of creation (milliseconds since epoch)
+	CreatedAt int `form:"createdAt" json:"createdAt" yaml:"createdAt" xml:"createdAt"`
+	// Email of the invitee
+	Email string `form:"email" json:"email" yaml:"email" xml:"email"`
+	// Expiry time (milliseconds since epoch)
+	ExpiresAt int `form:"expiresAt" json:"expiresAt" yaml:"expiresAt" xml:"expiresAt"`
+	// Invitation ID
+	ID string `form:"id" json:"id" yaml:"id" xml:"id"`
+	// ID of the user that sent the invitation
+	InvitedBy *string `form:"invitedBy,omitempty" json:"invitedBy,omitempty" yaml:"invitedBy,omitempty" xml:"invitedBy,omitempty"`
+	// Namespaces of the invited user
+	Namespaces []string `form:"namespaces,omitempty" json:"namespaces,omitempty" yaml:"namespaces,omitempty" xml:"namespaces,omitempty"`
+	// Organizations of the invited user
+	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
+	// Roles of the invited user
+	Roles []string `form:"roles" json:"roles" yaml:"roles" xml:"roles"`
+}
+
+// Validate validates the Invitation media type instance.
+func (mt *Invitation) Validate() (err error) {
+	if mt.ID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "id"))
+	}
+	if mt.Email == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "email"))
+	}
+	if mt.Roles == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "roles"))
+	}
+
+	return
+}
+
+// DecodeInvitation decodes the Invitation instance encoded in resp body.
+func (c *Client) DecodeInvitation(resp *http.Response) (*Invitation, error) {
+	var decoded Invitation
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// InvitationCollection is the media type for an array of Invitation (default view)
+//
+// Identifier: application/vnd.goa.user.invitation+json; type=collection; view=default
+type InvitationCollection []*Invitation
+
+// Validate validates the InvitationCollection media type instance.
+func (mt InvitationCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeInvitationCollection decodes the InvitationCollection instance encoded in resp body.
+func (c *Client) DecodeInvitationCollection(resp *http.Response) (InvitationCollection, error) {
+	var decoded InvitationCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
+// Login media type (default view)
+//
+// Identifier: application/vnd.goa.user.login+json; view=default
+type Login struct {
+	// Time of the login (milliseconds since epoch)
+	CreatedAt int `form:"createdAt" json:"createdAt" yaml:"createdAt" xml:"createdAt"`
+	// Login ID
+	ID string `form:"id" json:"id" yaml:"id" xml:"id"`
+	// IP address of the client
+	IP *string `form:"ip,omitempty" json:"ip,omitempty" yaml:"ip,omitempty" xml:"ip,omitempty"`
+	// Outcome of the login
+	Outcome string `form:"outcome" json:"outcome" yaml:"outcome" xml:"outcome"`
+	// User agent of the client
+	UserAgent *string `form:"userAgent,omitempty" json:"userAgent,omitempty" yaml:"userAgent,omitempty" xml:"userAgent,omitempty"`
+	// User ID
+	UserID string `form:"userId" json:"userId" yaml:"userId" xml:"userId"`
+}
+
+// Validate validates the Login media type instance.
+func (mt *Login) Validate() (err error) {
+	if mt.ID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "id"))
+	}
+	if mt.UserID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "userId"))
+	}
+	if mt.Outcome == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "outcome"))
+	}
+
+	if !(mt.Outcome == "success" || mt.Outcome == "failure" || mt.Outcome == "locked") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.outcome`, mt.Outcome, []interface{}{"success", "failure", "locked"}))
+	}
+	return
+}
+
+// DecodeLogin decodes the Login instance encoded in resp body.
+func (c *Client) DecodeLogin(resp *http.Response) (*Login, error) {
+	var decoded Login
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// LoginCollection is the media type for an array of Login (default view)
+//
+// Identifier: application/vnd.goa.user.login+json; type=collection; view=default
+type LoginCollection []*Login
+
+// Validate validates the LoginCollection media type instance.
+func (mt LoginCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeLoginCollection decodes the LoginCollection instance encoded in resp body.
+func (c *Client) DecodeLoginCollection(resp *http.Response) (LoginCollection, error) {
+	var decoded LoginCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
+// RecoveryCodes media type (default view)
+//
+// Identifier: application/vnd.goa.user.recovery-codes+json; view=default
+type RecoveryCodes struct {
+	// One-time recovery codes
+	RecoveryCodes []string `form:"recoveryCodes" json:"recoveryCodes" yaml:"recoveryCodes" xml:"recoveryCodes"`
+}
+
+// Validate validates the RecoveryCodes media type instance.
+func (mt *RecoveryCodes) Validate() (err error) {
+	if mt.RecoveryCodes == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "recoveryCodes"))
+	}
+	return
+}
+
+// DecodeRecoveryCodes decodes the RecoveryCodes instance encoded in resp body.
+func (c *Client) DecodeRecoveryCodes(resp *http.Response) (*RecoveryCodes, error) {
+	var decoded RecoveryCodes
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// TOTPEnrollment media type (default view)
+//
+// Identifier: application/vnd.goa.user.totp-enrollment+json; view=default
+type TOTPEnrollment struct {
+	// Base32 encoded TOTP secret
+	Secret string `form:"secret" json:"secret" yaml:"secret" xml:"secret"`
+	// otpauth URI of the secret, usually shown as QR code
+	URI string `form:"uri" json:"uri" yaml:"uri" xml:"uri"`
+}
+
+// Validate validates the TOTPEnrollment media type instance.
+func (mt *TOTPEnrollment) Validate() (err error) {
+	if mt.Secret == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "secret"))
+	}
+	if mt.URI == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "uri"))
+	}
+	return
+}
+
+// DecodeTOTPEnrollment decodes the TOTPEnrollment instance encoded in resp body.
+func (c *Client) DecodeTOTPEnrollment(resp *http.Response) (*TOTPEnrollment, error) {
+	var decoded TOTPEnrollment
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// usersCollection is the media type for an array of users (default view)
+//
+// Identifier: application/vnd.goa.user+json; type=collection; view=default
+type UsersCollection []*Users
+
+// Validate validates the UsersCollection media type instance.
+func (mt UsersCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeUsersCollection decodes the UsersCollection instance encoded in resp body.
+func (c *Client) DecodeUsersCollection(resp *http.Response) (UsersCollection, error) {
+	var decoded UsersCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
 // ResetToken media type (default view)
 //
 // Identifier: resettokenmedia; view=default
 type ResetToken struct {
 	// User email
 	Email string `form:"email" json:"email" yaml:"email" xml:"email"`
+	// Expiry time of the token (milliseconds since epoch)
+	ExpiresAt *int `form:"expiresAt,omitempty" json:"expiresAt,omitempty" yaml:"expiresAt,omitempty" xml:"expiresAt,omitempty"`
 	// User ID
 	ID string `form:"id" json:"id" yaml:"id" xml:"id"`
-	// New token
-	Token string `form:"token" json:"token" yaml:"token" xml:"token"`
+	// New token. Not returned when the service sends the verification email itself.
+	Token *string `form:"token,omitempty" json:"token,omitempty" yaml:"token,omitempty" xml:"token,omitempty"`
 }
 
 // Validate validates the ResetToken media type instance.
@@ -121,9 +474,6 @@ func (mt *ResetToken) Validate() (err error) {
 	if mt.Email == "" {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "email"))
 	}
-	if mt.Token == "" {
-		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "token"))
-	}
 	return
 }
 
@@ -19,23 +19,1095 @@ import (
 	"strconv"
 )
 
+// AcceptInvitationUserPath computes a request path to the acceptInvitation action of user.
+func AcceptInvitationUserPath() string {
+
+	return fmt.Sprintf("/users/invitations/accept")
+}
+
+// Accept an invitation. Creates an active user with the invited roles and memberships.
+func (c *Client) AcceptInvitationUser(ctx context.Context, path string, payload *AcceptInvitationPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewAcceptInvitationUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewAcceptInvitationUserRequest create the request corresponding to the acceptInvitation action endpoint of the user resource.
+func (c *Client) NewAcceptInvitationUserRequest(ctx context.Context, path string, payload *AcceptInvitationPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// ChangePasswordUserPath computes a request path to the changePassword action of user.
+func ChangePasswordUserPath() string {
+
+	return fmt.Sprintf("/users/me/password")
+}
+
+// Change the password of the authenticated user
+func (c *Client) ChangePasswordUser(ctx context.Context, path string, payload *ChangePasswordPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewChangePasswordUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewChangePasswordUserRequest create the request corresponding to the changePassword action endpoint of the user resource.
+func (c *Client) NewChangePasswordUserRequest(ctx context.Context, path string, payload *ChangePasswordPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// ConfirmEmailChangeUserPath computes a request path to the confirmEmailChange action of user.
+func ConfirmEmailChangeUserPath() string {
+
+	return fmt.Sprintf("/users/email/confirm")
+}
+
+// Confirm an email change with the token sent to the new address
+func (c *Client) ConfirmEmailChangeUser(ctx context.Context, path string, payload *TokenPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewConfirmEmailChangeUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewConfirmEmailChangeUserRequest create the request corresponding to the confirmEmailChange action endpoint of the user resource.
+func (c *Client) NewConfirmEmailChangeUserRequest(ctx context.Context, path string, payload *TokenPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// ConfirmTotpUserPath computes a request path to the confirmTotp action of user.
+func ConfirmTotpUserPath() string {
+
+	return fmt.Sprintf("/users/me/mfa/totp/confirm")
+}
+
+// Confirm TOTP enrollment with the first code from the authenticator and enable MFA
+func (c *Client) ConfirmTotpUser(ctx context.Context, path string, payload *MFACodePayload, contentType string) (*http.Response, error) {
+	req, err := c.NewConfirmTotpUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewConfirmTotpUserRequest create the request corresponding to the confirmTotp action endpoint of the user resource.
+func (c *Client) NewConfirmTotpUserRequest(ctx context.Context, path string, payload *MFACodePayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
 // CreateUserPath computes a request path to the create action of user.
 func CreateUserPath() string {
 
-	return fmt.Sprintf("/users")
+	return fmt.Sprintf("/users")
+}
+
+// Creates user
+func (c *Client) CreateUser(ctx context.Context, path string, payload *CreateUserPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewCreateUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewCreateUserRequest create the request corresponding to the create action endpoint of the user resource.
+func (c *Client) NewCreateUserRequest(ctx context.Context, path string, payload *CreateUserPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// CreateInvitationUserPath computes a request path to the createInvitation action of user.
+func CreateInvitationUserPath() string {
+
+	return fmt.Sprintf("/users/invitations")
+}
+
+// Invite a user. The invitation is sent by email, the invitee accepts it by setting a password, or by registering in the invite-only registration mode.
+func (c *Client) CreateInvitationUser(ctx context.Context, path string, payload *InvitationPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewCreateInvitationUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewCreateInvitationUserRequest create the request corresponding to the createInvitation action endpoint of the user resource.
+func (c *Client) NewCreateInvitationUserRequest(ctx context.Context, path string, payload *InvitationPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// CreateTokenUserPath computes a request path to the createToken action of user.
+func CreateTokenUserPath() string {
+
+	return fmt.Sprintf("/users/me/tokens")
+}
+
+// Create a personal access token for the authenticated user. The token is returned only once.
+func (c *Client) CreateTokenUser(ctx context.Context, path string, payload *CreateAccessTokenPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewCreateTokenUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewCreateTokenUserRequest create the request corresponding to the createToken action endpoint of the user resource.
+func (c *Client) NewCreateTokenUserRequest(ctx context.Context, path string, payload *CreateAccessTokenPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// DeactivateUserPath computes a request path to the deactivate action of user.
+func DeactivateUserPath(userID string) string {
+	param0 := userID
+
+	return fmt.Sprintf("/users/%s/deactivate", param0)
+}
+
+// Deactivate user
+func (c *Client) DeactivateUser(ctx context.Context, path string, payload *StatusChangePayload, contentType string) (*http.Response, error) {
+	req, err := c.NewDeactivateUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewDeactivateUserRequest create the request corresponding to the deactivate action endpoint of the user resource.
+func (c *Client) NewDeactivateUserRequest(ctx context.Context, path string, payload *StatusChangePayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// DeleteUserPath computes a request path to the delete action of user.
+func DeleteUserPath(userID string) string {
+	param0 := userID
+
+	return fmt.Sprintf("/users/%s", param0)
+}
+
+// Soft-delete user. The user is hidden from all lookups until restored.
+func (c *Client) DeleteUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewDeleteUserRequest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewDeleteUserRequest create the request corresponding to the delete action endpoint of the user resource.
+func (c *Client) NewDeleteUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// EnrollTotpUserPath computes a request path to the enrollTotp action of user.
+func EnrollTotpUserPath() string {
+
+	return fmt.Sprintf("/users/me/mfa/totp")
+}
+
+// Start TOTP multi-factor authentication enrollment for the authenticated user
+func (c *Client) EnrollTotpUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewEnrollTotpUserRequest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewEnrollTotpUserRequest create the request corresponding to the enrollTotp action endpoint of the user resource.
+func (c *Client) NewEnrollTotpUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// FindUserPath computes a request path to the find action of user.
+func FindUserPath() string {
+
+	return fmt.Sprintf("/users/find")
+}
+
+// Find a user by email+password
+func (c *Client) FindUser(ctx context.Context, path string, payload *Credentials, contentType string) (*http.Response, error) {
+	req, err := c.NewFindUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewFindUserRequest create the request corresponding to the find action endpoint of the user resource.
+func (c *Client) NewFindUserRequest(ctx context.Context, path string, payload *Credentials, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// FindByEmailUserPath computes a request path to the findByEmail action of user.
+func FindByEmailUserPath() string {
+
+	return fmt.Sprintf("/users/find/email")
+}
+
+// Find a user by email
+func (c *Client) FindByEmailUser(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewFindByEmailUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewFindByEmailUserRequest create the request corresponding to the findByEmail action endpoint of the user resource.
+func (c *Client) NewFindByEmailUserRequest(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// FindByMagicLinkUserPath computes a request path to the findByMagicLink action of user.
+func FindByMagicLinkUserPath() string {
+
+	return fmt.Sprintf("/users/find/magic-link")
+}
+
+// Find a user by magic link token. The token is consumed. Intended for internal use.
+func (c *Client) FindByMagicLinkUser(ctx context.Context, path string, payload *TokenPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewFindByMagicLinkUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewFindByMagicLinkUserRequest create the request corresponding to the findByMagicLink action endpoint of the user resource.
+func (c *Client) NewFindByMagicLinkUserRequest(ctx context.Context, path string, payload *TokenPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// FindByTokenUserPath computes a request path to the findByToken action of user.
+func FindByTokenUserPath() string {
+
+	return fmt.Sprintf("/users/find/token")
+}
+
+// Find a user by personal access token. Intended for internal use.
+func (c *Client) FindByTokenUser(ctx context.Context, path string, payload *AccessTokenPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewFindByTokenUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewFindByTokenUserRequest create the request corresponding to the findByToken action endpoint of the user resource.
+func (c *Client) NewFindByTokenUserRequest(ctx context.Context, path string, payload *AccessTokenPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// FindUsersUserPath computes a request path to the findUsers action of user.
+func FindUsersUserPath() string {
+
+	return fmt.Sprintf("/users/list")
+}
+
+// Find (filter) users by some filter.
+func (c *Client) FindUsersUser(ctx context.Context, path string, payload *FilterPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewFindUsersUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewFindUsersUserRequest create the request corresponding to the findUsers action endpoint of the user resource.
+func (c *Client) NewFindUsersUserRequest(ctx context.Context, path string, payload *FilterPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// ForgotPasswordUserPath computes a request path to the forgotPassword action of user.
+func ForgotPasswordUserPath() string {
+
+	return fmt.Sprintf("/users/password/forgot")
+}
+
+// Forgot password action (sending email to user with link for resseting password)
+func (c *Client) ForgotPasswordUser(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewForgotPasswordUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewForgotPasswordUserRequest create the request corresponding to the forgotPassword action endpoint of the user resource.
+func (c *Client) NewForgotPasswordUserRequest(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// ForgotPasswordUpdateUserPath computes a request path to the forgotPasswordUpdate action of user.
+func ForgotPasswordUpdateUserPath() string {
+
+	return fmt.Sprintf("/users/password/forgot")
+}
+
+// Password token validation & password update
+func (c *Client) ForgotPasswordUpdateUser(ctx context.Context, path string, payload *ForgotPasswordPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewForgotPasswordUpdateUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewForgotPasswordUpdateUserRequest create the request corresponding to the forgotPasswordUpdate action endpoint of the user resource.
+func (c *Client) NewForgotPasswordUpdateUserRequest(ctx context.Context, path string, payload *ForgotPasswordPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("PUT", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// GetUserPath computes a request path to the get action of user.
+func GetUserPath(userID string) string {
+	param0 := userID
+
+	return fmt.Sprintf("/users/%s", param0)
+}
+
+// Get user by id
+func (c *Client) GetUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewGetUserRequest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewGetUserRequest create the request corresponding to the get action endpoint of the user resource.
+func (c *Client) NewGetUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// GetAllUserPath computes a request path to the getAll action of user.
+func GetAllUserPath() string {
+
+	return fmt.Sprintf("/users")
+}
+
+// Retrieves all active users
+func (c *Client) GetAllUser(ctx context.Context, path string, limit *int, offset *int, order *string, sorting *string) (*http.Response, error) {
+	req, err := c.NewGetAllUserRequest(ctx, path, limit, offset, order, sorting)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewGetAllUserRequest create the request corresponding to the getAll action endpoint of the user resource.
+func (c *Client) NewGetAllUserRequest(ctx context.Context, path string, limit *int, offset *int, order *string, sorting *string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	values := u.Query()
+	if limit != nil {
+		tmp45 := strconv.Itoa(*limit)
+		values.Set("limit", tmp45)
+	}
+	if offset != nil {
+		tmp46 := strconv.Itoa(*offset)
+		values.Set("offset", tmp46)
+	}
+	if order != nil {
+		values.Set("order", *order)
+	}
+	if sorting != nil {
+		values.Set("sorting", *sorting)
+	}
+	u.RawQuery = values.Encode()
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// GetDuplicatesUserPath computes a request path to the getDuplicates action of user.
+func GetDuplicatesUserPath() string {
+
+	return fmt.Sprintf("/users/duplicates")
+}
+
+// Report the users whose emails differ only in the letter case or the form of the domain
+func (c *Client) GetDuplicatesUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewGetDuplicatesUserRequest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewGetDuplicatesUserRequest create the request corresponding to the getDuplicates action endpoint of the user resource.
+func (c *Client) NewGetDuplicatesUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// GetLoginsUserPath computes a request path to the getLogins action of user.
+func GetLoginsUserPath(userID string) string {
+	param0 := userID
+
+	return fmt.Sprintf("/users/%s/logins", param0)
+}
+
+// Retrieves the login history of a user, the most recent first
+func (c *Client) GetLoginsUser(ctx context.Context, path string, limit *int, offset *int) (*http.Response, error) {
+	req, err := c.NewGetLoginsUserRequest(ctx, path, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewGetLoginsUserRequest create the request corresponding to the getLogins action endpoint of the user resource.
+func (c *Client) NewGetLoginsUserRequest(ctx context.Context, path string, limit *int, offset *int) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	values := u.Query()
+	if limit != nil {
+		tmp47 := strconv.Itoa(*limit)
+		values.Set("limit", tmp47)
+	}
+	if offset != nil {
+		tmp48 := strconv.Itoa(*offset)
+		values.Set("offset", tmp48)
+	}
+	u.RawQuery = values.Encode()
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// GetMeUserPath computes a request path to the getMe action of user.
+func GetMeUserPath() string {
+
+	return fmt.Sprintf("/users/me")
+}
+
+// Retrieves the user information for the authenticated user
+func (c *Client) GetMeUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewGetMeUserRequest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewGetMeUserRequest create the request corresponding to the getMe action endpoint of the user resource.
+func (c *Client) NewGetMeUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// GetMyLoginsUserPath computes a request path to the getMyLogins action of user.
+func GetMyLoginsUserPath() string {
+
+	return fmt.Sprintf("/users/me/logins")
+}
+
+// Retrieves the login history of the authenticated user, the most recent first
+func (c *Client) GetMyLoginsUser(ctx context.Context, path string, limit *int, offset *int) (*http.Response, error) {
+	req, err := c.NewGetMyLoginsUserRequest(ctx, path, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewGetMyLoginsUserRequest create the request corresponding to the getMyLogins action endpoint of the user resource.
+func (c *Client) NewGetMyLoginsUserRequest(ctx context.Context, path string, limit *int, offset *int) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	values := u.Query()
+	if limit != nil {
+		tmp49 := strconv.Itoa(*limit)
+		values.Set("limit", tmp49)
+	}
+	if offset != nil {
+		tmp50 := strconv.Itoa(*offset)
+		values.Set("offset", tmp50)
+	}
+	u.RawQuery = values.Encode()
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ListInvitationsUserPath computes a request path to the listInvitations action of user.
+func ListInvitationsUserPath() string {
+
+	return fmt.Sprintf("/users/invitations")
+}
+
+// List the pending invitations
+func (c *Client) ListInvitationsUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewListInvitationsUserRequest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewListInvitationsUserRequest create the request corresponding to the listInvitations action endpoint of the user resource.
+func (c *Client) NewListInvitationsUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ListTokensUserPath computes a request path to the listTokens action of user.
+func ListTokensUserPath() string {
+
+	return fmt.Sprintf("/users/me/tokens")
+}
+
+// List the personal access tokens of the authenticated user
+func (c *Client) ListTokensUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewListTokensUserRequest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewListTokensUserRequest create the request corresponding to the listTokens action endpoint of the user resource.
+func (c *Client) NewListTokensUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// MergeUsersUserPath computes a request path to the mergeUsers action of user.
+func MergeUsersUserPath() string {
+
+	return fmt.Sprintf("/users/duplicates/merge")
+}
+
+// Merge duplicate users into one user. The roles and memberships are combined and the duplicates are deleted.
+func (c *Client) MergeUsersUser(ctx context.Context, path string, payload *MergeUsersPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewMergeUsersUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewMergeUsersUserRequest create the request corresponding to the mergeUsers action endpoint of the user resource.
+func (c *Client) NewMergeUsersUserRequest(ctx context.Context, path string, payload *MergeUsersPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// PatchUserPayload is the user patch action payload.
+type PatchUserPayload map[string]interface{}
+
+// PatchUserPath computes a request path to the patch action of user.
+func PatchUserPath(userID string) string {
+	param0 := userID
+
+	return fmt.Sprintf("/users/%s", param0)
+}
+
+// Partially update user with a JSON merge patch (application/merge-patch+json). Absent fields are left as they are, null fields are cleared.
+func (c *Client) PatchUser(ctx context.Context, path string, payload PatchUserPayload, ifMatch *string, contentType string) (*http.Response, error) {
+	req, err := c.NewPatchUserRequest(ctx, path, payload, ifMatch, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewPatchUserRequest create the request corresponding to the patch action endpoint of the user resource.
+func (c *Client) NewPatchUserRequest(ctx context.Context, path string, payload PatchUserPayload, ifMatch *string, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("PATCH", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	if ifMatch != nil {
+
+		header.Set("If-Match", *ifMatch)
+	}
+	return req, nil
+}
+
+// PatchMeUserPayload is the user patchMe action payload.
+type PatchMeUserPayload map[string]interface{}
+
+// PatchMeUserPath computes a request path to the patchMe action of user.
+func PatchMeUserPath() string {
+
+	return fmt.Sprintf("/users/me")
 }
 
-// Creates user
-func (c *Client) CreateUser(ctx context.Context, path string, payload *CreateUserPayload, contentType string) (*http.Response, error) {
-	req, err := c.NewCreateUserRequest(ctx, path, payload, contentType)
+// Partially update the profile of the authenticated user with a JSON merge patch (application/merge-patch+json). Only the fields of UpdateMePayload are accepted.
+func (c *Client) PatchMeUser(ctx context.Context, path string, payload PatchMeUserPayload, ifMatch *string, contentType string) (*http.Response, error) {
+	req, err := c.NewPatchMeUserRequest(ctx, path, payload, ifMatch, contentType)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewCreateUserRequest create the request corresponding to the create action endpoint of the user resource.
-func (c *Client) NewCreateUserRequest(ctx context.Context, path string, payload *CreateUserPayload, contentType string) (*http.Request, error) {
+// NewPatchMeUserRequest create the request corresponding to the patchMe action endpoint of the user resource.
+func (c *Client) NewPatchMeUserRequest(ctx context.Context, path string, payload PatchMeUserPayload, ifMatch *string, contentType string) (*http.Request, error) {
 	var body bytes.Buffer
 	if contentType == "" {
 		contentType = "*/*" // Use default encoder
@@ -49,7 +1121,7 @@ func (c *Client) NewCreateUserRequest(ctx context.Context, path string, payload
 		scheme = "http"
 	}
 	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
-	req, err := http.NewRequest("POST", u.String(), &body)
+	req, err := http.NewRequest("PATCH", u.String(), &body)
 	if err != nil {
 		return nil, err
 	}
@@ -59,26 +1131,61 @@ func (c *Client) NewCreateUserRequest(ctx context.Context, path string, payload
 	} else {
 		header.Set("Content-Type", contentType)
 	}
+	if ifMatch != nil {
+
+		header.Set("If-Match", *ifMatch)
+	}
 	return req, nil
 }
 
-// FindUserPath computes a request path to the find action of user.
-func FindUserPath() string {
+// PurgeUserPath computes a request path to the purge action of user.
+func PurgeUserPath(userID string) string {
+	param0 := userID
 
-	return fmt.Sprintf("/users/find")
+	return fmt.Sprintf("/users/%s/purge", param0)
 }
 
-// Find a user by email+password
-func (c *Client) FindUser(ctx context.Context, path string, payload *Credentials, contentType string) (*http.Response, error) {
-	req, err := c.NewFindUserRequest(ctx, path, payload, contentType)
+// Permanently remove user and all of the user's tokens. Admin only.
+func (c *Client) PurgeUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewPurgeUserRequest(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewFindUserRequest create the request corresponding to the find action endpoint of the user resource.
-func (c *Client) NewFindUserRequest(ctx context.Context, path string, payload *Credentials, contentType string) (*http.Request, error) {
+// NewPurgeUserRequest create the request corresponding to the purge action endpoint of the user resource.
+func (c *Client) NewPurgeUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ReactivateUserPath computes a request path to the reactivate action of user.
+func ReactivateUserPath(userID string) string {
+	param0 := userID
+
+	return fmt.Sprintf("/users/%s/reactivate", param0)
+}
+
+// Reactivate suspended, locked or deactivated user
+func (c *Client) ReactivateUser(ctx context.Context, path string, payload *StatusChangePayload, contentType string) (*http.Response, error) {
+	req, err := c.NewReactivateUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewReactivateUserRequest create the request corresponding to the reactivate action endpoint of the user resource.
+func (c *Client) NewReactivateUserRequest(ctx context.Context, path string, payload *StatusChangePayload, contentType string) (*http.Request, error) {
 	var body bytes.Buffer
 	if contentType == "" {
 		contentType = "*/*" // Use default encoder
@@ -105,23 +1212,23 @@ func (c *Client) NewFindUserRequest(ctx context.Context, path string, payload *C
 	return req, nil
 }
 
-// FindByEmailUserPath computes a request path to the findByEmail action of user.
-func FindByEmailUserPath() string {
+// RegisterUserPath computes a request path to the register action of user.
+func RegisterUserPath() string {
 
-	return fmt.Sprintf("/users/find/email")
+	return fmt.Sprintf("/users/register")
 }
 
-// Find a user by email
-func (c *Client) FindByEmailUser(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Response, error) {
-	req, err := c.NewFindByEmailUserRequest(ctx, path, payload, contentType)
+// Self-service registration. The user is created inactive with the user role, and a verification email is sent. In the invite-only mode, the invitation is accepted as with acceptInvitation.
+func (c *Client) RegisterUser(ctx context.Context, path string, payload *RegisterPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewRegisterUserRequest(ctx, path, payload, contentType)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewFindByEmailUserRequest create the request corresponding to the findByEmail action endpoint of the user resource.
-func (c *Client) NewFindByEmailUserRequest(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Request, error) {
+// NewRegisterUserRequest create the request corresponding to the register action endpoint of the user resource.
+func (c *Client) NewRegisterUserRequest(ctx context.Context, path string, payload *RegisterPayload, contentType string) (*http.Request, error) {
 	var body bytes.Buffer
 	if contentType == "" {
 		contentType = "*/*" // Use default encoder
@@ -148,23 +1255,23 @@ func (c *Client) NewFindByEmailUserRequest(ctx context.Context, path string, pay
 	return req, nil
 }
 
-// FindUsersUserPath computes a request path to the findUsers action of user.
-func FindUsersUserPath() string {
+// RequestEmailChangeUserPath computes a request path to the requestEmailChange action of user.
+func RequestEmailChangeUserPath() string {
 
-	return fmt.Sprintf("/users/list")
+	return fmt.Sprintf("/users/me/email")
 }
 
-// Find (filter) users by some filter.
-func (c *Client) FindUsersUser(ctx context.Context, path string, payload *FilterPayload, contentType string) (*http.Response, error) {
-	req, err := c.NewFindUsersUserRequest(ctx, path, payload, contentType)
+// Request an email change for the authenticated user. The email is changed once the token sent to the new address is confirmed.
+func (c *Client) RequestEmailChangeUser(ctx context.Context, path string, payload *ChangeEmailPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewRequestEmailChangeUserRequest(ctx, path, payload, contentType)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewFindUsersUserRequest create the request corresponding to the findUsers action endpoint of the user resource.
-func (c *Client) NewFindUsersUserRequest(ctx context.Context, path string, payload *FilterPayload, contentType string) (*http.Request, error) {
+// NewRequestEmailChangeUserRequest create the request corresponding to the requestEmailChange action endpoint of the user resource.
+func (c *Client) NewRequestEmailChangeUserRequest(ctx context.Context, path string, payload *ChangeEmailPayload, contentType string) (*http.Request, error) {
 	var body bytes.Buffer
 	if contentType == "" {
 		contentType = "*/*" // Use default encoder
@@ -191,23 +1298,23 @@ func (c *Client) NewFindUsersUserRequest(ctx context.Context, path string, paylo
 	return req, nil
 }
 
-// ForgotPasswordUserPath computes a request path to the forgotPassword action of user.
-func ForgotPasswordUserPath() string {
+// RequestMagicLinkUserPath computes a request path to the requestMagicLink action of user.
+func RequestMagicLinkUserPath() string {
 
-	return fmt.Sprintf("/users/password/forgot")
+	return fmt.Sprintf("/users/magic-link")
 }
 
-// Forgot password action (sending email to user with link for resseting password)
-func (c *Client) ForgotPasswordUser(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Response, error) {
-	req, err := c.NewForgotPasswordUserRequest(ctx, path, payload, contentType)
+// Send a single-use sign-in link to the email of the user
+func (c *Client) RequestMagicLinkUser(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewRequestMagicLinkUserRequest(ctx, path, payload, contentType)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewForgotPasswordUserRequest create the request corresponding to the forgotPassword action endpoint of the user resource.
-func (c *Client) NewForgotPasswordUserRequest(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Request, error) {
+// NewRequestMagicLinkUserRequest create the request corresponding to the requestMagicLink action endpoint of the user resource.
+func (c *Client) NewRequestMagicLinkUserRequest(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Request, error) {
 	var body bytes.Buffer
 	if contentType == "" {
 		contentType = "*/*" // Use default encoder
@@ -234,23 +1341,53 @@ func (c *Client) NewForgotPasswordUserRequest(ctx context.Context, path string,
 	return req, nil
 }
 
-// ForgotPasswordUpdateUserPath computes a request path to the forgotPasswordUpdate action of user.
-func ForgotPasswordUpdateUserPath() string {
+// ResetMfaUserPath computes a request path to the resetMfa action of user.
+func ResetMfaUserPath(userID string) string {
+	param0 := userID
 
-	return fmt.Sprintf("/users/password/forgot")
+	return fmt.Sprintf("/users/%s/mfa", param0)
 }
 
-// Password token validation & password update
-func (c *Client) ForgotPasswordUpdateUser(ctx context.Context, path string, payload *ForgotPasswordPayload, contentType string) (*http.Response, error) {
-	req, err := c.NewForgotPasswordUpdateUserRequest(ctx, path, payload, contentType)
+// Disable MFA for a user and remove the TOTP secret and recovery codes
+func (c *Client) ResetMfaUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewResetMfaUserRequest(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewForgotPasswordUpdateUserRequest create the request corresponding to the forgotPasswordUpdate action endpoint of the user resource.
-func (c *Client) NewForgotPasswordUpdateUserRequest(ctx context.Context, path string, payload *ForgotPasswordPayload, contentType string) (*http.Request, error) {
+// NewResetMfaUserRequest create the request corresponding to the resetMfa action endpoint of the user resource.
+func (c *Client) NewResetMfaUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ResetVerificationTokenUserPath computes a request path to the resetVerificationToken action of user.
+func ResetVerificationTokenUserPath() string {
+
+	return fmt.Sprintf("/users/verification/reset")
+}
+
+// Reset verification token
+func (c *Client) ResetVerificationTokenUser(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewResetVerificationTokenUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewResetVerificationTokenUserRequest create the request corresponding to the resetVerificationToken action endpoint of the user resource.
+func (c *Client) NewResetVerificationTokenUserRequest(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Request, error) {
 	var body bytes.Buffer
 	if contentType == "" {
 		contentType = "*/*" // Use default encoder
@@ -264,7 +1401,7 @@ func (c *Client) NewForgotPasswordUpdateUserRequest(ctx context.Context, path st
 		scheme = "http"
 	}
 	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
-	req, err := http.NewRequest("PUT", u.String(), &body)
+	req, err := http.NewRequest("POST", u.String(), &body)
 	if err != nil {
 		return nil, err
 	}
@@ -277,127 +1414,158 @@ func (c *Client) NewForgotPasswordUpdateUserRequest(ctx context.Context, path st
 	return req, nil
 }
 
-// GetUserPath computes a request path to the get action of user.
-func GetUserPath(userID string) string {
+// RestoreUserPath computes a request path to the restore action of user.
+func RestoreUserPath(userID string) string {
 	param0 := userID
 
-	return fmt.Sprintf("/users/%s", param0)
+	return fmt.Sprintf("/users/%s/restore", param0)
 }
 
-// Get user by id
-func (c *Client) GetUser(ctx context.Context, path string) (*http.Response, error) {
-	req, err := c.NewGetUserRequest(ctx, path)
+// Restore soft-deleted user
+func (c *Client) RestoreUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewRestoreUserRequest(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewGetUserRequest create the request corresponding to the get action endpoint of the user resource.
-func (c *Client) NewGetUserRequest(ctx context.Context, path string) (*http.Request, error) {
+// NewRestoreUserRequest create the request corresponding to the restore action endpoint of the user resource.
+func (c *Client) NewRestoreUserRequest(ctx context.Context, path string) (*http.Request, error) {
 	scheme := c.Scheme
 	if scheme == "" {
 		scheme = "http"
 	}
 	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequest("POST", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 	return req, nil
 }
 
-// GetAllUserPath computes a request path to the getAll action of user.
-func GetAllUserPath() string {
+// RevokeInvitationUserPath computes a request path to the revokeInvitation action of user.
+func RevokeInvitationUserPath(invitationID string) string {
+	param0 := invitationID
 
-	return fmt.Sprintf("/users")
+	return fmt.Sprintf("/users/invitations/%s", param0)
 }
 
-// Retrieves all active users
-func (c *Client) GetAllUser(ctx context.Context, path string, limit *int, offset *int, order *string, sorting *string) (*http.Response, error) {
-	req, err := c.NewGetAllUserRequest(ctx, path, limit, offset, order, sorting)
+// Revoke a pending invitation
+func (c *Client) RevokeInvitationUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewRevokeInvitationUserRequest(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewGetAllUserRequest create the request corresponding to the getAll action endpoint of the user resource.
-func (c *Client) NewGetAllUserRequest(ctx context.Context, path string, limit *int, offset *int, order *string, sorting *string) (*http.Request, error) {
+// NewRevokeInvitationUserRequest create the request corresponding to the revokeInvitation action endpoint of the user resource.
+func (c *Client) NewRevokeInvitationUserRequest(ctx context.Context, path string) (*http.Request, error) {
 	scheme := c.Scheme
 	if scheme == "" {
 		scheme = "http"
 	}
 	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
-	values := u.Query()
-	if limit != nil {
-		tmp13 := strconv.Itoa(*limit)
-		values.Set("limit", tmp13)
-	}
-	if offset != nil {
-		tmp14 := strconv.Itoa(*offset)
-		values.Set("offset", tmp14)
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	if order != nil {
-		values.Set("order", *order)
+	return req, nil
+}
+
+// RevokeTokenUserPath computes a request path to the revokeToken action of user.
+func RevokeTokenUserPath(tokenID string) string {
+	param0 := tokenID
+
+	return fmt.Sprintf("/users/me/tokens/%s", param0)
+}
+
+// Revoke a personal access token of the authenticated user
+func (c *Client) RevokeTokenUser(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewRevokeTokenUserRequest(ctx, path)
+	if err != nil {
+		return nil, err
 	}
-	if sorting != nil {
-		values.Set("sorting", *sorting)
+	return c.Client.Do(ctx, req)
+}
+
+// NewRevokeTokenUserRequest create the request corresponding to the revokeToken action endpoint of the user resource.
+func (c *Client) NewRevokeTokenUserRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
 	}
-	u.RawQuery = values.Encode()
-	req, err := http.NewRequest("GET", u.String(), nil)
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 	return req, nil
 }
 
-// GetMeUserPath computes a request path to the getMe action of user.
-func GetMeUserPath() string {
+// SuspendUserPath computes a request path to the suspend action of user.
+func SuspendUserPath(userID string) string {
+	param0 := userID
 
-	return fmt.Sprintf("/users/me")
+	return fmt.Sprintf("/users/%s/suspend", param0)
 }
 
-// Retrieves the user information for the authenticated user
-func (c *Client) GetMeUser(ctx context.Context, path string) (*http.Response, error) {
-	req, err := c.NewGetMeUserRequest(ctx, path)
+// Suspend user
+func (c *Client) SuspendUser(ctx context.Context, path string, payload *StatusChangePayload, contentType string) (*http.Response, error) {
+	req, err := c.NewSuspendUserRequest(ctx, path, payload, contentType)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewGetMeUserRequest create the request corresponding to the getMe action endpoint of the user resource.
-func (c *Client) NewGetMeUserRequest(ctx context.Context, path string) (*http.Request, error) {
+// NewSuspendUserRequest create the request corresponding to the suspend action endpoint of the user resource.
+func (c *Client) NewSuspendUserRequest(ctx context.Context, path string, payload *StatusChangePayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
 	scheme := c.Scheme
 	if scheme == "" {
 		scheme = "http"
 	}
 	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequest("POST", u.String(), &body)
 	if err != nil {
 		return nil, err
 	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
 	return req, nil
 }
 
-// ResetVerificationTokenUserPath computes a request path to the resetVerificationToken action of user.
-func ResetVerificationTokenUserPath() string {
+// UnlockUserPath computes a request path to the unlock action of user.
+func UnlockUserPath(userID string) string {
+	param0 := userID
 
-	return fmt.Sprintf("/users/verification/reset")
+	return fmt.Sprintf("/users/%s/unlock", param0)
 }
 
-// Reset verification token
-func (c *Client) ResetVerificationTokenUser(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Response, error) {
-	req, err := c.NewResetVerificationTokenUserRequest(ctx, path, payload, contentType)
+// Unlock user locked after too many failed logins
+func (c *Client) UnlockUser(ctx context.Context, path string, payload *StatusChangePayload, contentType string) (*http.Response, error) {
+	req, err := c.NewUnlockUserRequest(ctx, path, payload, contentType)
 	if err != nil {
 		return nil, err
 	}
 	return c.Client.Do(ctx, req)
 }
 
-// NewResetVerificationTokenUserRequest create the request corresponding to the resetVerificationToken action endpoint of the user resource.
-func (c *Client) NewResetVerificationTokenUserRequest(ctx context.Context, path string, payload *EmailPayload, contentType string) (*http.Request, error) {
+// NewUnlockUserRequest create the request corresponding to the unlock action endpoint of the user resource.
+func (c *Client) NewUnlockUserRequest(ctx context.Context, path string, payload *StatusChangePayload, contentType string) (*http.Request, error) {
 	var body bytes.Buffer
 	if contentType == "" {
 		contentType = "*/*" // Use default encoder
@@ -432,8 +1600,8 @@ func UpdateUserPath(userID string) string {
 }
 
 // Update user
-func (c *Client) UpdateUser(ctx context.Context, path string, payload *UpdateUserPayload, contentType string) (*http.Response, error) {
-	req, err := c.NewUpdateUserRequest(ctx, path, payload, contentType)
+func (c *Client) UpdateUser(ctx context.Context, path string, payload *UpdateUserPayload, ifMatch *string, contentType string) (*http.Response, error) {
+	req, err := c.NewUpdateUserRequest(ctx, path, payload, ifMatch, contentType)
 	if err != nil {
 		return nil, err
 	}
@@ -441,7 +1609,54 @@ func (c *Client) UpdateUser(ctx context.Context, path string, payload *UpdateUse
 }
 
 // NewUpdateUserRequest create the request corresponding to the update action endpoint of the user resource.
-func (c *Client) NewUpdateUserRequest(ctx context.Context, path string, payload *UpdateUserPayload, contentType string) (*http.Request, error) {
+func (c *Client) NewUpdateUserRequest(ctx context.Context, path string, payload *UpdateUserPayload, ifMatch *string, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("PUT", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	if ifMatch != nil {
+
+		header.Set("If-Match", *ifMatch)
+	}
+	return req, nil
+}
+
+// UpdateMeUserPath computes a request path to the updateMe action of user.
+func UpdateMeUserPath() string {
+
+	return fmt.Sprintf("/users/me")
+}
+
+// Update the profile of the authenticated user. The email and the password are changed through their usual flows, both need the current password.
+func (c *Client) UpdateMeUser(ctx context.Context, path string, payload *UpdateMePayload, ifMatch *string, contentType string) (*http.Response, error) {
+	req, err := c.NewUpdateMeUserRequest(ctx, path, payload, ifMatch, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewUpdateMeUserRequest create the request corresponding to the updateMe action endpoint of the user resource.
+func (c *Client) NewUpdateMeUserRequest(ctx context.Context, path string, payload *UpdateMePayload, ifMatch *string, contentType string) (*http.Request, error) {
 	var body bytes.Buffer
 	if contentType == "" {
 		contentType = "*/*" // Use default encoder
@@ -465,6 +1680,10 @@ func (c *Client) NewUpdateUserRequest(ctx context.Context, path string, payload
 	} else {
 		header.Set("Content-Type", contentType)
 	}
+	if ifMatch != nil {
+
+		header.Set("If-Match", *ifMatch)
+	}
 	return req, nil
 }
 
@@ -501,3 +1720,46 @@ func (c *Client) NewVerifyUserRequest(ctx context.Context, path string, token *s
 	}
 	return req, nil
 }
+
+// VerifyMfaUserPath computes a request path to the verifyMfa action of user.
+func VerifyMfaUserPath() string {
+
+	return fmt.Sprintf("/users/mfa/verify")
+}
+
+// Verify the second factor (TOTP or recovery code) of a user. Intended for internal use after find.
+func (c *Client) VerifyMfaUser(ctx context.Context, path string, payload *MFAVerifyPayload, contentType string) (*http.Response, error) {
+	req, err := c.NewVerifyMfaUserRequest(ctx, path, payload, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewVerifyMfaUserRequest create the request corresponding to the verifyMfa action endpoint of the user resource.
+func (c *Client) NewVerifyMfaUserRequest(ctx context.Context, path string, payload *MFAVerifyPayload, contentType string) (*http.Request, error) {
+	var body bytes.Buffer
+	if contentType == "" {
+		contentType = "*/*" // Use default encoder
+	}
+	err := c.Encoder.Encode(payload, &body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("POST", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	if contentType == "*/*" {
+		header.Set("Content-Type", "application/json")
+	} else {
+		header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
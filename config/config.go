@@ -1,6 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
 	stdcfg "github.com/Microkubes/microservice-tools/config"
 	"github.com/Microkubes/microservice-tools/gateway"
 )
@@ -24,6 +28,206 @@ type ServiceConfig struct {
 	Version string `json:"version"`
 	// RabbitMQ holds information about the rabbitmq server
 	RabbitMQ map[string]string `json:"rabbitmq"`
+	// PasswordPolicy holds the rules that every new password must satisfy
+	PasswordPolicy *PasswordPolicy `json:"passwordPolicy,omitempty"`
+	// PasswordHashing holds the configuration of the algorithm used to hash the passwords
+	PasswordHashing *PasswordHashing `json:"passwordHashing,omitempty"`
+	// Lockout holds the configuration for locking accounts after failed logins
+	Lockout *Lockout `json:"lockout,omitempty"`
+	// MFA holds the multi-factor authentication configuration
+	MFA *MFA `json:"mfa,omitempty"`
+	// VerificationToken holds the configuration of the email verification tokens
+	VerificationToken *VerificationToken `json:"verificationToken,omitempty"`
+	// TokenSecret is the secret key used to hash the email verification and password reset tokens
+	TokenSecret string `json:"tokenSecret,omitempty"`
+	// TokenSecretFile is the path to a file holding the token secret key. Takes precedence over TokenSecret.
+	TokenSecretFile string `json:"tokenSecretFile,omitempty"`
+	// LoginHistorySize is the number of recent logins kept for every user. Defaults to 50.
+	LoginHistorySize int `json:"loginHistorySize,omitempty"`
+	// Registration holds the configuration of the self-service registration
+	Registration *Registration `json:"registration,omitempty"`
+	// InvitationTTL is the time, in seconds, after which an invitation expires. Defaults to 7 days.
+	InvitationTTL int `json:"invitationTtl,omitempty"`
+	// MagicLinkTTL is the time, in seconds, after which a magic link sign-in token expires. Defaults to 15 minutes.
+	MagicLinkTTL int `json:"magicLinkTtl,omitempty"`
+	// FieldPolicy maps the fields of the user to the roles allowed to change them. Merged over the default policy.
+	FieldPolicy map[string][]string `json:"fieldPolicy,omitempty"`
+	// Profile holds the schema of the profile attributes of the users, by attribute name
+	Profile map[string]ProfileAttribute `json:"profile,omitempty"`
+}
+
+// PasswordHashing holds the password hashing configuration. Zero values fall back to the defaults.
+type PasswordHashing struct {
+	// Algorithm is the algorithm used for hashing new passwords. Can be "bcrypt" (default) or "argon2id".
+	Algorithm string `json:"algorithm,omitempty"`
+	// BcryptCost is the bcrypt cost factor
+	BcryptCost int `json:"bcryptCost,omitempty"`
+	// Argon2 holds the Argon2id parameters
+	Argon2 Argon2Params `json:"argon2,omitempty"`
+}
+
+// Argon2Params holds the Argon2id hashing parameters.
+type Argon2Params struct {
+	// Memory is the amount of memory used, in KiB
+	Memory uint32 `json:"memory,omitempty"`
+	// Iterations is the number of passes over the memory
+	Iterations uint32 `json:"iterations,omitempty"`
+	// Parallelism is the number of threads used
+	Parallelism uint8 `json:"parallelism,omitempty"`
+	// SaltLength is the length of the random salt, in bytes
+	SaltLength uint32 `json:"saltLength,omitempty"`
+	// KeyLength is the length of the generated key, in bytes
+	KeyLength uint32 `json:"keyLength,omitempty"`
+}
+
+// PasswordPolicy holds the configuration of the password policy. Zero values fall back to the defaults.
+type PasswordPolicy struct {
+	// MinLength is the minimal length of the password
+	MinLength int `json:"minLength,omitempty"`
+	// MaxLength is the maximal length of the password
+	MaxLength int `json:"maxLength,omitempty"`
+	// RequireUppercase requires at least one uppercase letter in the password
+	RequireUppercase bool `json:"requireUppercase,omitempty"`
+	// RequireLowercase requires at least one lowercase letter in the password
+	RequireLowercase bool `json:"requireLowercase,omitempty"`
+	// RequireDigit requires at least one digit in the password
+	RequireDigit bool `json:"requireDigit,omitempty"`
+	// RequireSpecial requires at least one character that is not a letter or a digit in the password
+	RequireSpecial bool `json:"requireSpecial,omitempty"`
+	// ForbidEmailLocalPart forbids passwords containing the local part (before @) of the user's email
+	ForbidEmailLocalPart bool `json:"forbidEmailLocalPart,omitempty"`
+	// DenyListFile is the path to a file with common passwords (one per line) that are not allowed
+	DenyListFile string `json:"denyListFile,omitempty"`
+	// HistorySize is the number of recent passwords that cannot be reused. A negative value disables the check.
+	HistorySize int `json:"historySize,omitempty"`
+}
+
+// GetLockout returns the lockout configuration with the defaults applied.
+func (svc *ServiceConfig) GetLockout() Lockout {
+	lockout := Lockout{}
+	if svc.Lockout != nil {
+		lockout = *svc.Lockout
+	}
+	if lockout.MaxFailedLogins == 0 {
+		lockout.MaxFailedLogins = 5
+	}
+	if lockout.LockDuration <= 0 {
+		lockout.LockDuration = 300
+	}
+	if lockout.MaxLockDuration <= 0 {
+		lockout.MaxLockDuration = 86400
+	}
+	if lockout.MaxLockDuration < lockout.LockDuration {
+		lockout.MaxLockDuration = lockout.LockDuration
+	}
+	return lockout
+}
+
+// GetLoginHistorySize returns the number of recent logins kept for every user, with the default applied.
+func (svc *ServiceConfig) GetLoginHistorySize() int {
+	if svc.LoginHistorySize <= 0 {
+		return 50
+	}
+	return svc.LoginHistorySize
+}
+
+// GetInvitationTTL returns the time, in seconds, after which an invitation expires, with the default applied.
+func (svc *ServiceConfig) GetInvitationTTL() int {
+	if svc.InvitationTTL <= 0 {
+		return 604800
+	}
+	return svc.InvitationTTL
+}
+
+// GetMagicLinkTTL returns the time, in seconds, after which a magic link sign-in token expires, with the default
+// applied.
+func (svc *ServiceConfig) GetMagicLinkTTL() int {
+	if svc.MagicLinkTTL <= 0 {
+		return 900
+	}
+	return svc.MagicLinkTTL
+}
+
+// GetMFA returns the multi-factor authentication configuration with the defaults applied.
+func (svc *ServiceConfig) GetMFA() MFA {
+	mfa := MFA{}
+	if svc.MFA != nil {
+		mfa = *svc.MFA
+	}
+	if mfa.Issuer == "" {
+		mfa.Issuer = "Microkubes"
+	}
+	if mfa.RecoveryCodes <= 0 {
+		mfa.RecoveryCodes = 10
+	}
+	return mfa
+}
+
+// GetVerificationToken returns the email verification token configuration with the defaults applied.
+func (svc *ServiceConfig) GetVerificationToken() VerificationToken {
+	verificationToken := VerificationToken{}
+	if svc.VerificationToken != nil {
+		verificationToken = *svc.VerificationToken
+	}
+	if verificationToken.TTL <= 0 {
+		verificationToken.TTL = 86400
+	}
+	if verificationToken.SweepInterval <= 0 {
+		verificationToken.SweepInterval = 3600
+	}
+	return verificationToken
+}
+
+// GetRegistration returns the self-service registration configuration with the defaults applied.
+// Registration is closed unless configured otherwise.
+func (svc *ServiceConfig) GetRegistration() Registration {
+	registration := Registration{}
+	if svc.Registration != nil {
+		registration = *svc.Registration
+	}
+	if registration.Mode == "" {
+		registration.Mode = RegistrationClosed
+	}
+	return registration
+}
+
+// FieldPolicyOwner is the role, in the field policy, of a user changing their own account.
+const FieldPolicyOwner = "owner"
+
+// GetFieldPolicy returns the field policy: the configured entries merged over the default policy, which allows
+// only admin and system users to change the roles, memberships, status and external id, and also lets users
+// change their own password and profile. Fields that are in neither can be changed by anyone allowed to update the user.
+func (svc *ServiceConfig) GetFieldPolicy() map[string][]string {
+	admin := []string{"admin", "system"}
+	policy := map[string][]string{
+		"roles":         admin,
+		"organizations": admin,
+		"namespaces":    admin,
+		"active":        admin,
+		"externalId":    admin,
+		"password":      {"admin", "system", FieldPolicyOwner},
+		"profile":       {"admin", "system", FieldPolicyOwner},
+	}
+	for field, roles := range svc.FieldPolicy {
+		policy[field] = roles
+	}
+	return policy
+}
+
+// LoadTokenSecret reads the token secret key from TokenSecretFile, if set. Returns an error if no token
+// secret is configured.
+func (svc *ServiceConfig) LoadTokenSecret() error {
+	if svc.TokenSecretFile != "" {
+		secret, err := ioutil.ReadFile(svc.TokenSecretFile)
+		if err != nil {
+			return err
+		}
+		svc.TokenSecret = strings.TrimSpace(string(secret))
+	}
+	if svc.TokenSecret == "" {
+		return fmt.Errorf("token secret is not configured")
+	}
+	return nil
 }
 
 func (svc *ServiceConfig) ToStandardConfig() *stdcfg.ServiceConfig {
@@ -37,3 +241,103 @@ func (svc *ServiceConfig) ToStandardConfig() *stdcfg.ServiceConfig {
 		Version:          svc.Version,
 	}
 }
+
+const (
+	// RegistrationOpen allows anyone to register.
+	RegistrationOpen = "open"
+	// RegistrationClosed disables the self-service registration.
+	RegistrationClosed = "closed"
+	// RegistrationInviteOnly allows only invited users to register.
+	RegistrationInviteOnly = "invite-only"
+)
+
+// Registration holds the configuration of the self-service registration.
+type Registration struct {
+	// Mode is one of "open", "closed" or "invite-only"
+	Mode string `json:"mode,omitempty"`
+	// AllowedDomains, if set, are the only email domains that can register. Subdomains are included.
+	AllowedDomains []string `json:"allowedDomains,omitempty"`
+	// DeniedDomains are the email domains that cannot register. Subdomains are included.
+	DeniedDomains []string `json:"deniedDomains,omitempty"`
+}
+
+const (
+	// ProfileTypeString is the type of text profile attributes.
+	ProfileTypeString = "string"
+	// ProfileTypeNumber is the type of numeric profile attributes.
+	ProfileTypeNumber = "number"
+	// ProfileTypeBoolean is the type of boolean profile attributes.
+	ProfileTypeBoolean = "boolean"
+)
+
+const (
+	// VisibilityPublic profile attributes are visible to everyone allowed to read the user.
+	VisibilityPublic = "public"
+	// VisibilitySelf profile attributes are visible only to the user and to admins.
+	VisibilitySelf = "self"
+	// VisibilityAdmin profile attributes are visible only to admins, and only admins can change them.
+	VisibilityAdmin = "admin"
+)
+
+// ProfileAttribute holds the schema of a profile attribute of the users.
+type ProfileAttribute struct {
+	// Type is one of "string" (default), "number" or "boolean"
+	Type string `json:"type,omitempty"`
+	// Required attributes must be set on every user
+	Required bool `json:"required,omitempty"`
+	// MaxLength is the maximal length of string values
+	MaxLength int `json:"maxLength,omitempty"`
+	// Enum, if set, holds the only allowed values
+	Enum []interface{} `json:"enum,omitempty"`
+	// Pattern is a regular expression that string values must match
+	Pattern string `json:"pattern,omitempty"`
+	// Visibility is one of "public", "self" (default) or "admin"
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// GetProfile returns the schema of the profile attributes, with the default type and visibility applied.
+func (svc *ServiceConfig) GetProfile() map[string]ProfileAttribute {
+	profile := map[string]ProfileAttribute{}
+	for name, attribute := range svc.Profile {
+		if attribute.Type == "" {
+			attribute.Type = ProfileTypeString
+		}
+		if attribute.Visibility == "" {
+			attribute.Visibility = VisibilitySelf
+		}
+		profile[name] = attribute
+	}
+	return profile
+}
+
+// VerificationToken holds the configuration of the email verification tokens.
+type VerificationToken struct {
+	// TTL is the time, in seconds, after which the token expires
+	TTL int `json:"ttl,omitempty"`
+	// SweepInterval is the time, in seconds, between two runs of the sweeper that deletes the expired tokens on
+	// backends without native TTL support
+	SweepInterval int `json:"sweepInterval,omitempty"`
+	// ExternalEmail disables the verification email sent by the service, for callers that send their own.
+	// The token is then returned to the caller instead.
+	ExternalEmail bool `json:"externalEmail,omitempty"`
+}
+
+// MFA holds the multi-factor authentication configuration.
+type MFA struct {
+	// Issuer is the name of the service shown in the authenticator app
+	Issuer string `json:"issuer,omitempty"`
+	// RecoveryCodes is the number of one-time recovery codes generated when MFA is enabled
+	RecoveryCodes int `json:"recoveryCodes,omitempty"`
+}
+
+// Lockout holds the configuration for locking user accounts after consecutive failed logins.
+type Lockout struct {
+	// MaxFailedLogins is the number of consecutive failed logins after which the account is locked.
+	// A negative value disables the lockout.
+	MaxFailedLogins int `json:"maxFailedLogins,omitempty"`
+	// LockDuration is the duration of the first lock, in seconds. Every further failed login after the lock
+	// expires doubles the duration.
+	LockDuration int `json:"lockDuration,omitempty"`
+	// MaxLockDuration is the maximal duration of the lock, in seconds.
+	MaxLockDuration int `json:"maxLockDuration,omitempty"`
+}
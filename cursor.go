@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/keitaroinc/goa"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// usersCursor is the position in cursor mode: the id of the last user of the previous page and the order.
+// Users are ordered by id, so users added while iterating come after the cursor and no user is skipped or
+// listed twice.
+type usersCursor struct {
+	After string `json:"after,omitempty"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// encodeCursor encodes the cursor into an opaque string.
+func encodeCursor(cursor *usersCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes a cursor given by encodeCursor. The empty cursor starts from the beginning, in the
+// given order. Invalid cursors give a bad request error.
+func decodeCursor(value string, desc bool) (*usersCursor, error) {
+	if value == "" {
+		return &usersCursor{Desc: desc}, nil
+	}
+	invalid := goa.InvalidAttributeTypeError("payload.cursor", value, "cursor")
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalid
+	}
+	cursor := &usersCursor{}
+	if err = json.Unmarshal(data, cursor); err != nil || (cursor.After != "" && !bson.IsObjectIdHex(cursor.After)) {
+		return nil, invalid
+	}
+	return cursor, nil
+}
+
+// condition returns the filter condition for the users after the cursor, or nil at the beginning.
+func (u *usersCursor) condition() *userCondition {
+	if u.After == "" {
+		return nil
+	}
+	operator := opGt
+	if u.Desc {
+		operator = opLt
+	}
+	return &userCondition{Field: "id", Operator: operator, Values: []interface{}{u.After}}
+}
+
+// direction returns the sort direction of the cursor.
+func (u *usersCursor) direction() string {
+	if u.Desc {
+		return "desc"
+	}
+	return "asc"
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	cursor := &usersCursor{After: "5df2103b5f1b640001142d40", Desc: true}
+	decoded, err := decodeCursor(encodeCursor(cursor), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, cursor) {
+		t.Errorf("Expected %v, got %v", cursor, decoded)
+	}
+	if condition := decoded.condition(); condition.Operator != opLt || condition.Values[0] != cursor.After {
+		t.Errorf("Expected the users before the cursor in descending order, got %v", condition)
+	}
+
+	start, err := decodeCursor("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start.condition() != nil || start.direction() != "desc" {
+		t.Errorf("Expected the empty cursor to start from the beginning, got %v", start)
+	}
+
+	for _, value := range []string{"not a cursor", encodeCursor(&usersCursor{After: "bad-id"})} {
+		if _, err = decodeCursor(value, false); err == nil {
+			t.Errorf("Expected an error for the cursor %s", value)
+		}
+	}
+}
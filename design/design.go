@@ -35,13 +35,84 @@ var _ = Resource("user", func() {
 		Response(InternalServerError, ErrorMedia)
 	})
 
+	Action("register", func() {
+		Description("Self-service registration. The user is created inactive with the user role, and a verification email is sent. In the invite-only mode, the invitation is accepted as with acceptInvitation.")
+		Routing(POST("register"))
+		Payload(RegisterPayload)
+		Response(Created, UserMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("createInvitation", func() {
+		Description("Invite a user. The invitation is sent by email, the invitee accepts it by setting a password, or by registering in the invite-only registration mode.")
+		Routing(POST("invitations"))
+		Payload(InvitationPayload)
+		Response(Created, InvitationMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("listInvitations", func() {
+		Description("List the pending invitations")
+		Routing(GET("invitations"))
+		Response(OK, CollectionOf(InvitationMedia))
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("revokeInvitation", func() {
+		Description("Revoke a pending invitation")
+		Routing(DELETE("invitations/:invitationId"))
+		Params(func() {
+			Param("invitationId", String, "Invitation ID")
+		})
+		Response(NoContent)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("acceptInvitation", func() {
+		Description("Accept an invitation. Creates an active user with the invited roles and memberships.")
+		Routing(POST("invitations/accept"))
+		Payload(AcceptInvitationPayload)
+		Response(Created, UserMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("getDuplicates", func() {
+		Description("Report the users whose emails differ only in the letter case or the form of the domain")
+		Routing(GET("duplicates"))
+		Response(OK, CollectionOf(DuplicateUsersMedia))
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("mergeUsers", func() {
+		Description("Merge duplicate users into one user. The roles and memberships are combined and the duplicates are deleted.")
+		Routing(POST("duplicates/merge"))
+		Payload(MergeUsersPayload)
+		Response(OK, UserMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
 	Action("get", func() {
 		Description("Get user by id")
 		Routing(GET("/:userId"))
 		Params(func() {
 			Param("userId", String, "User ID")
 		})
-		Response(OK, UserMedia)
+		Response(OK, func() {
+			Media(UserMedia)
+			Headers(func() {
+				Header("ETag", String, "Version of the user, for the If-Match header of the update")
+			})
+		})
 		Response(NotFound, ErrorMedia)
 		Response(BadRequest, ErrorMedia)
 		Response(InternalServerError, ErrorMedia)
@@ -50,7 +121,226 @@ var _ = Resource("user", func() {
 	Action("getMe", func() {
 		Description("Retrieves the user information for the authenticated user")
 		Routing(GET("/me"))
+		Response(OK, func() {
+			Media(UserMedia)
+			Headers(func() {
+				Header("ETag", String, "Version of the user, for the If-Match header of the update")
+			})
+		})
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("updateMe", func() {
+		Description("Update the profile of the authenticated user. The email and the password are changed through their usual flows, both need the current password.")
+		Routing(PUT("/me"))
+		Headers(func() {
+			Header("If-Match", String, "ETag of the user, the update is rejected if the user has been changed since")
+		})
+		Payload(UpdateMePayload)
+		Response(OK, func() {
+			Media(UserMedia)
+			Headers(func() {
+				Header("ETag", String, "Version of the updated user")
+			})
+		})
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(PreconditionFailed, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("patchMe", func() {
+		Description("Partially update the profile of the authenticated user with a JSON merge patch (application/merge-patch+json). Only the fields of UpdateMePayload are accepted.")
+		Routing(PATCH("/me"))
+		Headers(func() {
+			Header("If-Match", String, "ETag of the user, the update is rejected if the user has been changed since")
+		})
+		Payload(UserMergePatch)
+		Response(OK, func() {
+			Media(UserMedia)
+			Headers(func() {
+				Header("ETag", String, "Version of the updated user")
+			})
+		})
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(PreconditionFailed, ErrorMedia)
+		Response(UnsupportedMediaType, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("changePassword", func() {
+		Description("Change the password of the authenticated user")
+		Routing(POST("/me/password"))
+		Payload(ChangePasswordPayload)
+		Response(OK)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("requestEmailChange", func() {
+		Description("Request an email change for the authenticated user. The email is changed once the token sent to the new address is confirmed.")
+		Routing(POST("/me/email"))
+		Payload(ChangeEmailPayload)
+		Response(OK)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("confirmEmailChange", func() {
+		Description("Confirm an email change with the token sent to the new address")
+		Routing(POST("email/confirm"))
+		Payload(TokenPayload)
 		Response(OK, UserMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("enrollTotp", func() {
+		Description("Start TOTP multi-factor authentication enrollment for the authenticated user")
+		Routing(POST("/me/mfa/totp"))
+		Response(OK, TOTPEnrollmentMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("confirmTotp", func() {
+		Description("Confirm TOTP enrollment with the first code from the authenticator and enable MFA")
+		Routing(POST("/me/mfa/totp/confirm"))
+		Payload(MFACodePayload)
+		Response(OK, RecoveryCodesMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("verifyMfa", func() {
+		Description("Verify the second factor (TOTP or recovery code) of a user. Intended for internal use after find.")
+		Routing(POST("/mfa/verify"))
+		Payload(MFAVerifyPayload)
+		Response(OK, UserMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response("Locked", func() {
+			Description("The account is locked after too many failed logins")
+			Status(423)
+			Media(ErrorMedia)
+		})
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("resetMfa", func() {
+		Description("Disable MFA for a user and remove the TOTP secret and recovery codes")
+		Routing(DELETE("/:userId/mfa"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		Response(OK, UserMedia)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("createToken", func() {
+		Description("Create a personal access token for the authenticated user. The token is returned only once.")
+		Routing(POST("/me/tokens"))
+		Payload(CreateAccessTokenPayload)
+		Response(Created, AccessTokenMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("listTokens", func() {
+		Description("List the personal access tokens of the authenticated user")
+		Routing(GET("/me/tokens"))
+		Response(OK, CollectionOf(AccessTokenMedia))
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("revokeToken", func() {
+		Description("Revoke a personal access token of the authenticated user")
+		Routing(DELETE("/me/tokens/:tokenId"))
+		Params(func() {
+			Param("tokenId", String, "Access token ID")
+		})
+		Response(NoContent)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("findByToken", func() {
+		Description("Find a user by personal access token. Intended for internal use.")
+		Routing(POST("find/token"))
+		Payload(AccessTokenPayload)
+		Response(OK, func() {
+			Media(UserMedia)
+			Headers(func() {
+				Header("X-Token-Scopes", String, "Comma separated scopes of the access token")
+			})
+		})
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("requestMagicLink", func() {
+		Description("Send a single-use sign-in link to the email of the user")
+		Routing(POST("magic-link"))
+		Payload(EmailPayload)
+		Response(OK)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("findByMagicLink", func() {
+		Description("Find a user by magic link token. The token is consumed. Intended for internal use.")
+		Routing(POST("find/magic-link"))
+		Payload(TokenPayload)
+		Response(OK, UserMedia)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response("Locked", func() {
+			Status(423)
+			Media(ErrorMedia)
+		})
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("getLogins", func() {
+		Description("Retrieves the login history of a user, the most recent first")
+		Routing(GET("/:userId/logins"))
+		Params(func() {
+			Param("userId", String, "User ID")
+			Param("limit", Integer, "Limit logins per page")
+			Param("offset", Integer, "Number of logins to skip")
+		})
+		Response(OK, CollectionOf(LoginMedia))
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("getMyLogins", func() {
+		Description("Retrieves the login history of the authenticated user, the most recent first")
+		Routing(GET("/me/logins"))
+		Params(func() {
+			Param("limit", Integer, "Limit logins per page")
+			Param("offset", Integer, "Number of logins to skip")
+		})
+		Response(OK, CollectionOf(LoginMedia))
 		Response(NotFound, ErrorMedia)
 		Response(BadRequest, ErrorMedia)
 		Response(InternalServerError, ErrorMedia)
@@ -78,7 +368,130 @@ var _ = Resource("user", func() {
 		Params(func() {
 			Param("userId", String, "User ID")
 		})
+		Headers(func() {
+			Header("If-Match", String, "ETag of the user, the update is rejected if the user has been changed since")
+		})
 		Payload(UpdateUserPayload)
+		Response(OK, func() {
+			Media(UserMedia)
+			Headers(func() {
+				Header("ETag", String, "Version of the updated user")
+			})
+		})
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(PreconditionFailed, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("patch", func() {
+		Description("Partially update user with a JSON merge patch (application/merge-patch+json). Absent fields are left as they are, null fields are cleared.")
+		Routing(PATCH("/:userId"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		Headers(func() {
+			Header("If-Match", String, "ETag of the user, the update is rejected if the user has been changed since")
+		})
+		Payload(UserMergePatch)
+		Response(OK, func() {
+			Media(UserMedia)
+			Headers(func() {
+				Header("ETag", String, "Version of the updated user")
+			})
+		})
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(PreconditionFailed, ErrorMedia)
+		Response(UnsupportedMediaType, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("delete", func() {
+		Description("Soft-delete user. The user is hidden from all lookups until restored.")
+		Routing(DELETE("/:userId"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		Response(NoContent)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("restore", func() {
+		Description("Restore soft-deleted user")
+		Routing(POST("/:userId/restore"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		Response(OK, UserMedia)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("purge", func() {
+		Description("Permanently remove user and all of the user's tokens. Admin only.")
+		Routing(DELETE("/:userId/purge"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		Response(NoContent)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(Forbidden, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("suspend", func() {
+		Description("Suspend user")
+		Routing(POST("/:userId/suspend"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		OptionalPayload(StatusChangePayload)
+		Response(OK, UserMedia)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("reactivate", func() {
+		Description("Reactivate suspended, locked or deactivated user")
+		Routing(POST("/:userId/reactivate"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		OptionalPayload(StatusChangePayload)
+		Response(OK, UserMedia)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("unlock", func() {
+		Description("Unlock user locked after too many failed logins")
+		Routing(POST("/:userId/unlock"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		OptionalPayload(StatusChangePayload)
+		Response(OK, UserMedia)
+		Response(NotFound, ErrorMedia)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError, ErrorMedia)
+	})
+
+	Action("deactivate", func() {
+		Description("Deactivate user")
+		Routing(POST("/:userId/deactivate"))
+		Params(func() {
+			Param("userId", String, "User ID")
+		})
+		OptionalPayload(StatusChangePayload)
 		Response(OK, UserMedia)
 		Response(NotFound, ErrorMedia)
 		Response(BadRequest, ErrorMedia)
@@ -100,6 +513,11 @@ var _ = Resource("user", func() {
 		Payload(CredentialsPayload)
 		Response(OK, UserMedia)
 		Response(NotFound, ErrorMedia)
+		Response("Locked", func() {
+			Description("The account is locked after too many failed logins")
+			Status(423)
+			Media(ErrorMedia)
+		})
 		Response(BadRequest, ErrorMedia)
 		Response(InternalServerError, ErrorMedia)
 	})
@@ -170,9 +588,17 @@ var UserMedia = MediaType("application/vnd.goa.user+json", func() {
 		Attribute("roles")
 		Attribute("externalId")
 		Attribute("active")
+		Attribute("status", String, "Lifecycle status of user account", func() {
+			Enum("pending_verification", "active", "suspended", "locked", "deactivated", "deleted")
+		})
 		Attribute("organizations")
 		Attribute("namespaces")
-		Required("id", "email", "roles", "externalId", "active")
+		Attribute("lastLoginAt", Integer, "Time of the last successful login (milliseconds since epoch)")
+		Attribute("mfaEnabled", Boolean, "Whether multi-factor authentication is enabled")
+		Attribute("pendingEmail", String, "New email of user, waiting for confirmation")
+		Attribute("displayEmail", String, "Email of user as entered, the email attribute holds the normalized form")
+		Attribute("profile", HashOf(String, Any), "Profile attributes of user visible to the caller")
+		Required("id", "email", "roles", "active")
 	})
 
 	View("default", func() {
@@ -181,8 +607,130 @@ var UserMedia = MediaType("application/vnd.goa.user+json", func() {
 		Attribute("roles")
 		Attribute("externalId")
 		Attribute("active")
+		Attribute("status")
+		Attribute("organizations")
+		Attribute("namespaces")
+		Attribute("lastLoginAt")
+		Attribute("mfaEnabled")
+		Attribute("pendingEmail")
+		Attribute("displayEmail")
+		Attribute("profile")
+	})
+})
+
+// DuplicateUsersMedia holds the users sharing the same normalized email.
+var DuplicateUsersMedia = MediaType("application/vnd.goa.user.duplicate-users+json", func() {
+	TypeName("DuplicateUsers")
+	Attributes(func() {
+		Attribute("email", String, "Normalized email shared by the users")
+		Attribute("users", CollectionOf(UserMedia), "Users with the email")
+		Required("email", "users")
+	})
+	View("default", func() {
+		Attribute("email")
+		Attribute("users")
+	})
+})
+
+// AccessTokenMedia is a personal access token. The token itself is included only when it is created.
+var AccessTokenMedia = MediaType("application/vnd.goa.user.access-token+json", func() {
+	TypeName("AccessToken")
+	Attributes(func() {
+		Attribute("id", String, "Access token ID")
+		Attribute("name", String, "Name of the access token")
+		Attribute("prefix", String, "First characters of the token, for identification")
+		Attribute("token", String, "The access token. Returned only when the token is created.")
+		Attribute("scopes", ArrayOf(String), "Scopes of the access token")
+		Attribute("createdAt", Integer, "Time of creation (milliseconds since epoch)")
+		Attribute("expiresAt", Integer, "Expiry time (milliseconds since epoch)")
+		Attribute("lastUsedAt", Integer, "Time of the last use (milliseconds since epoch)")
+		Required("id", "name", "prefix", "createdAt")
+	})
+	View("default", func() {
+		Attribute("id")
+		Attribute("name")
+		Attribute("prefix")
+		Attribute("token")
+		Attribute("scopes")
+		Attribute("createdAt")
+		Attribute("expiresAt")
+		Attribute("lastUsedAt")
+	})
+})
+
+// InvitationMedia defines the media type for an invitation. The invitation token is sent only by email.
+var InvitationMedia = MediaType("application/vnd.goa.user.invitation+json", func() {
+	TypeName("Invitation")
+	Attributes(func() {
+		Attribute("id", String, "Invitation ID")
+		Attribute("email", String, "Email of the invitee")
+		Attribute("roles", ArrayOf(String), "Roles of the invited user")
+		Attribute("organizations", ArrayOf(String), "Organizations of the invited user")
+		Attribute("namespaces", ArrayOf(String), "Namespaces of the invited user")
+		Attribute("invitedBy", String, "ID of the user that sent the invitation")
+		Attribute("createdAt", Integer, "Time of creation (milliseconds since epoch)")
+		Attribute("expiresAt", Integer, "Expiry time (milliseconds since epoch)")
+		Required("id", "email", "roles", "createdAt", "expiresAt")
+	})
+	View("default", func() {
+		Attribute("id")
+		Attribute("email")
+		Attribute("roles")
 		Attribute("organizations")
 		Attribute("namespaces")
+		Attribute("invitedBy")
+		Attribute("createdAt")
+		Attribute("expiresAt")
+	})
+})
+
+// TOTPEnrollmentMedia holds the TOTP secret to be added to the authenticator app.
+var TOTPEnrollmentMedia = MediaType("application/vnd.goa.user.totp-enrollment+json", func() {
+	TypeName("TOTPEnrollment")
+	Attributes(func() {
+		Attribute("secret", String, "Base32 encoded TOTP secret")
+		Attribute("uri", String, "otpauth URI of the secret, usually shown as QR code")
+		Required("secret", "uri")
+	})
+	View("default", func() {
+		Attribute("secret")
+		Attribute("uri")
+	})
+})
+
+// RecoveryCodesMedia holds the one-time recovery codes. They are shown only once.
+var RecoveryCodesMedia = MediaType("application/vnd.goa.user.recovery-codes+json", func() {
+	TypeName("RecoveryCodes")
+	Attributes(func() {
+		Attribute("recoveryCodes", ArrayOf(String), "One-time recovery codes")
+		Required("recoveryCodes")
+	})
+	View("default", func() {
+		Attribute("recoveryCodes")
+	})
+})
+
+// LoginMedia is a single entry in the login history of a user.
+var LoginMedia = MediaType("application/vnd.goa.user.login+json", func() {
+	TypeName("Login")
+	Attributes(func() {
+		Attribute("id", String, "Login ID")
+		Attribute("userId", String, "User ID")
+		Attribute("outcome", String, "Outcome of the login", func() {
+			Enum("success", "failure", "locked")
+		})
+		Attribute("ip", String, "IP address of the client")
+		Attribute("userAgent", String, "User agent of the client")
+		Attribute("createdAt", Integer, "Time of the login (milliseconds since epoch)")
+		Required("id", "userId", "outcome", "createdAt")
+	})
+	View("default", func() {
+		Attribute("id")
+		Attribute("userId")
+		Attribute("outcome")
+		Attribute("ip")
+		Attribute("userAgent")
+		Attribute("createdAt")
 	})
 })
 
@@ -192,13 +740,15 @@ var ResetTokenMedia = MediaType("ResetTokenMedia", func() {
 	Attributes(func() {
 		Attribute("id", String, "User ID")
 		Attribute("email", String, "User email")
-		Attribute("token", String, "New token")
-		Required("id", "email", "token")
+		Attribute("token", String, "New token. Not returned when the service sends the verification email itself.")
+		Attribute("expiresAt", Integer, "Expiry time of the token (milliseconds since epoch)")
+		Required("id", "email")
 	})
 	View("default", func() {
 		Attribute("id")
 		Attribute("email")
 		Attribute("token")
+		Attribute("expiresAt")
 	})
 })
 
@@ -209,10 +759,7 @@ var CreateUserPayload = Type("CreateUserPayload", func() {
 	Attribute("email", String, "Email of user", func() {
 		Format("email")
 	})
-	Attribute("password", String, "Password of user", func() {
-		MinLength(6)
-		MaxLength(30)
-	})
+	Attribute("password", String, "Password of user")
 	Attribute("roles", ArrayOf(String), "Roles of user")
 	Attribute("organizations", ArrayOf(String), "List of organizations to which this user belongs to")
 	Attribute("namespaces", ArrayOf(String), "List of namespaces this user belongs to")
@@ -221,10 +768,49 @@ var CreateUserPayload = Type("CreateUserPayload", func() {
 		Default(false)
 	})
 	Attribute("token", String, "Token for email verification")
+	Attribute("profile", HashOf(String, Any), "Profile attributes of user, as declared in the profile schema of the service")
 
 	Required("email")
 })
 
+// RegisterPayload defines the payload for the self-service registration.
+var RegisterPayload = Type("RegisterPayload", func() {
+	Description("Self-service registration payload")
+
+	Attribute("email", String, "Email of user", func() {
+		Format("email")
+	})
+	Attribute("password", String, "Password of user")
+	Attribute("profile", HashOf(String, Any), "Profile attributes of user, as declared in the profile schema of the service")
+	Attribute("invitationToken", String, "Invitation token. Required in the invite-only registration mode.")
+
+	Required("email", "password")
+})
+
+// InvitationPayload defines the payload for inviting a user.
+var InvitationPayload = Type("InvitationPayload", func() {
+	Description("Invitation payload")
+
+	Attribute("email", String, "Email of the invitee", func() {
+		Format("email")
+	})
+	Attribute("roles", ArrayOf(String), "Roles of the invited user. Defaults to the user role.")
+	Attribute("organizations", ArrayOf(String), "Organizations of the invited user")
+	Attribute("namespaces", ArrayOf(String), "Namespaces of the invited user")
+	Attribute("expiresAt", Integer, "Expiry time (milliseconds since epoch). Defaults to the configured invitation TTL.")
+
+	Required("email")
+})
+
+// AcceptInvitationPayload defines the payload for accepting an invitation.
+var AcceptInvitationPayload = Type("AcceptInvitationPayload", func() {
+	Description("Accept invitation payload")
+	Attribute("token", String, "Invitation token")
+	Attribute("password", String, "Password of the new user")
+	Attribute("profile", HashOf(String, Any), "Profile attributes of the new user, as declared in the profile schema of the service")
+	Required("token", "password")
+})
+
 // UpdateUserPayload defines the payload for the user.
 var UpdateUserPayload = Type("UpdateUserPayload", func() {
 	Description("UpdateUserPayload")
@@ -232,10 +818,7 @@ var UpdateUserPayload = Type("UpdateUserPayload", func() {
 	Attribute("email", String, "Email of user", func() {
 		Format("email")
 	})
-	Attribute("password", String, "Password of user", func() {
-		MinLength(6)
-		MaxLength(30)
-	})
+	Attribute("password", String, "Password of user")
 	Attribute("roles", ArrayOf(String), "Roles of user")
 	Attribute("organizations", ArrayOf(String), "List of organizations to which this user belongs to")
 	Attribute("namespaces", ArrayOf(String), "List of namespaces this user belongs to")
@@ -244,6 +827,19 @@ var UpdateUserPayload = Type("UpdateUserPayload", func() {
 		Default(false)
 	})
 	Attribute("token", String, "Token for email verification")
+	Attribute("profile", HashOf(String, Any), "Profile attributes of user, replacing the current ones")
+})
+
+// UserMergePatch defines the JSON merge patch (RFC 7396) of the user. The fields are those of UpdateUserPayload,
+// validated by the service, as the payload type cannot tell an absent field from a null one.
+var UserMergePatch = HashOf(String, Any)
+
+// StatusChangePayload defines the payload for changing the status of the user account.
+var StatusChangePayload = Type("StatusChangePayload", func() {
+	Description("Status change payload")
+	Attribute("reason", String, "Reason for changing the status", func() {
+		MaxLength(500)
+	})
 })
 
 // CredentialsPayload defines the payload for the credentials.
@@ -252,10 +848,7 @@ var CredentialsPayload = Type("Credentials", func() {
 	Attribute("email", String, "Email of user", func() {
 		Format("email")
 	})
-	Attribute("password", String, "Password of user", func() {
-		MinLength(6)
-		MaxLength(30)
-	})
+	Attribute("password", String, "Password of user")
 	Required("email", "password")
 })
 
@@ -274,14 +867,91 @@ var ForgotPasswordPayload = Type("ForgotPasswordPayload", func() {
 	Attribute("email", String, "Email of the user", func() {
 		Format("email")
 	})
-	Attribute("password", String, "New password", func() {
-		MinLength(6)
-		MaxLength(30)
-	})
+	Attribute("password", String, "New password")
 	Attribute("token", String, "Forgot password token")
 	Required("password", "token")
 })
 
+// ChangePasswordPayload defines the payload for changing the password of the authenticated user.
+var ChangePasswordPayload = Type("ChangePasswordPayload", func() {
+	Description("Change password payload")
+	Attribute("currentPassword", String, "Current password")
+	Attribute("newPassword", String, "New password")
+	Required("currentPassword", "newPassword")
+})
+
+// UpdateMePayload defines the payload for updating the profile of the authenticated user.
+var UpdateMePayload = Type("UpdateMePayload", func() {
+	Description("Update the authenticated user payload")
+	Attribute("email", String, "New email, changed once confirmed", func() {
+		Format("email")
+	})
+	Attribute("password", String, "New password")
+	Attribute("currentPassword", String, "Current password, needed to change the email or the password")
+	Attribute("profile", HashOf(String, Any), "Profile attributes of user, replacing the current ones")
+})
+
+// ChangeEmailPayload defines the payload for requesting an email change of the authenticated user.
+var ChangeEmailPayload = Type("ChangeEmailPayload", func() {
+	Description("Change email payload")
+	Attribute("email", String, "New email", func() {
+		Format("email")
+	})
+	Attribute("currentPassword", String, "Current password")
+	Required("email", "currentPassword")
+})
+
+// MergeUsersPayload defines the payload for merging duplicate users.
+var MergeUsersPayload = Type("MergeUsersPayload", func() {
+	Description("Merge users payload")
+	Attribute("userId", String, "ID of the user to keep")
+	Attribute("duplicateIds", ArrayOf(String), "IDs of the duplicate users to merge and delete", func() {
+		MinLength(1)
+	})
+	Required("userId", "duplicateIds")
+})
+
+// TokenPayload defines a payload holding a single token.
+var TokenPayload = Type("TokenPayload", func() {
+	Description("Token payload")
+	Attribute("token", String, "Token")
+	Required("token")
+})
+
+// MFACodePayload defines the payload for confirming the TOTP enrollment.
+var MFACodePayload = Type("MFACodePayload", func() {
+	Description("MFA code payload")
+	Attribute("code", String, "Code generated by the authenticator")
+	Required("code")
+})
+
+// MFAVerifyPayload defines the payload for verifying the second factor of a user.
+var MFAVerifyPayload = Type("MFAVerifyPayload", func() {
+	Description("MFA verification payload")
+	Attribute("userId", String, "User ID")
+	Attribute("code", String, "TOTP code or recovery code")
+	Required("userId", "code")
+})
+
+// CreateAccessTokenPayload defines the payload for creating a personal access token.
+var CreateAccessTokenPayload = Type("CreateAccessTokenPayload", func() {
+	Description("Create access token payload")
+	Attribute("name", String, "Name of the access token", func() {
+		MinLength(1)
+		MaxLength(100)
+	})
+	Attribute("scopes", ArrayOf(String), "Scopes of the access token")
+	Attribute("expiresAt", Integer, "Expiry time (milliseconds since epoch). The token does not expire if not set.")
+	Required("name")
+})
+
+// AccessTokenPayload defines the payload for looking up a user by personal access token.
+var AccessTokenPayload = Type("AccessTokenPayload", func() {
+	Description("Access token payload")
+	Attribute("token", String, "Personal access token")
+	Required("token")
+})
+
 // Swagger UI
 var _ = Resource("swagger", func() {
 	Description("The API swagger specification")
@@ -293,18 +963,36 @@ var _ = Resource("swagger", func() {
 
 // FilterPayload Users filter request payload.
 var FilterPayload = Type("FilterPayload", func() {
-	Attribute("page", Integer, "Page number (1-based).")
+	Attribute("page", Integer, "Page number (1-based). Not used in cursor mode.", func() {
+		Default(1)
+	})
 	Attribute("pageSize", Integer, "Items per page.")
-	Attribute("filter", ArrayOf(FilterProperty), "Users filter.")
-	Attribute("sort", OrderSpec, "Sort specification.")
-	Required("page", "pageSize")
+	Attribute("cursor", String, "Cursor of the page, the nextCursor of the previous page. An empty cursor starts iterating in cursor mode, where the users are ordered by id.")
+	Attribute("filter", ArrayOf(FilterProperty), "Users filter. All the filters must match.")
+	Attribute("anyOf", ArrayOf(FilterGroup), "Filter groups, at least one of which must match.")
+	Attribute("sort", OrderSpec, "Sort specification. In cursor mode, only the direction of the id.")
+	Attribute("includeTotal", Boolean, "Count the users matching the filter into the total. Counting goes over all the matching users, so it is off by default.", func() {
+		Default(false)
+	})
+	Required("pageSize")
 })
 
-// FilterProperty Single property filter. Holds the property name and the value to be matched for that property.
+// FilterProperty Single property filter. Holds the property name, the operator and the value to be matched for that property.
 var FilterProperty = Type("FilterProperty", func() {
-	Attribute("property", String, "Property name")
-	Attribute("value", String, "Property value to match")
-	Required("property", "value")
+	Attribute("property", String, "Property name. Profile attributes are matched as profile.<name>.")
+	Attribute("operator", String, "Operator. The gt, gte, lt and lte operators apply to createdAt and modifiedAt.", func() {
+		Enum("eq", "ne", "in", "nin", "prefix", "contains", "gt", "gte", "lt", "lte", "exists")
+		Default("eq")
+	})
+	Attribute("value", String, "Property value to match. For the exists operator, true (default) or false.")
+	Attribute("values", ArrayOf(String), "Property values to match, for the in and nin operators")
+	Required("property")
+})
+
+// FilterGroup is a group of filters that must all match.
+var FilterGroup = Type("FilterGroup", func() {
+	Attribute("filter", ArrayOf(FilterProperty), "Filters that must all match")
+	Required("filter")
 })
 
 // OrderSpec specifies the sorting - by which property and the direction, either 'asc' (ascending)
@@ -319,13 +1007,17 @@ var OrderSpec = Type("OrderSpec", func() {
 var UsersPageMedia = MediaType("application/mt.ckan.users-page+json", func() {
 	TypeName("UsersPage")
 	Attributes(func() {
-		Attribute("page", Integer, "Page number (1-based).")
+		Attribute("page", Integer, "Page number (1-based). Not set in cursor mode.")
 		Attribute("pageSize", Integer, "Items per page.")
+		Attribute("total", Integer, "Total number of the users matching the filter. Set only when includeTotal is requested.")
+		Attribute("nextCursor", String, "Cursor of the next page in cursor mode. Not set on the last page.")
 		Attribute("items", ArrayOf(UserMedia), "Users list")
 	})
 	View("default", func() {
 		Attribute("page")
 		Attribute("pageSize")
+		Attribute("total")
+		Attribute("nextCursor")
 		Attribute("items")
 	})
 })
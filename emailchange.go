@@ -0,0 +1,212 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/helpers"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// RequestEmailChange stores the new email of the authenticated user as pending and sends a confirmation
+// token to the new address. The current email stays in use, and unique, until the change is confirmed.
+func (c *UserController) RequestEmailChange(ctx *app.RequestEmailChangeUserContext) error {
+	if !auth.HasAuth(ctx.Context) {
+		return ctx.InternalServerError(goa.ErrBadRequest("no-auth"))
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", auth.GetAuth(ctx.Context).UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	if err := c.checkCurrentPassword(user, ctx.Payload.CurrentPassword); err != nil {
+		return ctx.Forbidden(err)
+	}
+
+	if _, err := c.requestUserEmailChange(user, ctx.Payload.Email); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if errorStatus(err) == http.StatusBadRequest {
+			return ctx.BadRequest(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK([]byte{})
+}
+
+// requestUserEmailChange stores the new email of the user as pending and sends the confirmation token to the
+// new address. An email that is the current one or that is already in use gives a bad request error.
+func (c *UserController) requestUserEmailChange(user *store.UserRecord, newEmail string) (*store.UserRecord, error) {
+	update, token, err := c.emailChangeUpdate(user, newEmail)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := c.saveUser(user, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sendEmailChange(user, newEmail, token); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// emailChangeUpdate checks the new email of the user and returns the fields to save along with the
+// confirmation token to send.
+func (c *UserController) emailChangeUpdate(user *store.UserRecord, newEmail string) (map[string]interface{}, string, error) {
+	email := normalizeEmail(newEmail)
+
+	if email == user.Email {
+		return nil, "", goa.ErrBadRequest("the new email is the same as the current one")
+	}
+
+	existing := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("email", email), existing); err == nil {
+		return nil, "", goa.ErrBadRequest("email is already in use")
+	} else if !backends.IsErrNotFound(err) {
+		return nil, "", err
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	token := generateToken(42)
+	return map[string]interface{}{
+		"pendingEmail":          strings.TrimSpace(newEmail),
+		"pendingEmailToken":     c.TokenHasher.Hash(token),
+		"pendingEmailExpiresAt": now + int64(c.Config.GetVerificationToken().TTL)*1000,
+		"modifiedAt":            now,
+	}, token, nil
+}
+
+// sendEmailChange sends the confirmation token to the new email and notifies the current address of the
+// user.
+func (c *UserController) sendEmailChange(user *store.UserRecord, newEmail, token string) error {
+	email := normalizeEmail(newEmail)
+	err := c.sendEmail(email, "emailChange", map[string]string{
+		"id":    user.ID.Hex(),
+		"email": email,
+		"token": token,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The current address is notified so that the owner can react to a change they did not request.
+	err = c.sendEmail(user.Email, "emailChangeRequested", map[string]string{
+		"id":       user.ID.Hex(),
+		"email":    user.Email,
+		"newEmail": email,
+	})
+	if err != nil {
+		c.Service.LogError("User: failed to send email change notification.", "err", err.Error())
+	}
+	return nil
+}
+
+// ConfirmEmailChange replaces the email of the user with the normalized pending one. The verification tokens issued
+// for the old email are deleted, and so is any outstanding forgot-password token.
+func (c *UserController) ConfirmEmailChange(ctx *app.ConfirmEmailChangeUserContext) error {
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("pendingEmailToken", c.TokenHasher.Hash(ctx.Payload.Token)), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() || user.PendingEmail == "" || !c.TokenHasher.Matches(user.PendingEmailToken, ctx.Payload.Token) {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	clearPending := map[string]interface{}{
+		"pendingEmail":          "",
+		"pendingEmailToken":     "",
+		"pendingEmailExpiresAt": 0,
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	if now >= user.PendingEmailExpiresAt {
+		if _, err := c.saveUser(user, clearPending); err != nil && err != errVersionConflict {
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+		return ctx.BadRequest(goa.ErrBadRequest("email change token has expired"))
+	}
+
+	update := map[string]interface{}{
+		"email":                 normalizeEmail(user.PendingEmail),
+		"displayEmail":          user.PendingEmail,
+		"pendingEmail":          "",
+		"pendingEmailToken":     "",
+		"pendingEmailExpiresAt": 0,
+		"forgotPasswordTokens":  store.FPToken{},
+		"modifiedAt":            now,
+	}
+
+	updated, err := saveEmailChange(c.Store.Users, user, update)
+	if err != nil {
+		if err == errVersionConflict {
+			return ctx.NotFound(goa.ErrNotFound("not found"))
+		}
+		if backends.IsErrAlreadyExists(err) || backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	err = c.Store.Tokens.DeleteAll(backends.NewFilter().Match("email", user.Email))
+	if err != nil && !backends.IsErrNotFound(err) {
+		c.Service.LogError("User: failed to delete tokens of the old email.", "err", err.Error())
+	}
+
+	return ctx.OK(c.userMedia(ctx, updated))
+}
+
+// saveEmailChange saves the update holding the new email of the user, as saveUser. DynamoDB cannot change the
+// hash key of an item (the email, in the users table) and leaves it out of the update. In that case the user
+// is saved again under the new email, which keeps the email unique, and the item under the old email is
+// deleted.
+func saveEmailChange(users backends.Repository, user *store.UserRecord, update map[string]interface{}) (*store.UserRecord, error) {
+	email, _ := update["email"].(string)
+	updated, err := saveUserRecord(users, user, update)
+	if err != nil {
+		return nil, err
+	}
+	if updated.Email == email {
+		return updated, nil
+	}
+
+	record := map[string]interface{}{}
+	if err = backends.MapToInterface(updated, &record); err != nil {
+		return nil, err
+	}
+	record["email"] = email
+	if _, err = users.Save(&record, nil); err != nil {
+		return nil, err
+	}
+	if err = users.DeleteOne(backends.NewFilter().Match("email", user.Email)); err != nil {
+		return nil, err
+	}
+
+	updated.Email = email
+	return updated, nil
+}
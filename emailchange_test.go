@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/store"
+)
+
+// hashKeyRepository mimics DynamoDB, which leaves the hash key of an item out of the updates.
+type hashKeyRepository struct {
+	backends.Repository
+	hashKey string
+}
+
+func (r *hashKeyRepository) Save(object interface{}, filter backends.Filter) (interface{}, error) {
+	if filter != nil {
+		payload, err := backends.InterfaceToMap(object)
+		if err != nil {
+			return nil, err
+		}
+		update := map[string]interface{}{}
+		for k, v := range *payload {
+			if k != r.hashKey {
+				update[k] = v
+			}
+		}
+		object = &update
+	}
+	return r.Repository.Save(object, filter)
+}
+
+func (r *hashKeyRepository) DeleteOne(filter backends.Filter) error {
+	return r.Repository.DeleteAll(filter)
+}
+
+func TestSaveEmailChangeHashKey(t *testing.T) {
+	db := store.NewDB()
+	db.Users = &hashKeyRepository{Repository: db.Users, hashKey: "email"}
+
+	user := &store.UserRecord{}
+	if _, err := db.Users.GetOne(backends.NewFilter().Match("id", "5df2103b5f1b640001142d40"), user); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := saveEmailChange(db.Users, user, map[string]interface{}{
+		"email":        "keitaro-user5-new@gmail.com",
+		"pendingEmail": "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Email != "keitaro-user5-new@gmail.com" {
+		t.Errorf("Expected the new email, got %s", updated.Email)
+	}
+
+	saved := &store.UserRecord{}
+	if _, err = db.Users.GetOne(backends.NewFilter().Match("email", "keitaro-user5-new@gmail.com"), saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Password != user.Password {
+		t.Error("Expected the user to be saved under the new email")
+	}
+	if _, err = db.Users.GetOne(backends.NewFilter().Match("email", user.Email), &store.UserRecord{}); !backends.IsErrNotFound(err) {
+		t.Errorf("Expected the user under the old email to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/helpers"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+	"golang.org/x/net/idna"
+)
+
+// normalizeEmail returns the canonical form of the email, used to store and look up the users: without the
+// surrounding spaces, in lower case and with an internationalized domain in its ASCII (punycode) form.
+func normalizeEmail(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return strings.ToLower(email)
+	}
+	local, domain := email[:at], email[at+1:]
+	if ascii, err := idna.Lookup.ToASCII(domain); err == nil {
+		domain = ascii
+	}
+	return strings.ToLower(local) + "@" + strings.ToLower(domain)
+}
+
+// allUsers returns all user records, including the deleted ones.
+func allUsers(users backends.Repository) ([]*store.UserRecord, error) {
+	var typeHint map[string]interface{}
+	result, err := users.GetAll(backends.NewFilter(), typeHint, "", "", 0, 0)
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return []*store.UserRecord{}, nil
+		}
+		return nil, err
+	}
+	records := []*store.UserRecord{}
+	if result != nil {
+		if err = backends.MapToInterface(result, &records); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// duplicateUsers groups the users by the normalized email and returns the groups with more than one user.
+func duplicateUsers(users backends.Repository) (map[string][]*store.UserRecord, error) {
+	records, err := allUsers(users)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string][]*store.UserRecord{}
+	for _, record := range records {
+		email := normalizeEmail(record.Email)
+		groups[email] = append(groups[email], record)
+	}
+	for email, group := range groups {
+		if len(group) < 2 {
+			delete(groups, email)
+		}
+	}
+	return groups, nil
+}
+
+// normalizeStoredEmails normalizes the emails of the users stored by previous versions of the service, keeping
+// the stored email as display form. Users whose normalized email is taken by another user are left for the
+// admin to merge. Returns the number of normalized users and the number of duplicate users left.
+func normalizeStoredEmails(users backends.Repository) (int, int, error) {
+	records, err := allUsers(users)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	taken := map[string]int{}
+	for _, record := range records {
+		taken[normalizeEmail(record.Email)]++
+	}
+
+	normalized, duplicates := 0, 0
+	for _, record := range records {
+		email := normalizeEmail(record.Email)
+		if record.Email == email {
+			continue
+		}
+		if taken[email] > 1 {
+			duplicates++
+			continue
+		}
+		update := map[string]interface{}{
+			"email":        email,
+			"displayEmail": record.Email,
+		}
+		if _, err = saveEmailChange(users, record, update); err != nil {
+			return normalized, duplicates, err
+		}
+		normalized++
+	}
+
+	return normalized, duplicates, nil
+}
+
+// mergeStrings appends the values not already in the list.
+func mergeStrings(list []string, values []string) []string {
+	for _, value := range values {
+		found := false
+		for _, v := range list {
+			if v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// GetDuplicates reports the users whose emails have the same normalized form.
+func (c *UserController) GetDuplicates(ctx *app.GetDuplicatesUserContext) error {
+	groups, err := duplicateUsers(c.Store.Users)
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	duplicates := app.DuplicateUsersCollection{}
+	for email, group := range groups {
+		users := app.UsersCollection{}
+		for _, user := range group {
+			users = append(users, c.userMedia(ctx, user))
+		}
+		duplicates = append(duplicates, &app.DuplicateUsers{
+			Email: email,
+			Users: users,
+		})
+	}
+
+	return ctx.OK(duplicates)
+}
+
+// MergeUsers merges the duplicate users into the given user. The roles, organizations and namespaces of the
+// duplicates are added to the user, the duplicates are purged and the email of the user is normalized. Only
+// admin and system users may merge users.
+func (c *UserController) MergeUsers(ctx *app.MergeUsersUserContext) error {
+	if !hasAnyRole(auth.GetAuth(ctx), "admin", "system") {
+		return ctx.Forbidden(errForbidden("only admin can merge users"))
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.Payload.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	email := normalizeEmail(user.Email)
+	roles := []string{}
+	organizations := []string{}
+	namespaces := []string{}
+
+	duplicates := []*store.UserRecord{}
+	for _, id := range ctx.Payload.DuplicateIds {
+		if id == ctx.Payload.UserID {
+			return ctx.BadRequest(goa.ErrBadRequest("cannot merge a user into itself"))
+		}
+		duplicate := &store.UserRecord{}
+		if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", id), duplicate); err != nil {
+			if backends.IsErrNotFound(err) {
+				return ctx.NotFound(goa.ErrNotFound(err))
+			}
+			if backends.IsErrInvalidInput(err) {
+				return ctx.BadRequest(goa.ErrBadRequest(err))
+			}
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+		if normalizeEmail(duplicate.Email) != email {
+			return ctx.BadRequest(goa.ErrBadRequest(fmt.Sprintf("user %s is not a duplicate", id)))
+		}
+		roles = mergeStrings(roles, duplicate.Roles)
+		organizations = mergeStrings(organizations, duplicate.Organizations)
+		namespaces = mergeStrings(namespaces, duplicate.Namespaces)
+		duplicates = append(duplicates, duplicate)
+	}
+
+	// The memberships of the duplicates are saved on the user before the duplicates are purged, so that they
+	// are not lost if the save fails.
+	updated, err := c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		displayEmail := user.DisplayEmail
+		if displayEmail == "" {
+			displayEmail = user.Email
+		}
+		return map[string]interface{}{
+			"displayEmail":  displayEmail,
+			"roles":         mergeStrings(mergeStrings([]string{}, user.Roles), roles),
+			"organizations": mergeStrings(mergeStrings([]string{}, user.Organizations), organizations),
+			"namespaces":    mergeStrings(mergeStrings([]string{}, user.Namespaces), namespaces),
+			"modifiedAt":    helpers.CurrentTimeMilliseconds(),
+		}, nil
+	})
+	if err != nil {
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	// The duplicates are purged before the email is normalized, so that the normalized email is free.
+	for _, duplicate := range duplicates {
+		if err := c.purgeUser(duplicate); err != nil && !backends.IsErrNotFound(err) {
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+	}
+
+	if updated.Email != email {
+		update := map[string]interface{}{
+			"email":      email,
+			"modifiedAt": helpers.CurrentTimeMilliseconds(),
+		}
+		if updated, err = saveEmailChange(c.Store.Users, updated, update); err != nil {
+			if backends.IsErrAlreadyExists(err) || backends.IsErrInvalidInput(err) {
+				return ctx.BadRequest(goa.ErrBadRequest(err))
+			}
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+	}
+
+	return ctx.OK(c.userMedia(ctx, updated))
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/store"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	for email, expected := range map[string]string{
+		"keitaro-user1@gmail.com":     "keitaro-user1@gmail.com",
+		"  Keitaro-User1@GMAIL.com  ": "keitaro-user1@gmail.com",
+		"user@Bücher.example":         "user@xn--bcher-kva.example",
+		"user@xn--bcher-kva.example":  "user@xn--bcher-kva.example",
+		"no-domain":                   "no-domain",
+	} {
+		if normalized := normalizeEmail(email); normalized != expected {
+			t.Errorf("%q: expected %q, got %q", email, expected, normalized)
+		}
+	}
+}
+
+func TestNormalizeStoredEmails(t *testing.T) {
+	db := store.NewDB()
+	for _, email := range []string{"Keitaro-User20@gmail.com", "Keitaro-User21@gmail.com", "keitaro-user21@GMAIL.com"} {
+		if _, err := db.Users.Save(&store.UserRecord{Email: email}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	normalized, duplicates, err := normalizeStoredEmails(db.Users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if normalized != 1 || duplicates != 2 {
+		t.Errorf("Expected 1 normalized and 2 duplicate users, got %d and %d", normalized, duplicates)
+	}
+
+	user := &store.UserRecord{}
+	if _, err = db.Users.GetOne(backends.NewFilter().Match("email", "keitaro-user20@gmail.com"), user); err != nil {
+		t.Fatal(err)
+	}
+	if user.DisplayEmail != "Keitaro-User20@gmail.com" {
+		t.Errorf("Expected the stored email to be kept as display email, got %q", user.DisplayEmail)
+	}
+
+	groups, err := duplicateUsers(db.Users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || len(groups["keitaro-user21@gmail.com"]) != 2 {
+		t.Errorf("Expected one group of duplicates, got %v", groups)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/store"
+)
+
+// changedFields returns the names of the fields of the user that the changes would change. Fields set to
+// their current values are not changed.
+func changedFields(user *store.UserRecord, changes *userChanges) []string {
+	fields := []string{}
+	if changes.Active != nil && *changes.Active != (user.CurrentStatus() == store.StatusActive) {
+		fields = append(fields, "active")
+	}
+	if changes.Password != nil && *changes.Password != "" {
+		fields = append(fields, "password")
+	}
+	if changes.ExternalID != nil && *changes.ExternalID != user.ExternalID {
+		fields = append(fields, "externalId")
+	}
+	if changes.Roles != nil && !sameStrings(changes.Roles, user.Roles) {
+		fields = append(fields, "roles")
+	}
+	if changes.Organizations != nil && !sameStrings(changes.Organizations, user.Organizations) {
+		fields = append(fields, "organizations")
+	}
+	if changes.Namespaces != nil && !sameStrings(changes.Namespaces, user.Namespaces) {
+		fields = append(fields, "namespaces")
+	}
+	if profile := changes.profile(user); profile != nil {
+		fields = append(fields, changedProfileAttributes(user.Profile, profile)...)
+	}
+	return fields
+}
+
+// sameStrings checks whether the two lists have the same values, in any order.
+func sameStrings(a, b []string) bool {
+	for _, value := range a {
+		if !containsString(b, value) {
+			return false
+		}
+	}
+	for _, value := range b {
+		if !containsString(a, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsString checks whether the list contains the value.
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// rejectedFields returns the fields that the caller is not allowed to change by the field policy. Requests
+// without auth are internal and, as in actorID, are treated as made by the system.
+func (c *UserController) rejectedFields(ctx context.Context, user *store.UserRecord, fields []string) []string {
+	authObj := auth.GetAuth(ctx)
+	if authObj == nil {
+		return nil
+	}
+
+	rejected := []string{}
+	for _, field := range fields {
+		roles, ok := c.fieldRoles(field)
+		if !ok || hasAnyRole(authObj, roles...) {
+			continue
+		}
+		if authObj.UserID == user.ID.Hex() && containsString(roles, config.FieldPolicyOwner) {
+			continue
+		}
+		rejected = append(rejected, field)
+	}
+	return rejected
+}
+
+// fieldRoles returns the roles allowed to change the field by the field policy, and whether the field is in
+// the policy. Profile attributes ("profile.<name>") have the roles of the profile, except the attributes
+// visible only to admins, which only admin and system users can change.
+func (c *UserController) fieldRoles(field string) ([]string, bool) {
+	policy := c.Config.GetFieldPolicy()
+	if roles, ok := policy[field]; ok {
+		return roles, true
+	}
+	name := profileAttributeName(field)
+	if name == "" {
+		return nil, false
+	}
+	if c.Config.GetProfile()[name].Visibility == config.VisibilityAdmin {
+		return []string{"admin", "system"}, true
+	}
+	roles, ok := policy["profile"]
+	return roles, ok
+}
@@ -0,0 +1,726 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Filter operators.
+const (
+	opEq       = "eq"
+	opNe       = "ne"
+	opIn       = "in"
+	opNin      = "nin"
+	opPrefix   = "prefix"
+	opContains = "contains"
+	opGt       = "gt"
+	opGte      = "gte"
+	opLt       = "lt"
+	opLte      = "lte"
+	opExists   = "exists"
+)
+
+var (
+	// equalityOperators can be used on every filterable field.
+	equalityOperators = []string{opEq, opNe, opIn, opNin, opExists}
+	// textOperators can be used on the text fields.
+	textOperators = append([]string{opPrefix, opContains}, equalityOperators...)
+	// rangeOperators can be used on the timestamps.
+	rangeOperators = append([]string{opGt, opGte, opLt, opLte}, equalityOperators...)
+)
+
+// filterableFields are the fields of the user that FindUsers can filter and sort by, with the operators
+// allowed on each. Profile attributes ("profile.<name>") are filterable too, see profileFilterAttribute.
+var filterableFields = map[string][]string{
+	"id":            equalityOperators,
+	"email":         textOperators,
+	"externalId":    textOperators,
+	"status":        equalityOperators,
+	"active":        equalityOperators,
+	"roles":         textOperators,
+	"organizations": textOperators,
+	"namespaces":    textOperators,
+	"createdAt":     rangeOperators,
+	"modifiedAt":    rangeOperators,
+}
+
+// userCondition is a single condition of a users filter, with values converted to the type of the field.
+type userCondition struct {
+	Field    string
+	Operator string
+	Values   []interface{}
+}
+
+// userFilter is a compiled users filter. All the conditions and at least one of the groups must match.
+type userFilter struct {
+	All []*userCondition
+	Any [][]*userCondition
+	// ActiveOnly limits the filter to the active users.
+	ActiveOnly bool
+}
+
+// and returns a copy of the filter with the conditions added. Nil conditions are skipped.
+func (f *userFilter) and(conditions ...*userCondition) *userFilter {
+	filter := *f
+	filter.All = append([]*userCondition{}, f.All...)
+	for _, condition := range conditions {
+		if condition != nil {
+			filter.All = append(filter.All, condition)
+		}
+	}
+	return &filter
+}
+
+// compileUserFilter validates the filter of FindUsers against the filterable fields and converts the values.
+// The errors are bad request errors.
+func (c *UserController) compileUserFilter(ctx context.Context, payload *app.FilterPayload) (*userFilter, error) {
+	filter := &userFilter{}
+	var err error
+	if filter.All, err = c.compileConditions(ctx, "filter", payload.Filter); err != nil {
+		return nil, err
+	}
+	for i, group := range payload.AnyOf {
+		conditions, err := c.compileConditions(ctx, fmt.Sprintf("anyOf[%d].filter", i), group.Filter)
+		if err != nil {
+			return nil, err
+		}
+		filter.Any = append(filter.Any, conditions)
+	}
+	return filter, nil
+}
+
+// compileConditions compiles the filter properties of a filter or of a filter group.
+func (c *UserController) compileConditions(ctx context.Context, attribute string, properties []*app.FilterProperty) ([]*userCondition, error) {
+	conditions := []*userCondition{}
+	for i, property := range properties {
+		condition, err := c.compileCondition(ctx, fmt.Sprintf("%s[%d]", attribute, i), property)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// compileCondition compiles a single filter property.
+func (c *UserController) compileCondition(ctx context.Context, attribute string, property *app.FilterProperty) (*userCondition, error) {
+	operator := property.Operator
+	if operator == "" {
+		operator = opEq
+	}
+	operators, err := c.fieldOperators(ctx, property.Property)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(operators, operator) {
+		return nil, goa.ErrBadRequest(fmt.Sprintf("operator %s cannot be used on %s", operator, property.Property))
+	}
+
+	condition := &userCondition{Field: property.Property, Operator: operator}
+	if operator == opExists {
+		exists := true
+		if property.Value != nil {
+			if exists, err = strconv.ParseBool(*property.Value); err != nil {
+				return nil, goa.InvalidAttributeTypeError(attribute+".value", *property.Value, "boolean")
+			}
+		}
+		condition.Values = []interface{}{exists}
+		return condition, nil
+	}
+
+	values := property.Values
+	if operator != opIn && operator != opNin {
+		if property.Value == nil {
+			return nil, goa.MissingAttributeError(attribute, "value")
+		}
+		values = []string{*property.Value}
+	} else if len(values) == 0 {
+		return nil, goa.MissingAttributeError(attribute, "values")
+	}
+	for _, value := range values {
+		converted, err := c.filterValue(ctx, attribute, property.Property, value)
+		if err != nil {
+			return nil, err
+		}
+		condition.Values = append(condition.Values, converted)
+	}
+	return condition, nil
+}
+
+// fieldOperators returns the operators allowed on the field. Fields that are not filterable give a bad
+// request error.
+func (c *UserController) fieldOperators(ctx context.Context, field string) ([]string, error) {
+	if operators, ok := filterableFields[field]; ok {
+		return operators, nil
+	}
+	name := profileAttributeName(field)
+	if name == "" {
+		return nil, goa.ErrBadRequest(fmt.Sprintf("cannot filter by %s", field))
+	}
+	attribute, err := c.profileFilterAttribute(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if attribute.Type == config.ProfileTypeString {
+		return textOperators, nil
+	}
+	return equalityOperators, nil
+}
+
+// filterValue converts the value of a filter to the type of the field.
+func (c *UserController) filterValue(ctx context.Context, attribute, field, value string) (interface{}, error) {
+	switch field {
+	case "email":
+		return normalizeEmail(value), nil
+	case "id":
+		if !bson.IsObjectIdHex(value) {
+			return nil, goa.InvalidFormatError(attribute+".value", value, "objectid", fmt.Errorf("invalid id"))
+		}
+		return value, nil
+	case "active":
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, goa.InvalidAttributeTypeError(attribute+".value", value, "boolean")
+		}
+		return active, nil
+	case "createdAt", "modifiedAt":
+		timestamp, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, goa.InvalidAttributeTypeError(attribute+".value", value, "integer")
+		}
+		return timestamp, nil
+	}
+	if name := profileAttributeName(field); name != "" {
+		return c.profileFilterValue(ctx, name, value)
+	}
+	return value, nil
+}
+
+// checkSortField checks that the users can be sorted by the field. Sorting is limited to the filterable
+// fields, so that the order does not give away the values of the other fields.
+func (c *UserController) checkSortField(ctx context.Context, field string) error {
+	_, err := c.fieldOperators(ctx, field)
+	return err
+}
+
+// mongoFilter translates the filter to a MongoDB query. The backend passes the query operators through.
+func (f *userFilter) mongoFilter() backends.Filter {
+	filter := backends.NewFilter()
+	expressions := mongoConditions(f.All)
+	if f.ActiveOnly {
+		expressions = append(expressions, mongoActive)
+	}
+	if len(expressions) > 0 {
+		filter["$and"] = expressions
+	}
+	if len(f.Any) > 0 {
+		groups := []interface{}{}
+		for _, group := range f.Any {
+			if len(group) == 0 {
+				groups = append(groups, bson.M{})
+				continue
+			}
+			groups = append(groups, bson.M{"$and": mongoConditions(group)})
+		}
+		filter["$or"] = groups
+	}
+	return filter
+}
+
+// mongoActive matches the active users, as UserRecord.CurrentStatus: by the status, or by the active flag for
+// the users saved before the statuses.
+var mongoActive = bson.M{"$or": []interface{}{
+	bson.M{"status": store.StatusActive},
+	bson.M{
+		"status":    bson.M{"$in": []interface{}{nil, ""}},
+		"deletedAt": bson.M{"$in": []interface{}{nil, 0}},
+		"active":    true,
+	},
+}}
+
+// emptyValues are the values that the exists operator takes as no value. The backend stores the empty fields
+// of the users.
+var emptyValues = []interface{}{nil, "", []interface{}{}}
+
+// mongoConditions translates the conditions to MongoDB query expressions.
+func mongoConditions(conditions []*userCondition) []interface{} {
+	expressions := []interface{}{}
+	for _, condition := range conditions {
+		field := condition.Field
+		values := condition.Values
+		if field == "id" {
+			field = "_id"
+			values = []interface{}{}
+			for _, value := range condition.Values {
+				if id, ok := value.(string); ok {
+					values = append(values, bson.ObjectIdHex(id))
+					continue
+				}
+				values = append(values, value)
+			}
+		}
+
+		var expression interface{}
+		switch condition.Operator {
+		case opExists:
+			if condition.Values[0].(bool) {
+				expression = bson.M{"$nin": emptyValues}
+			} else {
+				expression = bson.M{"$in": emptyValues}
+			}
+		case opIn:
+			expression = bson.M{"$in": values}
+		case opNin:
+			expression = bson.M{"$nin": values}
+		case opPrefix:
+			expression = bson.M{"$regex": "^" + regexp.QuoteMeta(fmt.Sprint(values[0]))}
+		case opContains:
+			expression = bson.M{"$regex": regexp.QuoteMeta(fmt.Sprint(values[0]))}
+		default:
+			expression = bson.M{"$" + condition.Operator: values[0]}
+		}
+		expressions = append(expressions, bson.M{field: expression})
+	}
+	return expressions
+}
+
+// listFields are the user fields that hold lists of values.
+var listFields = []string{"roles", "organizations", "namespaces"}
+
+// dynamoFilter translates the filter to a DynamoDB filter expression, with the "$" placeholders for the names
+// and "?" for the values, as the dynamo package takes them. The conditions that DynamoDB cannot run give an
+// invalid input error.
+func (f *userFilter) dynamoFilter() (string, []interface{}, error) {
+	expression, args, err := dynamoConditions(f.All)
+	if err != nil {
+		return "", nil, err
+	}
+	expressions := []string{}
+	if expression != "" {
+		expressions = append(expressions, expression)
+	}
+	if f.ActiveOnly {
+		expressions = append(expressions, dynamoActive)
+		args = append(args, dynamoActiveArgs...)
+	}
+
+	groups := []string{}
+	for _, group := range f.Any {
+		expression, groupArgs, err := dynamoConditions(group)
+		if err != nil {
+			return "", nil, err
+		}
+		if expression == "" {
+			// an empty group matches every user
+			groups = nil
+			break
+		}
+		groups = append(groups, "("+expression+")")
+		args = append(args, groupArgs...)
+	}
+	if len(groups) > 0 {
+		expressions = append(expressions, "("+strings.Join(groups, " OR ")+")")
+	}
+	return strings.Join(expressions, " AND "), args, nil
+}
+
+// dynamoActive matches the active users, as mongoActive. The dynamo package does not store the empty strings.
+const dynamoActive = "($ = ? OR (attribute_not_exists($) AND (attribute_not_exists($) OR $ = ?) AND $ = ?))"
+
+var dynamoActiveArgs = []interface{}{"status", store.StatusActive, "status", "deletedAt", "deletedAt", 0, "active", true}
+
+// dynamoConditions translates the conditions to a DynamoDB filter expression that matches all of them.
+func dynamoConditions(conditions []*userCondition) (string, []interface{}, error) {
+	expressions := []string{}
+	args := []interface{}{}
+	for _, condition := range conditions {
+		expression, conditionArgs, err := condition.dynamoCondition()
+		if err != nil {
+			return "", nil, err
+		}
+		expressions = append(expressions, expression)
+		args = append(args, conditionArgs...)
+	}
+	return strings.Join(expressions, " AND "), args, nil
+}
+
+// dynamoCondition translates the condition to a DynamoDB filter expression, with the same semantics as the
+// MongoDB query. A condition on a list field matches when the list holds the value, DynamoDB cannot match
+// the values in a list by prefix or by part.
+func (u *userCondition) dynamoCondition() (string, []interface{}, error) {
+	name := "$"
+	names := []interface{}{u.Field}
+	if attribute := profileAttributeName(u.Field); attribute != "" {
+		name = "$.$"
+		names = []interface{}{"profile", attribute}
+	}
+	list := containsString(listFields, u.Field)
+	args := []interface{}{}
+	term := func(format string, values ...interface{}) string {
+		args = append(args, names...)
+		args = append(args, values...)
+		return fmt.Sprintf(format, name)
+	}
+
+	switch u.Operator {
+	case opExists:
+		var expression string
+		if list {
+			expression = term("size(%s) > ?", 0)
+		} else {
+			// the name is used twice
+			args = append(args, names...)
+			expression = term("(attribute_exists(%s) AND NOT attribute_type(%[1]s, ?))", "NULL")
+		}
+		if !u.Values[0].(bool) {
+			expression = "NOT " + expression
+		}
+		return expression, args, nil
+	case opPrefix, opContains:
+		if list {
+			return "", nil, backends.ErrInvalidInput(fmt.Sprintf("operator %s cannot be used on %s", u.Operator, u.Field))
+		}
+		function := "begins_with"
+		if u.Operator == opContains {
+			function = "contains"
+		}
+		return term(function+"(%s, ?)", u.Values[0]), args, nil
+	case opEq, opNe, opIn, opNin:
+		terms := []string{}
+		if list {
+			for _, value := range u.Values {
+				terms = append(terms, term("contains(%s, ?)", value))
+			}
+		} else if len(u.Values) == 1 {
+			terms = append(terms, term("%s = ?", u.Values[0]))
+		} else {
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(u.Values)), ", ")
+			terms = append(terms, term("%s IN ("+placeholders+")", u.Values...))
+		}
+		expression := strings.Join(terms, " OR ")
+		if len(terms) > 1 || u.Operator == opNe || u.Operator == opNin {
+			expression = "(" + expression + ")"
+		}
+		if u.Operator == opNe || u.Operator == opNin {
+			expression = "NOT " + expression
+		}
+		return expression, args, nil
+	}
+
+	operators := map[string]string{opGt: ">", opGte: ">=", opLt: "<", opLte: "<="}
+	return term("%s "+operators[u.Operator]+" ?", u.Values[0]), args, nil
+}
+
+// matches checks whether the user fields match the filter. Used for the repositories that cannot run queries,
+// with the same semantics as the MongoDB query: a condition on a list field matches when any of
+// the values in the list matches, and the negated operators when none does.
+func (f *userFilter) matches(fields map[string]interface{}) bool {
+	if !matchesConditions(f.All, fields) {
+		return false
+	}
+	if len(f.Any) == 0 {
+		return true
+	}
+	for _, group := range f.Any {
+		if matchesConditions(group, fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesConditions checks whether the user fields match all the conditions.
+func matchesConditions(conditions []*userCondition, fields map[string]interface{}) bool {
+	for _, condition := range conditions {
+		if !condition.matches(fieldValue(fields, condition.Field)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches checks whether the value of the field matches the condition.
+func (u *userCondition) matches(value interface{}) bool {
+	items := fieldItems(value)
+	switch u.Operator {
+	case opExists:
+		return (len(items) > 0 && value != "") == u.Values[0].(bool)
+	case opNe, opNin:
+		for _, item := range items {
+			if containsValue(u.Values, item) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, item := range items {
+		if u.matchesItem(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesItem checks a single value of the field against the positive operators.
+func (u *userCondition) matchesItem(item interface{}) bool {
+	switch u.Operator {
+	case opEq, opIn:
+		return containsValue(u.Values, item)
+	case opPrefix:
+		s, ok := item.(string)
+		return ok && strings.HasPrefix(s, fmt.Sprint(u.Values[0]))
+	case opContains:
+		s, ok := item.(string)
+		return ok && strings.Contains(s, fmt.Sprint(u.Values[0]))
+	}
+
+	order, ok := compareValues(item, u.Values[0])
+	if !ok {
+		return false
+	}
+	switch u.Operator {
+	case opGt:
+		return order > 0
+	case opGte:
+		return order >= 0
+	case opLt:
+		return order < 0
+	case opLte:
+		return order <= 0
+	}
+	return false
+}
+
+// compareValues compares two numbers or two strings, and returns whether the values are comparable.
+func compareValues(a, b interface{}) (int, bool) {
+	if x, ok := profileNumber(a); ok {
+		y, ok := profileNumber(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	x, okA := a.(string)
+	y, okB := b.(string)
+	if !okA || !okB {
+		return 0, false
+	}
+	return strings.Compare(x, y), true
+}
+
+// containsValue checks whether the values hold the item, comparing numbers by value.
+func containsValue(values []interface{}, item interface{}) bool {
+	for _, value := range values {
+		if x, ok := profileNumber(value); ok {
+			if y, ok := profileNumber(item); ok && x == y {
+				return true
+			}
+			continue
+		}
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldItems returns the values of a list field, or the value itself for other fields.
+func fieldItems(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	if value == nil {
+		return nil
+	}
+	return []interface{}{value}
+}
+
+// fieldValue returns the value of the field. Profile attributes are given as "profile.<name>".
+func fieldValue(fields map[string]interface{}, field string) interface{} {
+	if name := profileAttributeName(field); name != "" {
+		profile, _ := fields["profile"].(map[string]interface{})
+		return profile[name]
+	}
+	return fields[field]
+}
+
+// userFields returns the fields of the user as in its JSON representation.
+func userFields(user *store.UserRecord) (map[string]interface{}, error) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// listUsers returns a page of the users matching the filter, from the offset, and the total number of the
+// matching users. The after condition selects the page in cursor mode and is not counted in the total. The
+// users are counted only if count is set, the total is 0 otherwise.
+func (c *UserController) listUsers(filter *userFilter, after *userCondition, sortBy, sortDir string, limit, offset int, count bool) ([]*store.UserRecord, int, error) {
+	var repository *backends.MongoSession
+	switch users := c.Store.Users.(type) {
+	case *backends.MongoSession:
+		repository = users
+	case *backends.DynamoCollection:
+		return scanDynamoUsers(users, filter, after, sortBy, sortDir, limit, offset)
+	default:
+		return c.filterUsers(filter, after, sortBy, sortDir, limit, offset)
+	}
+
+	total := 0
+	if count {
+		var err error
+		if total, err = countMongoUsers(repository, filter.mongoFilter()); err != nil {
+			return nil, 0, err
+		}
+	}
+	if sortBy == "id" {
+		sortBy = "_id"
+	}
+	users := []*store.UserRecord{}
+	result, err := repository.GetAll(filter.and(after).mongoFilter(), &store.UserRecord{}, sortBy, sortDir, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = backends.MapToInterface(result, &users); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// countMongoUsers counts the users matching the filter with a count query, the users are not read.
+func countMongoUsers(repository *backends.MongoSession, filter backends.Filter) (int, error) {
+	session, collection := repository.GetCollection()
+	defer session.Close()
+
+	return collection.Find(bson.M(filter)).Count()
+}
+
+// scanDynamoUsers runs listUsers on DynamoDB. The filter is run by DynamoDB as the filter expression of a
+// scan, so only the matching users are read. DynamoDB cannot sort the results of a scan, so the matching
+// users are sorted and paged here.
+func scanDynamoUsers(table *backends.DynamoCollection, filter *userFilter, after *userCondition, sortBy, sortDir string, limit, offset int) ([]*store.UserRecord, int, error) {
+	expression, args, err := filter.dynamoFilter()
+	if err != nil {
+		return nil, 0, err
+	}
+	scan := table.Scan()
+	if expression != "" {
+		scan = scan.Filter(expression, args...)
+	}
+	records := []map[string]interface{}{}
+	if err = scan.All(&records); err != nil {
+		return nil, 0, err
+	}
+	users := []*store.UserRecord{}
+	if err = backends.MapToInterface(&records, &users); err != nil {
+		return nil, 0, err
+	}
+	return pageUsers(users, after, sortBy, sortDir, limit, offset)
+}
+
+// filterUsers runs listUsers for the repositories that cannot run queries, as the in-memory store: all the
+// users are read from the repository, then filtered here.
+func (c *UserController) filterUsers(filter *userFilter, after *userCondition, sortBy, sortDir string, limit, offset int) ([]*store.UserRecord, int, error) {
+	result, err := c.Store.Users.GetAll(nil, &store.UserRecord{}, "", "", 0, 0)
+	if err != nil && !backends.IsErrNotFound(err) {
+		return nil, 0, err
+	}
+	users := []*store.UserRecord{}
+	if result != nil {
+		if err = backends.MapToInterface(result, &users); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	matched := []*store.UserRecord{}
+	for _, user := range users {
+		if filter.ActiveOnly && user.CurrentStatus() != store.StatusActive {
+			continue
+		}
+		fields, err := userFields(user)
+		if err != nil {
+			return nil, 0, err
+		}
+		if filter.matches(fields) {
+			matched = append(matched, user)
+		}
+	}
+	return pageUsers(matched, after, sortBy, sortDir, limit, offset)
+}
+
+// pageUsers sorts and pages the users matching a filter, for the backends that cannot. Returns the page and
+// the number of the matching users. The after condition selects the page in cursor mode and is not counted.
+func pageUsers(users []*store.UserRecord, after *userCondition, sortBy, sortDir string, limit, offset int) ([]*store.UserRecord, int, error) {
+	type matchedUser struct {
+		user   *store.UserRecord
+		fields map[string]interface{}
+	}
+	matched := []*matchedUser{}
+	for _, user := range users {
+		fields, err := userFields(user)
+		if err != nil {
+			return nil, 0, err
+		}
+		if after == nil || after.matches(fieldValue(fields, after.Field)) {
+			matched = append(matched, &matchedUser{user: user, fields: fields})
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		if sortDir == "desc" {
+			i, j = j, i
+		}
+		return lessFieldValue(fieldValue(matched[i].fields, sortBy), fieldValue(matched[j].fields, sortBy))
+	})
+
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	matched = matched[offset:]
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+
+	page := []*store.UserRecord{}
+	for _, m := range matched {
+		page = append(page, m.user)
+	}
+	return page, len(users), nil
+}
+
+// lessFieldValue compares two field values, numerically when both are numbers. Missing values come first.
+func lessFieldValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	x, okA := profileNumber(a)
+	y, okB := profileNumber(b)
+	if okA && okB {
+		return x < y
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
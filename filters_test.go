@@ -0,0 +1,111 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Microkubes/backends"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestMongoFilter(t *testing.T) {
+	filter := &userFilter{
+		All: []*userCondition{
+			{Field: "id", Operator: opNin, Values: []interface{}{"5df2103b5f1b640001142d3c"}},
+			{Field: "email", Operator: opPrefix, Values: []interface{}{"a.b+"}},
+			{Field: "createdAt", Operator: opGte, Values: []interface{}{int64(100)}},
+		},
+		Any: [][]*userCondition{
+			{{Field: "roles", Operator: opEq, Values: []interface{}{"admin"}}},
+			{{Field: "externalId", Operator: opExists, Values: []interface{}{false}}},
+		},
+	}
+
+	expected := backends.Filter{
+		"$and": []interface{}{
+			bson.M{"_id": bson.M{"$nin": []interface{}{bson.ObjectIdHex("5df2103b5f1b640001142d3c")}}},
+			bson.M{"email": bson.M{"$regex": `^a\.b\+`}},
+			bson.M{"createdAt": bson.M{"$gte": int64(100)}},
+		},
+		"$or": []interface{}{
+			bson.M{"$and": []interface{}{bson.M{"roles": bson.M{"$eq": "admin"}}}},
+			bson.M{"$and": []interface{}{bson.M{"externalId": bson.M{"$in": emptyValues}}}},
+		},
+	}
+	if mongoFilter := filter.mongoFilter(); !reflect.DeepEqual(mongoFilter, expected) {
+		t.Errorf("Expected %v, got %v", expected, mongoFilter)
+	}
+}
+
+func TestDynamoFilter(t *testing.T) {
+	filter := &userFilter{
+		All: []*userCondition{
+			{Field: "email", Operator: opPrefix, Values: []interface{}{"a.b+"}},
+			{Field: "roles", Operator: opNin, Values: []interface{}{"system", "admin"}},
+			{Field: "createdAt", Operator: opGte, Values: []interface{}{int64(100)}},
+		},
+		Any: [][]*userCondition{
+			{{Field: "status", Operator: opIn, Values: []interface{}{"active", "locked"}}},
+			{{Field: "profile.city", Operator: opExists, Values: []interface{}{false}}},
+		},
+	}
+
+	expression, args, err := filter.dynamoFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "begins_with($, ?) AND NOT (contains($, ?) OR contains($, ?)) AND $ >= ? AND " +
+		"(($ IN (?, ?)) OR (NOT (attribute_exists($.$) AND NOT attribute_type($.$, ?))))"
+	if expression != expected {
+		t.Errorf("Expected %s, got %s", expected, expression)
+	}
+	expectedArgs := []interface{}{
+		"email", "a.b+", "roles", "system", "roles", "admin", "createdAt", int64(100),
+		"status", "active", "locked", "profile", "city", "profile", "city", "NULL",
+	}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("Expected %v, got %v", expectedArgs, args)
+	}
+
+	active := &userFilter{ActiveOnly: true}
+	expression, args, err = active.dynamoFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placeholders := strings.Count(expression, "$") + strings.Count(expression, "?"); placeholders != len(args) {
+		t.Errorf("Expected an argument for each of the %d placeholders, got %v", placeholders, args)
+	}
+
+	unsupported := &userFilter{All: []*userCondition{{Field: "roles", Operator: opPrefix, Values: []interface{}{"adm"}}}}
+	if _, _, err = unsupported.dynamoFilter(); !backends.IsErrInvalidInput(err) {
+		t.Errorf("Expected an invalid input error for a prefix of the roles, got %v", err)
+	}
+}
+
+func TestUserConditionMatches(t *testing.T) {
+	roles := []interface{}{"user", "admin"}
+	for _, tc := range []struct {
+		condition *userCondition
+		value     interface{}
+		expected  bool
+	}{
+		{&userCondition{Operator: opEq, Values: []interface{}{"admin"}}, roles, true},
+		{&userCondition{Operator: opNe, Values: []interface{}{"admin"}}, roles, false},
+		{&userCondition{Operator: opIn, Values: []interface{}{"system", "user"}}, roles, true},
+		{&userCondition{Operator: opNin, Values: []interface{}{"system"}}, roles, true},
+		{&userCondition{Operator: opPrefix, Values: []interface{}{"adm"}}, roles, true},
+		{&userCondition{Operator: opContains, Values: []interface{}{"dmi"}}, "admin", true},
+		{&userCondition{Operator: opGt, Values: []interface{}{int64(100)}}, float64(101), true},
+		{&userCondition{Operator: opLte, Values: []interface{}{int64(100)}}, float64(101), false},
+		{&userCondition{Operator: opLt, Values: []interface{}{int64(100)}}, nil, false},
+		{&userCondition{Operator: opEq, Values: []interface{}{float64(2)}}, float64(2), true},
+		{&userCondition{Operator: opExists, Values: []interface{}{true}}, "", false},
+		{&userCondition{Operator: opExists, Values: []interface{}{false}}, nil, true},
+		{&userCondition{Operator: opNe, Values: []interface{}{"admin"}}, nil, true},
+	} {
+		if tc.condition.matches(tc.value) != tc.expected {
+			t.Errorf("Expected %v for %s %v on %v", tc.expected, tc.condition.Operator, tc.condition.Values, tc.value)
+		}
+	}
+}
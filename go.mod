@@ -6,8 +6,11 @@ require (
 	github.com/Microkubes/backends v1.1.2
 	github.com/Microkubes/microservice-security v1.2.1
 	github.com/Microkubes/microservice-tools v1.1.0
+	github.com/aws/aws-sdk-go v1.26.6
+	github.com/guregu/dynamo v1.5.0
 	github.com/keitaroinc/goa v1.5.0
 	github.com/spf13/cobra v0.0.5
 	golang.org/x/crypto v0.0.0-20191219195013-becbf705a915
+	golang.org/x/net v0.0.0-20191209160850-c0dbc17a3553
 	gopkg.in/mgo.v2 v2.0.0-20190816093944-a6b53ec6cb22
 )
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/Microkubes/microservice-user/config"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// DefaultArgon2Memory is the default Argon2id memory, in KiB.
+	DefaultArgon2Memory = 64 * 1024
+	// DefaultArgon2Iterations is the default number of Argon2id passes.
+	DefaultArgon2Iterations = 3
+	// DefaultArgon2Parallelism is the default number of Argon2id threads.
+	DefaultArgon2Parallelism = 2
+	// DefaultArgon2SaltLength is the default Argon2id salt length, in bytes.
+	DefaultArgon2SaltLength = 16
+	// DefaultArgon2KeyLength is the default Argon2id key length, in bytes.
+	DefaultArgon2KeyLength = 32
+)
+
+// ErrPasswordMismatch is returned when the password does not match the hash.
+var ErrPasswordMismatch = fmt.Errorf("password does not match")
+
+// PasswordHasher hashes passwords and verifies passwords against hashes. The hashes are self-describing,
+// they contain the algorithm and the parameters used to generate them.
+type PasswordHasher interface {
+	// Hash generates the hash of the password.
+	Hash(password string) (string, error)
+	// Compare checks the password against the hash. Returns nil if they match.
+	Compare(hash, password string) error
+	// Supports checks whether the hash has been generated by this hasher's algorithm.
+	Supports(hash string) bool
+	// NeedsRehash checks whether the hash has been generated with different algorithm or parameters than
+	// the ones currently configured.
+	NeedsRehash(hash string) bool
+}
+
+// BcryptHasher is a PasswordHasher using bcrypt.
+type BcryptHasher struct {
+	Cost int
+}
+
+// Hash generates the bcrypt hash of the password.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Compare checks the password against the bcrypt hash.
+func (h *BcryptHasher) Compare(hash, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return ErrPasswordMismatch
+		}
+		return err
+	}
+	return nil
+}
+
+// Supports checks for the bcrypt hash prefix ($2a$, $2b$ or $2y$).
+func (h *BcryptHasher) Supports(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// NeedsRehash checks whether the hash has been generated with a different cost.
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.Cost
+}
+
+// Argon2idHasher is a PasswordHasher using Argon2id. The hashes are encoded in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+type Argon2idHasher struct {
+	config.Argon2Params
+}
+
+// Hash generates the Argon2id hash of the password with a random salt.
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.Memory, h.Iterations, h.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Compare checks the password against the Argon2id hash, using the parameters encoded in the hash.
+func (h *Argon2idHasher) Compare(hash, password string) error {
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return err
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// Supports checks for the Argon2id hash prefix.
+func (h *Argon2idHasher) Supports(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+// NeedsRehash checks whether the hash has been generated with different Argon2id parameters.
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+	params, _, _, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return true
+	}
+	return params.Memory != h.Memory || params.Iterations != h.Iterations ||
+		params.Parallelism != h.Parallelism || params.KeyLength != h.KeyLength
+}
+
+// decodeArgon2idHash parses the PHC encoded Argon2id hash into parameters, salt and key.
+func decodeArgon2idHash(hash string) (*config.Argon2Params, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, fmt.Errorf("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, nil, nil, err
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	params := &config.Argon2Params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+// PasswordHashing hashes new passwords with the configured algorithm, while still being able to
+// verify hashes generated by any of the supported algorithms.
+type PasswordHashing struct {
+	// Current is the hasher used for new passwords
+	Current PasswordHasher
+	hashers []PasswordHasher
+}
+
+// NewPasswordHashing creates PasswordHashing from the configuration.
+func NewPasswordHashing(cfg *config.PasswordHashing) (*PasswordHashing, error) {
+	if cfg == nil {
+		cfg = &config.PasswordHashing{}
+	}
+
+	bcryptHasher := &BcryptHasher{
+		Cost: cfg.BcryptCost,
+	}
+	if bcryptHasher.Cost == 0 {
+		bcryptHasher.Cost = bcrypt.DefaultCost
+	}
+	if bcryptHasher.Cost < bcrypt.MinCost || bcryptHasher.Cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("invalid bcrypt cost %d", bcryptHasher.Cost)
+	}
+
+	argon2Hasher := &Argon2idHasher{
+		Argon2Params: cfg.Argon2,
+	}
+	if argon2Hasher.Memory == 0 {
+		argon2Hasher.Memory = DefaultArgon2Memory
+	}
+	if argon2Hasher.Iterations == 0 {
+		argon2Hasher.Iterations = DefaultArgon2Iterations
+	}
+	if argon2Hasher.Parallelism == 0 {
+		argon2Hasher.Parallelism = DefaultArgon2Parallelism
+	}
+	if argon2Hasher.SaltLength == 0 {
+		argon2Hasher.SaltLength = DefaultArgon2SaltLength
+	}
+	if argon2Hasher.KeyLength == 0 {
+		argon2Hasher.KeyLength = DefaultArgon2KeyLength
+	}
+
+	hashing := &PasswordHashing{
+		hashers: []PasswordHasher{bcryptHasher, argon2Hasher},
+	}
+
+	switch cfg.Algorithm {
+	case "", "bcrypt":
+		hashing.Current = bcryptHasher
+	case "argon2id":
+		hashing.Current = argon2Hasher
+	default:
+		return nil, fmt.Errorf("unsupported password hashing algorithm %s", cfg.Algorithm)
+	}
+
+	return hashing, nil
+}
+
+// Hash generates the hash of the password with the current hasher.
+func (p *PasswordHashing) Hash(password string) (string, error) {
+	return p.Current.Hash(password)
+}
+
+// Compare checks the password against a hash generated by any of the supported hashers.
+func (p *PasswordHashing) Compare(hash, password string) error {
+	for _, hasher := range p.hashers {
+		if hasher.Supports(hash) {
+			return hasher.Compare(hash, password)
+		}
+	}
+	return fmt.Errorf("unsupported password hash format")
+}
+
+// Supports checks whether any of the hashers supports the hash.
+func (p *PasswordHashing) Supports(hash string) bool {
+	for _, hasher := range p.hashers {
+		if hasher.Supports(hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsRehash checks whether the hash should be replaced with a hash generated by the current hasher.
+func (p *PasswordHashing) NeedsRehash(hash string) bool {
+	return !p.Current.Supports(hash) || p.Current.NeedsRehash(hash)
+}
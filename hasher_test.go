@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Microkubes/microservice-user/config"
+)
+
+func TestBcryptHasher(t *testing.T) {
+	hasher := &BcryptHasher{Cost: 5}
+
+	hash, err := hasher.Hash("keitaro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasher.Supports(hash) {
+		t.Errorf("Expected bcrypt hash, got %s", hash)
+	}
+	if err := hasher.Compare(hash, "keitaro"); err != nil {
+		t.Errorf("Expected password to match, got %s", err)
+	}
+	if err := hasher.Compare(hash, "other"); err != ErrPasswordMismatch {
+		t.Errorf("Expected ErrPasswordMismatch, got %v", err)
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("Expected no rehash for the same cost")
+	}
+	if !(&BcryptHasher{Cost: 6}).NeedsRehash(hash) {
+		t.Error("Expected rehash for different cost")
+	}
+}
+
+func TestArgon2idHasher(t *testing.T) {
+	hasher := &Argon2idHasher{
+		Argon2Params: config.Argon2Params{
+			Memory:      1024,
+			Iterations:  1,
+			Parallelism: 1,
+			SaltLength:  16,
+			KeyLength:   32,
+		},
+	}
+
+	hash, err := hasher.Hash("keitaro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("Unexpected hash format: %s", hash)
+	}
+	if err := hasher.Compare(hash, "keitaro"); err != nil {
+		t.Errorf("Expected password to match, got %s", err)
+	}
+	if err := hasher.Compare(hash, "other"); err != ErrPasswordMismatch {
+		t.Errorf("Expected ErrPasswordMismatch, got %v", err)
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("Expected no rehash for the same parameters")
+	}
+
+	stronger := &Argon2idHasher{Argon2Params: hasher.Argon2Params}
+	stronger.Iterations = 2
+	if !stronger.NeedsRehash(hash) {
+		t.Error("Expected rehash for different parameters")
+	}
+	// hashes generated with other parameters can still be verified
+	if err := stronger.Compare(hash, "keitaro"); err != nil {
+		t.Errorf("Expected password to match, got %s", err)
+	}
+
+	if err := hasher.Compare("$argon2id$invalid", "keitaro"); err == nil {
+		t.Error("Expected error for invalid hash")
+	}
+}
+
+func TestPasswordHashing(t *testing.T) {
+	bcryptHashing, err := NewPasswordHashing(&config.PasswordHashing{BcryptCost: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	argon2Hashing, err := NewPasswordHashing(&config.PasswordHashing{
+		Algorithm: "argon2id",
+		Argon2: config.Argon2Params{
+			Memory:     1024,
+			Iterations: 1,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bcryptHash, err := bcryptHashing.Hash("keitaro")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := argon2Hashing.Compare(bcryptHash, "keitaro"); err != nil {
+		t.Errorf("Expected bcrypt hash to be verified, got %s", err)
+	}
+	if !argon2Hashing.NeedsRehash(bcryptHash) {
+		t.Error("Expected bcrypt hash to need rehash")
+	}
+	if bcryptHashing.NeedsRehash(bcryptHash) {
+		t.Error("Expected no rehash with the same configuration")
+	}
+	if err := argon2Hashing.Compare("plain-text", "plain-text"); err == nil {
+		t.Error("Expected error for unsupported hash")
+	}
+
+	if _, err := NewPasswordHashing(&config.PasswordHashing{Algorithm: "md5"}); err == nil {
+		t.Error("Expected error for unsupported algorithm")
+	}
+	if _, err := NewPasswordHashing(&config.PasswordHashing{BcryptCost: 100}); err == nil {
+		t.Error("Expected error for invalid bcrypt cost")
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/helpers"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// CreateInvitation invites a user with the given roles and memberships. A previous invitation for the same
+// email is replaced. Only the hash of the invitation token is stored, the token is sent by email.
+func (c *UserController) CreateInvitation(ctx *app.CreateInvitationUserContext) error {
+	displayEmail := strings.TrimSpace(ctx.Payload.Email)
+	ctx.Payload.Email = normalizeEmail(ctx.Payload.Email)
+
+	existing := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("email", ctx.Payload.Email), existing); err == nil {
+		return ctx.BadRequest(goa.ErrBadRequest("user already exists"))
+	} else if !backends.IsErrNotFound(err) {
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	expiresAt := now + int64(c.Config.GetInvitationTTL())*1000
+	if ctx.Payload.ExpiresAt != nil {
+		expiresAt = int64(*ctx.Payload.ExpiresAt)
+		if expiresAt <= now {
+			return ctx.BadRequest(goa.ErrBadRequest("expiresAt must be in the future"))
+		}
+	}
+
+	roles := ctx.Payload.Roles
+	if len(roles) == 0 {
+		roles = []string{"user"}
+	}
+
+	err := c.Store.Invitations.DeleteAll(backends.NewFilter().Match("email", ctx.Payload.Email))
+	if err != nil && !backends.IsErrNotFound(err) {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	token := generateToken(42)
+	record := &store.InvitationRecord{
+		Email:         ctx.Payload.Email,
+		DisplayEmail:  displayEmail,
+		Roles:         roles,
+		Organizations: ctx.Payload.Organizations,
+		Namespaces:    ctx.Payload.Namespaces,
+		Token:         c.TokenHasher.Hash(token),
+		CreatedAt:     now,
+		ExpiresAt:     expiresAt,
+	}
+	if auth.HasAuth(ctx.Context) {
+		record.InvitedBy = auth.GetAuth(ctx.Context).UserID
+	}
+
+	result, err := c.Store.Invitations.Save(record, nil)
+	if err != nil {
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	created := &store.InvitationRecord{}
+	if err = backends.MapToInterface(result, created); err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	err = c.sendEmail(created.Email, "invitation", map[string]string{
+		"id":        created.ID,
+		"email":     created.Email,
+		"token":     token,
+		"expiresAt": strconv.FormatInt(created.ExpiresAt, 10),
+	})
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.Created(created.ToAppInvitation())
+}
+
+// ListInvitations lists the pending invitations, the most recent first. The expired invitations are left out,
+// they are deleted once someone tries to accept them.
+func (c *UserController) ListInvitations(ctx *app.ListInvitationsUserContext) error {
+	var typeHint map[string]interface{}
+	result, err := c.Store.Invitations.GetAll(backends.NewFilter(), typeHint, "createdAt", "desc", 0, 0)
+	if err != nil && !backends.IsErrNotFound(err) {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	records := []*store.InvitationRecord{}
+	if err == nil && result != nil {
+		if err = backends.MapToInterface(result, &records); err != nil {
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	invitations := app.InvitationCollection{}
+	for _, record := range records {
+		if record.IsExpired(now) {
+			continue
+		}
+		invitations = append(invitations, record.ToAppInvitation())
+	}
+
+	return ctx.OK(invitations)
+}
+
+// RevokeInvitation deletes a pending invitation.
+func (c *UserController) RevokeInvitation(ctx *app.RevokeInvitationUserContext) error {
+	if err := c.Store.Invitations.DeleteOne(backends.NewFilter().Match("id", ctx.InvitationID)); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.NoContent()
+}
+
+// AcceptInvitation creates the invited user with the password chosen by the invitee. The email has been
+// verified by receiving the invitation, so the user is active right away.
+func (c *UserController) AcceptInvitation(ctx *app.AcceptInvitationUserContext) error {
+	invitation, err := c.invitationByToken(ctx.Payload.Token)
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if errorStatus(err) == http.StatusBadRequest {
+			return ctx.BadRequest(err)
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if err = validateProfile(c.Config.GetProfile(), ctx.Payload.Profile); err != nil {
+		return ctx.BadRequest(err)
+	}
+
+	user, err := c.createInvitedUser(invitation, ctx.Payload.Password, ctx.Payload.Profile)
+	if err != nil {
+		if errorStatus(err) == http.StatusBadRequest {
+			return ctx.BadRequest(err)
+		}
+		if backends.IsErrAlreadyExists(err) || backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.Created(c.userMedia(ctx, user))
+}
+
+// invitationByToken returns the pending invitation with the given token. Unknown tokens give a not found
+// error. Expired invitations are deleted and give a bad request error.
+func (c *UserController) invitationByToken(token string) (*store.InvitationRecord, error) {
+	invitation := &store.InvitationRecord{}
+	if _, err := c.Store.Invitations.GetOne(backends.NewFilter().Match("token", c.TokenHasher.Hash(token)), invitation); err != nil {
+		return nil, err
+	}
+
+	if !c.TokenHasher.Matches(invitation.Token, token) {
+		return nil, backends.ErrNotFound("not found")
+	}
+
+	if invitation.IsExpired(helpers.CurrentTimeMilliseconds()) {
+		if err := c.Store.Invitations.DeleteOne(backends.NewFilter().Match("id", invitation.ID)); err != nil && !backends.IsErrNotFound(err) {
+			return nil, err
+		}
+		return nil, goa.ErrBadRequest("invitation has expired")
+	}
+	return invitation, nil
+}
+
+// createInvitedUser creates the user for the accepted invitation and deletes the invitation. The email has been
+// verified by receiving the invitation, so the user is active right away. Passwords that do not satisfy the
+// password policy give a bad request error.
+func (c *UserController) createInvitedUser(invitation *store.InvitationRecord, password string, profile map[string]interface{}) (*store.UserRecord, error) {
+	if err := c.PasswordPolicy.ValidationError(password, invitation.Email); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := c.Passwords.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &store.UserRecord{
+		Active:          true,
+		Status:          store.StatusActive,
+		Email:           invitation.Email,
+		DisplayEmail:    invitation.DisplayEmail,
+		Password:        hashedPassword,
+		PasswordHistory: c.PasswordPolicy.NextPasswordHistory(hashedPassword, nil),
+		Roles:           invitation.Roles,
+		Organizations:   invitation.Organizations,
+		Namespaces:      invitation.Namespaces,
+		Profile:         profile,
+		CreatedAt:       helpers.CurrentTimeMilliseconds(),
+		Version:         1,
+	}
+
+	result, err := c.Store.Users.Save(user, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.Store.Invitations.DeleteOne(backends.NewFilter().Match("id", invitation.ID)); err != nil && !backends.IsErrNotFound(err) {
+		c.Service.LogError("User: failed to delete accepted invitation.", "err", err.Error())
+	}
+
+	return result.(*store.UserRecord), nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// errLocked is returned when the user account is locked after too many failed logins.
+var errLocked = goa.NewErrorClass("locked", 423)
+
+// maxLockBackoff limits the exponent of the lock duration back-off.
+const maxLockBackoff = 16
+
+// lockDuration returns the duration of the lock (in milliseconds) after the given number of consecutive
+// failed logins. The first lock lasts LockDuration, and every further failed login doubles it up to
+// MaxLockDuration.
+func lockDuration(lockout config.Lockout, failedLogins int) int64 {
+	backoff := failedLogins - lockout.MaxFailedLogins
+	if backoff > maxLockBackoff {
+		backoff = maxLockBackoff
+	}
+	duration := int64(lockout.LockDuration) << uint(backoff)
+	if duration > int64(lockout.MaxLockDuration) {
+		duration = int64(lockout.MaxLockDuration)
+	}
+	return duration * 1000
+}
+
+// isLocked checks whether the user is currently locked out.
+func isLocked(user *store.UserRecord, now int64) bool {
+	return user.CurrentStatus() == store.StatusLocked && now < user.LockedUntil
+}
+
+// recordFailedLogin increments the failed logins counter of the user and locks the account when the
+// configured number of consecutive failures is reached. The counter is saved with updateUser, so that
+// concurrent failed logins are all counted. Returns true if the account has been locked.
+func (c *UserController) recordFailedLogin(user *store.UserRecord, now int64) (bool, error) {
+	lockout := c.Config.GetLockout()
+	locked := false
+
+	_, err := c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		failedLogins := user.FailedLogins + 1
+		update := map[string]interface{}{
+			"failedLogins":      failedLogins,
+			"lastFailedLoginAt": now,
+		}
+
+		locked = lockout.MaxFailedLogins > 0 && failedLogins >= lockout.MaxFailedLogins
+		if locked {
+			if user.CurrentStatus() != store.StatusLocked {
+				statusUpdate, err := user.StatusUpdate(store.StatusLocked, "too many failed logins", "system", now)
+				if err != nil {
+					return nil, err
+				}
+				for key, value := range statusUpdate {
+					update[key] = value
+				}
+			}
+			update["lockedUntil"] = now + lockDuration(lockout, failedLogins)
+		}
+		return update, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
+
+// recordSuccessfulLogin sets the last login time of the user and resets the failed logins counter. For users
+// with MFA enabled, it is called only once the second factor is verified. If the lock has expired, the user is
+// moved back to the status before the lock. The given record is updated with the saved changes.
+func (c *UserController) recordSuccessfulLogin(user *store.UserRecord, now int64) error {
+	updated, err := c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		update := map[string]interface{}{
+			"lastLoginAt":  now,
+			"failedLogins": 0,
+		}
+
+		if user.CurrentStatus() == store.StatusLocked {
+			statusUpdate, err := user.StatusUpdate(user.StatusBefore(store.StatusLocked), "lock expired", "system", now)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range statusUpdate {
+				update[key] = value
+			}
+		}
+		return update, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	*user = *updated
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/store"
+)
+
+// clientIP returns the IP address of the client that sent the request. The X-Forwarded-For and X-Real-IP
+// headers set by the API gateway take precedence over the remote address of the connection. Only the last
+// X-Forwarded-For entry, added by the gateway, is used: the entries before it are sent by the client and
+// cannot be trusted.
+func clientIP(req *http.Request) string {
+	if forwarded := req.Header["X-Forwarded-For"]; len(forwarded) > 0 {
+		entries := strings.Split(forwarded[len(forwarded)-1], ",")
+		if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+			return ip
+		}
+	}
+	if realIP := req.Header.Get("X-Real-IP"); realIP != "" {
+		return strings.TrimSpace(realIP)
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// recordLogin adds an entry with the given outcome to the login history of the user and removes the
+// oldest entries beyond the configured history size.
+func (c *UserController) recordLogin(req *http.Request, userID, outcome string, now int64) error {
+	login := map[string]interface{}{
+		"userId":    userID,
+		"outcome":   outcome,
+		"ip":        clientIP(req),
+		"userAgent": req.UserAgent(),
+		"createdAt": now,
+	}
+	if _, err := c.Store.Logins.Save(&login, nil); err != nil {
+		return err
+	}
+
+	expired, err := c.loginHistory(userID, 0, c.Config.GetLoginHistorySize())
+	if err != nil {
+		return err
+	}
+	for _, login := range expired {
+		if err := c.Store.Logins.DeleteOne(backends.NewFilter().Match("id", login.ID)); err != nil && !backends.IsErrNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// logLogin records the login in the login history of the user. Failing to record the login is only
+// logged, it does not affect the outcome of the login.
+func (c *UserController) logLogin(req *http.Request, userID, outcome string, now int64) {
+	if err := c.recordLogin(req, userID, outcome, now); err != nil {
+		c.Service.LogError("User: failed to record login history.", "err", err.Error())
+	}
+}
+
+// loginHistory returns the logins of the user, the most recent first. A user without logins has an
+// empty history.
+func (c *UserController) loginHistory(userID string, limit, offset int) ([]*store.LoginRecord, error) {
+	var typeHint map[string]interface{}
+	result, err := c.Store.Logins.GetAll(backends.NewFilter().Match("userId", userID), typeHint, "createdAt", "desc", limit, offset)
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return []*store.LoginRecord{}, nil
+		}
+		return nil, err
+	}
+
+	logins := []*store.LoginRecord{}
+	if result == nil {
+		return logins, nil
+	}
+	if err = backends.MapToInterface(result, &logins); err != nil {
+		return nil, err
+	}
+	return logins, nil
+}
+
+// toAppLogins converts the login records to the Login media type collection.
+func toAppLogins(logins []*store.LoginRecord) app.LoginCollection {
+	collection := app.LoginCollection{}
+	for _, login := range logins {
+		collection = append(collection, login.ToAppLogin())
+	}
+	return collection
+}
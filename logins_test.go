@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/users/find", nil)
+	req.RemoteAddr = "10.0.0.1:54321"
+
+	if ip := clientIP(req); ip != "10.0.0.1" {
+		t.Errorf("Expected the remote address, got %s", ip)
+	}
+
+	req.Header.Set("X-Real-IP", "192.168.1.1")
+	if ip := clientIP(req); ip != "192.168.1.1" {
+		t.Errorf("Expected X-Real-IP, got %s", ip)
+	}
+
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+	if ip := clientIP(req); ip != "10.0.0.2" {
+		t.Errorf("Expected the X-Forwarded-For address added by the gateway, got %s", ip)
+	}
+
+	req.Header.Add("X-Forwarded-For", "203.0.113.8")
+	if ip := clientIP(req); ip != "203.0.113.8" {
+		t.Errorf("Expected the last X-Forwarded-For header, got %s", ip)
+	}
+}
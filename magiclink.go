@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/helpers"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// errMagicLinkUsed is returned when the magic link token has already been consumed.
+var errMagicLinkUsed = errors.New("magic link already used")
+
+// RequestMagicLink sends a single-use sign-in token to the email of the user, replacing any previous one. The
+// response is the same whether the user exists or not, so that it cannot be used to look up emails.
+func (c *UserController) RequestMagicLink(ctx *app.RequestMagicLinkUserContext) error {
+	ctx.Payload.Email = normalizeEmail(ctx.Payload.Email)
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("email", ctx.Payload.Email), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.OK([]byte{})
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	// Locked users get the link, the lock is checked when the link is used.
+	if !user.CanLogin() && user.CurrentStatus() != store.StatusLocked {
+		return ctx.OK([]byte{})
+	}
+
+	token := generateToken(42)
+	expiresAt := helpers.CurrentTimeMilliseconds() + int64(c.Config.GetMagicLinkTTL())*1000
+	update := map[string]interface{}{
+		"magicLinkToken":     c.TokenHasher.Hash(token),
+		"magicLinkExpiresAt": expiresAt,
+	}
+	if _, err := c.updateUser(user, func(*store.UserRecord) (map[string]interface{}, error) {
+		return update, nil
+	}); err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	err := c.sendEmail(user.Email, "magicLink", map[string]string{
+		"id":        user.ID.Hex(),
+		"email":     user.Email,
+		"token":     token,
+		"expiresAt": strconv.FormatInt(expiresAt, 10),
+	})
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK([]byte{})
+}
+
+// FindByMagicLink looks up a user by magic link token and consumes the token. The account status and the
+// lockout are checked as in Find. Intended for internal use.
+func (c *UserController) FindByMagicLink(ctx *app.FindByMagicLinkUserContext) error {
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("magicLinkToken", c.TokenHasher.Hash(ctx.Payload.Token)), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if !c.TokenHasher.Matches(user.MagicLinkToken, ctx.Payload.Token) {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	// The token is consumed before any other check, so that it can be used only once. The consuming save is
+	// conditional, so when the same token is used concurrently only one of the requests gets through.
+	hash := user.MagicLinkToken
+	expiresAt := user.MagicLinkExpiresAt
+	consumed, err := c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		if user.MagicLinkToken != hash {
+			return nil, errMagicLinkUsed
+		}
+		return map[string]interface{}{
+			"magicLinkToken":     "",
+			"magicLinkExpiresAt": 0,
+		}, nil
+	})
+	if err != nil {
+		if err == errMagicLinkUsed || err == errVersionConflict || backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound("already used"))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+	user = consumed
+
+	now := helpers.CurrentTimeMilliseconds()
+	if now >= expiresAt {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	if isLocked(user, now) {
+		c.logLogin(ctx.Request, user.ID.Hex(), store.LoginLocked, now)
+		return ctx.Locked(errLocked("account is locked", "lockedUntil", user.LockedUntil))
+	}
+
+	// Users with expired lock are allowed to sign in.
+	if !user.CanLogin() && user.CurrentStatus() != store.StatusLocked {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	// As in Find, the login of users with MFA enabled is successful only once the second factor is verified.
+	if !user.MFAEnabled {
+		if err := c.recordSuccessfulLogin(user, now); err != nil {
+			c.Service.LogError("User: failed to record successful login.", "err", err.Error())
+		}
+		c.logLogin(ctx.Request, user.ID.Hex(), store.LoginSuccess, now)
+	}
+
+	return ctx.OK(c.userMedia(ctx, user))
+}
@@ -111,30 +111,147 @@ func main() {
 		},
 		"enableTtl":    true,
 		"ttlAttribute": "created_at",
-		"ttl":          86400,
+		"ttl":          serviceConfig.GetVerificationToken().TTL,
 	})
 	if err != nil {
 		service.LogError("Failed to get tokens repo.", err)
 		return
 	}
 
+	loginRepo, err := backend.DefineRepository("logins", backends.RepositoryDefinitionMap{
+		"name": "logins",
+		"indexes": []backends.Index{
+			backends.NewNonUniqueIndex("userId"),
+		},
+		"hashKey":       "id",
+		"readCapacity":  int64(5),
+		"writeCapacity": int64(5),
+		"GSI": map[string]interface{}{
+			"userId": map[string]interface{}{
+				"readCapacity":  1,
+				"writeCapacity": 1,
+			},
+		},
+	})
+	if err != nil {
+		service.LogError("Failed to get logins repo.", err)
+		return
+	}
+
+	accessTokenRepo, err := backend.DefineRepository("accessTokens", backends.RepositoryDefinitionMap{
+		"name": "accessTokens",
+		"indexes": []backends.Index{
+			backends.NewUniqueIndex("hash"),
+			backends.NewNonUniqueIndex("userId"),
+		},
+		"hashKey":       "id",
+		"readCapacity":  int64(5),
+		"writeCapacity": int64(5),
+		"GSI": map[string]interface{}{
+			"hash": map[string]interface{}{
+				"readCapacity":  1,
+				"writeCapacity": 1,
+			},
+			"userId": map[string]interface{}{
+				"readCapacity":  1,
+				"writeCapacity": 1,
+			},
+		},
+	})
+	if err != nil {
+		service.LogError("Failed to get access tokens repo.", err)
+		return
+	}
+
+	invitationRepo, err := backend.DefineRepository("invitations", backends.RepositoryDefinitionMap{
+		"name": "invitations",
+		"indexes": []backends.Index{
+			backends.NewUniqueIndex("token"),
+			backends.NewNonUniqueIndex("email"),
+		},
+		"hashKey":       "id",
+		"readCapacity":  int64(5),
+		"writeCapacity": int64(5),
+		"GSI": map[string]interface{}{
+			"token": map[string]interface{}{
+				"readCapacity":  1,
+				"writeCapacity": 1,
+			},
+			"email": map[string]interface{}{
+				"readCapacity":  1,
+				"writeCapacity": 1,
+			},
+		},
+	})
+	if err != nil {
+		service.LogError("Failed to get invitations repo.", err)
+		return
+	}
+
+	// DynamoDB expires the tokens natively, on other backends the expired tokens are deleted periodically.
+	if dbConf.DBName != "dynamodb" {
+		stopSweeper := startTokenSweeper(service, tokenRepo, serviceConfig.GetVerificationToken())
+		defer stopSweeper()
+	}
+
 	rmqChannel, err := openRabbitMQChannel(serviceConfig)
 	if err != nil {
 		service.LogError("Failed setup messaging channel.", err)
 	}
 
 	store := store.User{
-		Users:  userRepo,
-		Tokens: tokenRepo,
+		Users:        userRepo,
+		Tokens:       tokenRepo,
+		Logins:       loginRepo,
+		AccessTokens: accessTokenRepo,
+		Invitations:  invitationRepo,
+	}
+
+	if err = serviceConfig.LoadTokenSecret(); err != nil {
+		service.LogError("Failed to load token secret.", err)
+		return
+	}
+
+	passwordPolicy, err := NewPasswordPolicy(serviceConfig.PasswordPolicy)
+	if err != nil {
+		service.LogError("Failed to load password policy.", err)
+		return
+	}
+
+	passwordHashing, err := NewPasswordHashing(serviceConfig.PasswordHashing)
+	if err != nil {
+		service.LogError("Failed to configure password hashing.", err)
+		return
 	}
 
 	// Mount "swagger" controller
 	c1 := NewSwaggerController(service)
 	app.MountSwaggerController(service, c1)
 	// Mount "user" controller
-	c2 := NewUserController(service, store, rmqChannel)
+	c2 := NewUserController(service, store, rmqChannel, serviceConfig, passwordPolicy, passwordHashing)
 	app.MountUserController(service, c2)
 
+	// Tokens stored in plaintext by previous versions are replaced with their hashes. The tokens are looked up
+	// by hash only, so this runs before the service starts serving.
+	if migrated, err := migratePlaintextTokens(store, c2.TokenHasher); err != nil {
+		service.LogError("Failed to hash plaintext tokens.", "err", err.Error())
+	} else if migrated > 0 {
+		service.LogInfo("Hashed plaintext tokens.", "count", migrated)
+	}
+
+	// Emails stored as entered by previous versions are normalized, before the service starts serving as the
+	// users are looked up by the normalized email. Duplicates are left for the admins to merge.
+	if normalized, duplicates, err := normalizeStoredEmails(store.Users); err != nil {
+		service.LogError("Failed to normalize emails.", "err", err.Error())
+	} else {
+		if normalized > 0 {
+			service.LogInfo("Normalized emails.", "count", normalized)
+		}
+		if duplicates > 0 {
+			service.LogInfo("Found users with duplicate emails, see GET /users/duplicates.", "count", duplicates)
+		}
+	}
+
 	// Start service
 	if err := service.ListenAndServe(":8080"); err != nil {
 		service.LogError("startup", "err", err)
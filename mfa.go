@@ -0,0 +1,222 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/helpers"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// EnrollTotp generates a new TOTP secret for the authenticated user. MFA is not enabled until the
+// enrollment is confirmed with a code generated from the secret.
+func (c *UserController) EnrollTotp(ctx *app.EnrollTotpUserContext) error {
+	if !auth.HasAuth(ctx.Context) {
+		return ctx.InternalServerError(goa.ErrBadRequest("no-auth"))
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", auth.GetAuth(ctx.Context).UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	_, err = c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		if user.MFAEnabled {
+			return nil, goa.ErrBadRequest("MFA is already enabled")
+		}
+		return map[string]interface{}{
+			"pendingTotpSecret": secret,
+		}, nil
+	})
+	if err != nil {
+		if errorStatus(err) == http.StatusBadRequest {
+			return ctx.BadRequest(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK(&app.TOTPEnrollment{
+		Secret: secret,
+		URI:    totpURI(c.Config.GetMFA().Issuer, user.Email, secret),
+	})
+}
+
+// ConfirmTotp checks the first code generated from the pending TOTP secret and enables MFA for the
+// authenticated user. The recovery codes are returned only once, only their hashes are stored.
+func (c *UserController) ConfirmTotp(ctx *app.ConfirmTotpUserContext) error {
+	if !auth.HasAuth(ctx.Context) {
+		return ctx.InternalServerError(goa.ErrBadRequest("no-auth"))
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", auth.GetAuth(ctx.Context).UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	codes, hashes, err := generateRecoveryCodes(c.Config.GetMFA().RecoveryCodes)
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	_, err = c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		if user.PendingTOTPSecret == "" {
+			return nil, goa.ErrBadRequest("no pending TOTP enrollment")
+		}
+		counter, ok := validateTOTP(user.PendingTOTPSecret, ctx.Payload.Code, now, 0)
+		if !ok {
+			return nil, goa.ErrBadRequest("invalid code")
+		}
+		return map[string]interface{}{
+			"mfaEnabled":        true,
+			"totpSecret":        user.PendingTOTPSecret,
+			"pendingTotpSecret": "",
+			"totpLastCounter":   counter,
+			"recoveryCodes":     hashes,
+			"modifiedAt":        now,
+		}, nil
+	})
+	if err != nil {
+		if errorStatus(err) == http.StatusBadRequest {
+			return ctx.BadRequest(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK(&app.RecoveryCodes{
+		RecoveryCodes: codes,
+	})
+}
+
+// VerifyMfa verifies the second factor of a user, either a TOTP code or one of the recovery codes. Intended
+// for the authentication services, after the password has been checked with Find. The login of users with MFA
+// enabled is recorded as successful here. Invalid codes count as failed logins.
+func (c *UserController) VerifyMfa(ctx *app.VerifyMfaUserContext) error {
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.Payload.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	if isLocked(user, now) {
+		c.logLogin(ctx.Request, user.ID.Hex(), store.LoginLocked, now)
+		return ctx.Locked(errLocked("account is locked", "lockedUntil", user.LockedUntil))
+	}
+
+	if !user.CanLogin() && user.CurrentStatus() != store.StatusLocked {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	if !user.MFAEnabled {
+		return ctx.BadRequest(goa.ErrBadRequest("MFA is not enabled"))
+	}
+
+	// The code is consumed with a conditional save and checked again on every retry, so that the same
+	// code cannot be used by concurrent requests.
+	updated, err := c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		if counter, ok := validateTOTP(user.TOTPSecret, ctx.Payload.Code, now, user.TOTPLastCounter); ok {
+			return map[string]interface{}{
+				"totpLastCounter": counter,
+			}, nil
+		}
+		if remaining, ok := useRecoveryCode(user.RecoveryCodes, ctx.Payload.Code); ok {
+			return map[string]interface{}{
+				"recoveryCodes": remaining,
+			}, nil
+		}
+		return nil, errForbidden("invalid code")
+	})
+	if err != nil {
+		if errorStatus(err) != http.StatusForbidden {
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+		locked, err := c.recordFailedLogin(user, now)
+		if err != nil {
+			c.Service.LogError("User: failed to record failed login.", "err", err.Error())
+		}
+		if locked {
+			c.logLogin(ctx.Request, user.ID.Hex(), store.LoginLocked, now)
+			return ctx.Locked(errLocked("account is locked"))
+		}
+		c.logLogin(ctx.Request, user.ID.Hex(), store.LoginFailure, now)
+		return ctx.Forbidden(errForbidden("invalid code"))
+	}
+
+	if err := c.recordSuccessfulLogin(updated, now); err != nil {
+		c.Service.LogError("User: failed to record successful login.", "err", err.Error())
+	}
+	c.logLogin(ctx.Request, updated.ID.Hex(), store.LoginSuccess, now)
+
+	return ctx.OK(c.userMedia(ctx, updated))
+}
+
+// ResetMfa disables MFA for a user and removes the TOTP secret and the recovery codes, so that the user
+// can enroll again.
+func (c *UserController) ResetMfa(ctx *app.ResetMfaUserContext) error {
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	updated, err := c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"mfaEnabled":        false,
+			"totpSecret":        "",
+			"pendingTotpSecret": "",
+			"totpLastCounter":   0,
+			"recoveryCodes":     []string{},
+			"modifiedAt":        helpers.CurrentTimeMilliseconds(),
+		}, nil
+	})
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK(c.userMedia(ctx, updated))
+}
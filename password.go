@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+const (
+	// DefaultPasswordMinLength is the minimal password length used when none is configured.
+	DefaultPasswordMinLength = 6
+	// DefaultPasswordMaxLength is the maximal password length used when none is configured.
+	// bcrypt, the default hashing algorithm, only takes the first 72 bytes of the password into account.
+	DefaultPasswordMaxLength = 72
+	// DefaultPasswordHistorySize is the number of recent passwords that cannot be reused when none is configured.
+	DefaultPasswordHistorySize = 5
+)
+
+// PolicyViolation describes a single password policy rule that the password does not satisfy.
+type PolicyViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PasswordPolicy validates new passwords against the configured rules.
+type PasswordPolicy struct {
+	config.PasswordPolicy
+	denyList map[string]bool
+}
+
+// NewPasswordPolicy creates a PasswordPolicy from the configuration. If a deny-list file is
+// configured, the common passwords are loaded from it.
+func NewPasswordPolicy(cfg *config.PasswordPolicy) (*PasswordPolicy, error) {
+	policy := &PasswordPolicy{
+		denyList: map[string]bool{},
+	}
+	if cfg != nil {
+		policy.PasswordPolicy = *cfg
+	}
+	if policy.MinLength <= 0 {
+		policy.MinLength = DefaultPasswordMinLength
+	}
+	if policy.MaxLength <= 0 {
+		policy.MaxLength = DefaultPasswordMaxLength
+	}
+	if policy.HistorySize == 0 {
+		policy.HistorySize = DefaultPasswordHistorySize
+	}
+	if policy.MinLength > policy.MaxLength {
+		return nil, fmt.Errorf("password policy: minLength (%d) is greater than maxLength (%d)", policy.MinLength, policy.MaxLength)
+	}
+
+	if policy.DenyListFile != "" {
+		denyList, err := loadPasswordDenyList(policy.DenyListFile)
+		if err != nil {
+			return nil, err
+		}
+		policy.denyList = denyList
+	}
+
+	return policy, nil
+}
+
+// Validate checks the password of the user with the given email against the policy.
+// Returns the list of violated rules, which is empty if the password is valid.
+func (p *PasswordPolicy) Validate(password, email string) []*PolicyViolation {
+	violations := []*PolicyViolation{}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violations = append(violations, &PolicyViolation{
+			Rule:    "minLength",
+			Message: fmt.Sprintf("password must be at least %d characters long", p.MinLength),
+		})
+	}
+	if length > p.MaxLength {
+		violations = append(violations, &PolicyViolation{
+			Rule:    "maxLength",
+			Message: fmt.Sprintf("password must be at most %d characters long", p.MaxLength),
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSpecial = true
+		}
+	}
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, &PolicyViolation{
+			Rule:    "requireUppercase",
+			Message: "password must contain an uppercase letter",
+		})
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, &PolicyViolation{
+			Rule:    "requireLowercase",
+			Message: "password must contain a lowercase letter",
+		})
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, &PolicyViolation{
+			Rule:    "requireDigit",
+			Message: "password must contain a digit",
+		})
+	}
+	if p.RequireSpecial && !hasSpecial {
+		violations = append(violations, &PolicyViolation{
+			Rule:    "requireSpecial",
+			Message: "password must contain a special character",
+		})
+	}
+
+	if p.ForbidEmailLocalPart && email != "" {
+		localPart := strings.ToLower(email)
+		if i := strings.LastIndex(localPart, "@"); i >= 0 {
+			localPart = localPart[:i]
+		}
+		if localPart != "" && strings.Contains(strings.ToLower(password), localPart) {
+			violations = append(violations, &PolicyViolation{
+				Rule:    "forbidEmailLocalPart",
+				Message: "password must not contain the email name",
+			})
+		}
+	}
+
+	if p.denyList[strings.ToLower(password)] {
+		violations = append(violations, &PolicyViolation{
+			Rule:    "denyList",
+			Message: "password is too common",
+		})
+	}
+
+	return violations
+}
+
+// ValidationError validates the password and returns a bad request error that lists the
+// violations in the error meta, or nil if the password satisfies the policy.
+func (p *PasswordPolicy) ValidationError(password, email string) error {
+	violations := p.Validate(password, email)
+	if len(violations) == 0 {
+		return nil
+	}
+	return goa.ErrBadRequest("password does not satisfy the password policy", "violations", violations)
+}
+
+// ReuseError returns a bad request error if the password matches the current password of the user
+// or any of the recent passwords kept in the password history. Returns nil otherwise.
+func (p *PasswordPolicy) ReuseError(password string, user *store.UserRecord, hasher PasswordHasher) error {
+	if p.HistorySize < 0 {
+		return nil
+	}
+
+	hashes := append([]string{user.Password}, user.PasswordHistory...)
+	for _, hash := range hashes {
+		if hash == "" {
+			continue
+		}
+		if hasher.Compare(hash, password) == nil {
+			return goa.ErrBadRequest("password does not satisfy the password policy", "violations", []*PolicyViolation{
+				{
+					Rule:    "history",
+					Message: fmt.Sprintf("password must not be one of the last %d passwords", p.HistorySize),
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+// NextPasswordHistory returns the password history with the new password hash added to it,
+// keeping at most HistorySize hashes.
+func (p *PasswordPolicy) NextPasswordHistory(hashedPassword string, history []string) []string {
+	if p.HistorySize < 0 {
+		return nil
+	}
+
+	next := append([]string{hashedPassword}, history...)
+	if len(next) > p.HistorySize {
+		next = next[:p.HistorySize]
+	}
+	return next
+}
+
+// loadPasswordDenyList reads the common passwords from a file, one per line.
+// Empty lines and lines starting with # are ignored.
+func loadPasswordDenyList(file string) (map[string]bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	denyList := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		denyList[strings.ToLower(line)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return denyList, nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/store"
+)
+
+func TestPasswordPolicyDefaults(t *testing.T) {
+	policy, err := NewPasswordPolicy(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if violations := policy.Validate("keitaro", "user@example.com"); len(violations) != 0 {
+		t.Errorf("Expected no violations, got %d", len(violations))
+	}
+
+	violations := policy.Validate("short", "user@example.com")
+	if len(violations) != 1 || violations[0].Rule != "minLength" {
+		t.Errorf("Expected minLength violation, got %v", violations)
+	}
+
+	// passphrases longer than 30 characters are allowed
+	if violations := policy.Validate("correct horse battery staple and more", ""); len(violations) != 0 {
+		t.Errorf("Expected no violations for passphrase, got %d", len(violations))
+	}
+}
+
+func TestPasswordPolicyCharacterClasses(t *testing.T) {
+	policy, err := NewPasswordPolicy(&config.PasswordPolicy{
+		MinLength:        8,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if violations := policy.Validate("Keitaro-2020", ""); len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+
+	rules := map[string]bool{}
+	for _, violation := range policy.Validate("keitaro", "") {
+		rules[violation.Rule] = true
+	}
+	for _, rule := range []string{"minLength", "requireUppercase", "requireDigit", "requireSpecial"} {
+		if !rules[rule] {
+			t.Errorf("Expected %s violation", rule)
+		}
+	}
+	if rules["requireLowercase"] {
+		t.Error("Unexpected requireLowercase violation")
+	}
+}
+
+func TestPasswordPolicyEmailLocalPart(t *testing.T) {
+	policy, err := NewPasswordPolicy(&config.PasswordPolicy{
+		ForbidEmailLocalPart: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations := policy.Validate("my-John.Doe-pass", "john.doe@example.com")
+	if len(violations) != 1 || violations[0].Rule != "forbidEmailLocalPart" {
+		t.Errorf("Expected forbidEmailLocalPart violation, got %v", violations)
+	}
+}
+
+func TestPasswordPolicyDenyList(t *testing.T) {
+	f, err := ioutil.TempFile("", "deny-list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("# common passwords\n\npassword\nqwerty123\n")
+	f.Close()
+
+	policy, err := NewPasswordPolicy(&config.PasswordPolicy{
+		DenyListFile: f.Name(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations := policy.Validate("QWERTY123", "")
+	if len(violations) != 1 || violations[0].Rule != "denyList" {
+		t.Errorf("Expected denyList violation, got %v", violations)
+	}
+
+	if _, err := NewPasswordPolicy(&config.PasswordPolicy{DenyListFile: "/no/such/file"}); err == nil {
+		t.Error("Expected error for missing deny-list file")
+	}
+}
+
+func TestPasswordPolicyValidationError(t *testing.T) {
+	policy, _ := NewPasswordPolicy(nil)
+
+	if err := policy.ValidationError("keitaro", ""); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if err := policy.ValidationError("pass", ""); err == nil {
+		t.Error("Expected validation error")
+	}
+}
+
+func TestPasswordPolicyHistory(t *testing.T) {
+	policy, err := NewPasswordPolicy(&config.PasswordPolicy{
+		HistorySize: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &store.UserRecord{}
+	for _, password := range []string{"password-1", "password-2", "password-3"} {
+		hashedPassword, err := stringToBcryptHash(password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		user.Password = hashedPassword
+		user.PasswordHistory = policy.NextPasswordHistory(hashedPassword, user.PasswordHistory)
+	}
+
+	if len(user.PasswordHistory) != 2 {
+		t.Fatalf("Expected 2 hashes in the history, got %d", len(user.PasswordHistory))
+	}
+	if err := policy.ReuseError("password-3", user, passwordHashing); err == nil {
+		t.Error("Expected the current password to be rejected")
+	}
+	if err := policy.ReuseError("password-2", user, passwordHashing); err == nil {
+		t.Error("Expected the recent password to be rejected")
+	}
+	if err := policy.ReuseError("password-1", user, passwordHashing); err != nil {
+		t.Errorf("Expected the old password to be allowed, got %s", err)
+	}
+
+	disabled, _ := NewPasswordPolicy(&config.PasswordPolicy{HistorySize: -1})
+	if err := disabled.ReuseError("password-3", user, passwordHashing); err != nil {
+		t.Errorf("Expected no error when the history is disabled, got %s", err)
+	}
+}
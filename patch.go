@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// mergePatchMediaType is the media type of JSON merge patches (RFC 7396).
+const mergePatchMediaType = "application/merge-patch+json"
+
+// errUnsupportedMediaType is returned when the request body is not in a supported media type.
+var errUnsupportedMediaType = goa.NewErrorClass("unsupported_media_type", 415)
+
+// isMergePatch checks the Content-Type of a patch request. A request without Content-Type is taken as a
+// merge patch.
+func isMergePatch(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == mergePatchMediaType
+}
+
+// mergePatchChanges converts a JSON merge patch of the user into user changes, with the field rules of
+// UpdateUserPayload. A null value clears the field; the fields that cannot be cleared reject null.
+func mergePatchChanges(patch map[string]interface{}) (*userChanges, error) {
+	changes := &userChanges{}
+	for field, value := range patch {
+		attribute := "patch." + field
+		switch field {
+		case "active":
+			active, ok := value.(bool)
+			if !ok {
+				return nil, goa.InvalidAttributeTypeError(attribute, value, "boolean")
+			}
+			changes.Active = &active
+		case "email":
+			email, ok := value.(string)
+			if !ok {
+				return nil, goa.InvalidAttributeTypeError(attribute, value, "string")
+			}
+			if err := goa.ValidateFormat(goa.FormatEmail, email); err != nil {
+				return nil, goa.InvalidFormatError(attribute, email, goa.FormatEmail, err)
+			}
+			changes.Email = &email
+		case "password":
+			password, ok := value.(string)
+			if !ok {
+				return nil, goa.InvalidAttributeTypeError(attribute, value, "string")
+			}
+			changes.Password = &password
+		case "externalId":
+			externalID := ""
+			if value != nil {
+				s, ok := value.(string)
+				if !ok {
+					return nil, goa.InvalidAttributeTypeError(attribute, value, "string")
+				}
+				externalID = s
+			}
+			changes.ExternalID = &externalID
+		case "roles", "organizations", "namespaces":
+			values, err := patchStrings(attribute, value)
+			if err != nil {
+				return nil, err
+			}
+			switch field {
+			case "roles":
+				changes.Roles = values
+			case "organizations":
+				changes.Organizations = values
+			default:
+				changes.Namespaces = values
+			}
+		case "profile":
+			if value == nil {
+				changes.Profile = map[string]interface{}{}
+				continue
+			}
+			profile, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, goa.InvalidAttributeTypeError(attribute, value, "object")
+			}
+			changes.ProfilePatch = profile
+		case "token":
+			// Accepted for compatibility with UpdateUserPayload, but not used - as in Update.
+			if _, ok := value.(string); value != nil && !ok {
+				return nil, goa.InvalidAttributeTypeError(attribute, value, "string")
+			}
+		default:
+			return nil, goa.ErrBadRequest(fmt.Sprintf("unknown field %s", field))
+		}
+	}
+	return changes, nil
+}
+
+// patchStrings converts a patch value to a list of strings. Null clears the list.
+func patchStrings(attribute string, value interface{}) ([]string, error) {
+	if value == nil {
+		return []string{}, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, goa.InvalidAttributeTypeError(attribute, value, "array")
+	}
+	values := []string{}
+	for i, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, goa.InvalidAttributeTypeError(fmt.Sprintf("%s[%d]", attribute, i), item, "string")
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+// Patch runs the patch action. Unlike Update, only the fields present in the merge patch are changed.
+func (c *UserController) Patch(ctx *app.PatchUserContext) error {
+	if !isMergePatch(ctx.Request.Header.Get("Content-Type")) {
+		return ctx.UnsupportedMediaType(errUnsupportedMediaType("the patch must be a JSON merge patch", "contentType", mergePatchMediaType))
+	}
+
+	changes, err := mergePatchChanges(ctx.Payload)
+	if err != nil {
+		return ctx.BadRequest(err)
+	}
+
+	user := &store.UserRecord{}
+	if _, err = c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	if ctx.IfMatch != nil && !matchesETag(*ctx.IfMatch, user) {
+		return ctx.PreconditionFailed(errPreconditionFailed("the user has been changed", "etag", userETag(user)))
+	}
+
+	update, err := c.userUpdate(ctx, user, changes)
+	if err != nil {
+		switch errorStatus(err) {
+		case http.StatusBadRequest:
+			return ctx.BadRequest(err)
+		case http.StatusForbidden:
+			return ctx.Forbidden(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	updated, err := c.saveUser(user, update)
+	if err != nil {
+		if err == errVersionConflict {
+			return ctx.PreconditionFailed(errPreconditionFailed(err))
+		}
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	ctx.ResponseData.Header().Set("ETag", userETag(updated))
+	return ctx.OK(c.userMedia(ctx, updated))
+}
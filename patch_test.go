@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestIsMergePatch(t *testing.T) {
+	for contentType, expected := range map[string]bool{
+		"":                             true,
+		"application/merge-patch+json": true,
+		"application/merge-patch+json; charset=utf-8": true,
+		"application/json":            false,
+		"application/json-patch+json": false,
+		"text/plain; charset":         false,
+	} {
+		if isMergePatch(contentType) != expected {
+			t.Errorf("Expected %v for Content-Type %q", expected, contentType)
+		}
+	}
+}
+
+func TestMergePatchChanges(t *testing.T) {
+	changes, err := mergePatchChanges(map[string]interface{}{
+		"active":     false,
+		"externalId": nil,
+		"namespaces": []interface{}{"ns1"},
+		"token":      nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes.Active == nil || *changes.Active {
+		t.Errorf("Expected active to be false, got %v", changes.Active)
+	}
+	if changes.ExternalID == nil || *changes.ExternalID != "" {
+		t.Errorf("Expected externalId to be cleared, got %v", changes.ExternalID)
+	}
+	if len(changes.Namespaces) != 1 || changes.Roles != nil || changes.Password != nil {
+		t.Errorf("Expected only the namespaces to be set, got %v %v %v", changes.Namespaces, changes.Roles, changes.Password)
+	}
+
+	for _, patch := range []map[string]interface{}{
+		{"email": nil},
+		{"email": "not-an-email"},
+		{"password": nil},
+		{"organizations": []interface{}{"org", 1}},
+		{"createdAt": 0},
+	} {
+		if _, err = mergePatchChanges(patch); err == nil || errorStatus(err) != http.StatusBadRequest {
+			t.Errorf("Expected a bad request error for %v, got %v", patch, err)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// validateProfile validates the profile attributes against the profile schema. The errors of invalid
+// attributes are bad request errors.
+func validateProfile(schema map[string]config.ProfileAttribute, profile map[string]interface{}) error {
+	names := []string{}
+	for name := range profile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attribute, ok := schema[name]
+		if !ok {
+			return goa.ErrBadRequest(fmt.Sprintf("unknown profile attribute %s", name))
+		}
+		if err := validateProfileValue("profile."+name, attribute, profile[name]); err != nil {
+			return err
+		}
+	}
+
+	names = []string{}
+	for name, attribute := range schema {
+		if attribute.Required && profile[name] == nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return goa.MissingAttributeError("profile", names[0])
+	}
+	return nil
+}
+
+// validateProfileValue validates the value of a profile attribute.
+func validateProfileValue(name string, attribute config.ProfileAttribute, value interface{}) error {
+	switch attribute.Type {
+	case config.ProfileTypeNumber:
+		if _, ok := profileNumber(value); !ok {
+			return goa.InvalidAttributeTypeError(name, value, "number")
+		}
+	case config.ProfileTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return goa.InvalidAttributeTypeError(name, value, "boolean")
+		}
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return goa.InvalidAttributeTypeError(name, value, "string")
+		}
+		if attribute.MaxLength > 0 && utf8.RuneCountInString(s) > attribute.MaxLength {
+			return goa.InvalidLengthError(name, s, utf8.RuneCountInString(s), attribute.MaxLength, false)
+		}
+		if attribute.Pattern != "" {
+			pattern, err := regexp.Compile(attribute.Pattern)
+			if err != nil {
+				return err
+			}
+			if !pattern.MatchString(s) {
+				return goa.InvalidPatternError(name, s, attribute.Pattern)
+			}
+		}
+	}
+
+	if len(attribute.Enum) > 0 {
+		for _, allowed := range attribute.Enum {
+			if sameJSON(value, allowed) {
+				return nil
+			}
+		}
+		return goa.InvalidEnumValueError(name, value, attribute.Enum)
+	}
+	return nil
+}
+
+// profileNumber returns the numeric value of a profile attribute.
+func profileNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// mergeProfile applies a JSON merge patch to the profile. Null values remove the attributes.
+func mergeProfile(profile, patch map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for name, value := range profile {
+		merged[name] = value
+	}
+	for name, value := range patch {
+		if value == nil {
+			delete(merged, name)
+			continue
+		}
+		merged[name] = value
+	}
+	return merged
+}
+
+// changedProfileAttributes returns the fields ("profile.<name>") of the profile attributes that differ
+// between the two profiles.
+func changedProfileAttributes(current, profile map[string]interface{}) []string {
+	fields := []string{}
+	for name, value := range profile {
+		if !sameJSON(current[name], value) {
+			fields = append(fields, "profile."+name)
+		}
+	}
+	for name := range current {
+		if _, ok := profile[name]; !ok {
+			fields = append(fields, "profile."+name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// visibleProfile returns the profile attributes of the user that the caller can see: all of them for admin
+// and system users and for internal requests, the public and self attributes for the user and the public
+// attributes for everyone else.
+func (c *UserController) visibleProfile(ctx context.Context, user *store.UserRecord) map[string]interface{} {
+	authObj := auth.GetAuth(ctx)
+	if authObj == nil || hasAnyRole(authObj, "admin", "system") {
+		return user.Profile
+	}
+
+	schema := c.Config.GetProfile()
+	self := authObj.UserID == user.ID.Hex()
+	visible := map[string]interface{}{}
+	for name, value := range user.Profile {
+		attribute, ok := schema[name]
+		if !ok {
+			continue
+		}
+		if attribute.Visibility == config.VisibilityPublic || (self && attribute.Visibility == config.VisibilitySelf) {
+			visible[name] = value
+		}
+	}
+	return visible
+}
+
+// userMedia returns the user media with the profile attributes that the caller can see.
+func (c *UserController) userMedia(ctx context.Context, user *store.UserRecord) *app.Users {
+	media := user.ToAppUsers()
+	if profile := c.visibleProfile(ctx, user); len(profile) > 0 {
+		media.Profile = profile
+	}
+	return media
+}
+
+// profileFilterAttribute returns the profile attribute to filter by. Admin and system users can filter by any
+// attribute, everyone else only by the public attributes.
+func (c *UserController) profileFilterAttribute(ctx context.Context, name string) (config.ProfileAttribute, error) {
+	attribute, ok := c.Config.GetProfile()[name]
+	if !ok {
+		return attribute, goa.ErrBadRequest(fmt.Sprintf("unknown profile attribute %s", name))
+	}
+	if attribute.Visibility != config.VisibilityPublic && !hasAnyRole(auth.GetAuth(ctx), "admin", "system") {
+		return attribute, goa.ErrBadRequest(fmt.Sprintf("cannot filter by profile attribute %s", name))
+	}
+	return attribute, nil
+}
+
+// profileFilterValue converts the value of a profile attribute filter to the type of the attribute.
+func (c *UserController) profileFilterValue(ctx context.Context, name, value string) (interface{}, error) {
+	attribute, err := c.profileFilterAttribute(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch attribute.Type {
+	case config.ProfileTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, goa.InvalidAttributeTypeError("profile."+name, value, "number")
+		}
+		return number, nil
+	case config.ProfileTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, goa.InvalidAttributeTypeError("profile."+name, value, "boolean")
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+// profileAttributeName returns the name of the profile attribute of a "profile.<name>" field, or "" for other
+// fields.
+func profileAttributeName(field string) string {
+	if !strings.HasPrefix(field, "profile.") {
+		return ""
+	}
+	return strings.TrimPrefix(field, "profile.")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/Microkubes/microservice-user/config"
+)
+
+func TestValidateProfile(t *testing.T) {
+	schema := (&config.ServiceConfig{
+		Profile: map[string]config.ProfileAttribute{
+			"firstName": {Required: true, MaxLength: 5},
+			"phone":     {Pattern: `^\+?[0-9]+$`},
+			"age":       {Type: config.ProfileTypeNumber},
+			"tier":      {Enum: []interface{}{"gold", "silver"}},
+			"newsletter": {
+				Type: config.ProfileTypeBoolean,
+			},
+		},
+	}).GetProfile()
+
+	valid := map[string]interface{}{
+		"firstName":  "Ana",
+		"phone":      "+38970",
+		"age":        float64(30),
+		"tier":       "gold",
+		"newsletter": true,
+	}
+	if err := validateProfile(schema, valid); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, profile := range []map[string]interface{}{
+		{},
+		{"firstName": "Anastasia"},
+		{"firstName": "Ana", "phone": "phone"},
+		{"firstName": "Ana", "age": "30"},
+		{"firstName": "Ana", "tier": "bronze"},
+		{"firstName": "Ana", "newsletter": "yes"},
+		{"firstName": "Ana", "nickname": "an"},
+	} {
+		if err := validateProfile(schema, profile); err == nil || errorStatus(err) != http.StatusBadRequest {
+			t.Errorf("Expected a bad request error for %v, got %v", profile, err)
+		}
+	}
+}
+
+func TestMergeProfile(t *testing.T) {
+	profile := map[string]interface{}{"firstName": "Ana", "phone": "+38970"}
+	merged := mergeProfile(profile, map[string]interface{}{"phone": nil, "lastName": "Petrova"})
+
+	if !reflect.DeepEqual(merged, map[string]interface{}{"firstName": "Ana", "lastName": "Petrova"}) {
+		t.Errorf("Unexpected merged profile %v", merged)
+	}
+	if len(profile) != 2 {
+		t.Errorf("Expected the profile not to be modified, got %v", profile)
+	}
+	if fields := changedProfileAttributes(profile, merged); !reflect.DeepEqual(fields, []string{"profile.lastName", "profile.phone"}) {
+		t.Errorf("Unexpected changed attributes %v", fields)
+	}
+}
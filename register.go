@@ -0,0 +1,176 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/helpers"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// emailDomain returns the domain part of the email address, in lower case.
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:]))
+}
+
+// matchesDomain returns true if the domain is one of the given domains or a subdomain of one of them.
+func matchesDomain(domain string, domains []string) bool {
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d == "" {
+			continue
+		}
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// emailDomainAllowed checks the domain of the email against the allowed and denied domains of the
+// registration configuration. The denied domains take precedence.
+func emailDomainAllowed(email string, registration config.Registration) bool {
+	domain := emailDomain(email)
+	if domain == "" || matchesDomain(domain, registration.DeniedDomains) {
+		return false
+	}
+	if len(registration.AllowedDomains) > 0 {
+		return matchesDomain(domain, registration.AllowedDomains)
+	}
+	return true
+}
+
+// Register runs the register action. The registered user always gets only the user role and stays
+// inactive until the email is verified. In the invite-only mode, the invitation for the email is accepted
+// instead, and the user is created with the invited roles and memberships as in AcceptInvitation.
+func (c *UserController) Register(ctx *app.RegisterUserContext) error {
+	registration := c.Config.GetRegistration()
+	displayEmail := strings.TrimSpace(ctx.Payload.Email)
+	ctx.Payload.Email = normalizeEmail(ctx.Payload.Email)
+
+	var invitation *store.InvitationRecord
+	switch registration.Mode {
+	case config.RegistrationOpen:
+		if !emailDomainAllowed(ctx.Payload.Email, registration) {
+			return ctx.Forbidden(errForbidden("email domain is not allowed"))
+		}
+	case config.RegistrationInviteOnly:
+		if ctx.Payload.InvitationToken == nil {
+			return ctx.Forbidden(errForbidden("registration requires an invitation"))
+		}
+		var err error
+		if invitation, err = c.invitationByToken(*ctx.Payload.InvitationToken); err != nil {
+			if backends.IsErrNotFound(err) {
+				return ctx.Forbidden(errForbidden("invalid invitation"))
+			}
+			if errorStatus(err) == http.StatusBadRequest {
+				return ctx.BadRequest(err)
+			}
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+		if invitation.Email != ctx.Payload.Email {
+			return ctx.Forbidden(errForbidden("the invitation is for another email"))
+		}
+	default:
+		return ctx.Forbidden(errForbidden("registration is closed"))
+	}
+
+	if err := validateProfile(c.Config.GetProfile(), ctx.Payload.Profile); err != nil {
+		return ctx.BadRequest(err)
+	}
+
+	if invitation != nil {
+		user, err := c.createInvitedUser(invitation, ctx.Payload.Password, ctx.Payload.Profile)
+		if err != nil {
+			if errorStatus(err) == http.StatusBadRequest {
+				return ctx.BadRequest(err)
+			}
+			if backends.IsErrAlreadyExists(err) || backends.IsErrInvalidInput(err) {
+				return ctx.BadRequest(goa.ErrBadRequest(err))
+			}
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+		return ctx.Created(c.userMedia(ctx, user))
+	}
+
+	if err := c.PasswordPolicy.ValidationError(ctx.Payload.Password, ctx.Payload.Email); err != nil {
+		return ctx.BadRequest(err)
+	}
+
+	hashedPassword, err := c.Passwords.Hash(ctx.Payload.Password)
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	user := &store.UserRecord{
+		Active:          false,
+		Status:          store.StatusPendingVerification,
+		Email:           ctx.Payload.Email,
+		DisplayEmail:    displayEmail,
+		Password:        hashedPassword,
+		PasswordHistory: c.PasswordPolicy.NextPasswordHistory(hashedPassword, nil),
+		Roles:           []string{"user"},
+		Profile:         ctx.Payload.Profile,
+		CreatedAt:       helpers.CurrentTimeMilliseconds(),
+		Version:         1,
+	}
+
+	existing := &store.UserRecord{}
+	if _, err = c.Store.Users.GetOne(backends.NewFilter().Match("email", ctx.Payload.Email), existing); err == nil {
+		return c.registerExisting(ctx, user)
+	} else if !backends.IsErrNotFound(err) {
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	result, err := c.Store.Users.Save(user, nil)
+	if err != nil {
+		if backends.IsErrAlreadyExists(err) {
+			return c.registerExisting(ctx, user)
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	token := generateToken(42)
+	if _, err = c.saveVerificationToken(ctx.Payload.Email, token); err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	created := result.(*store.UserRecord)
+
+	// As in Create, a new verification email can be requested with ResetVerificationToken.
+	if err = c.sendVerificationEmail(created.ID.Hex(), ctx.Payload.Email, token); err != nil {
+		c.Service.LogError("User: failed to send verification email.", "err", err.Error())
+	}
+
+	return ctx.Created(c.userMedia(ctx, created))
+}
+
+// registerExisting responds to the registration of an email that already has a user as to a successful
+// registration, so that the registration cannot be used to look up emails. The user is not saved, the owner
+// of the email is notified of the attempt instead.
+func (c *UserController) registerExisting(ctx *app.RegisterUserContext, user *store.UserRecord) error {
+	err := c.sendEmail(user.Email, "registrationAttempt", map[string]string{
+		"email": user.Email,
+	})
+	if err != nil {
+		c.Service.LogError("User: failed to send registration attempt notification.", "err", err.Error())
+	}
+
+	user.ID = bson.NewObjectId()
+	return ctx.Created(c.userMedia(ctx, user))
+}
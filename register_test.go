@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Microkubes/microservice-user/config"
+)
+
+func TestEmailDomainAllowed(t *testing.T) {
+	registration := config.Registration{
+		AllowedDomains: []string{"example.com", "@Keitaro.com"},
+		DeniedDomains:  []string{"blocked.example.com"},
+	}
+
+	for email, expected := range map[string]bool{
+		"user@example.com":         true,
+		"user@EXAMPLE.com":         true,
+		"user@dev.example.com":     true,
+		"user@keitaro.com":         true,
+		"user@blocked.example.com": false,
+		"user@notexample.com":      false,
+		"user@gmail.com":           false,
+		"no-domain":                false,
+	} {
+		if allowed := emailDomainAllowed(email, registration); allowed != expected {
+			t.Errorf("%s: expected %v, got %v", email, expected, allowed)
+		}
+	}
+
+	if !emailDomainAllowed("user@gmail.com", config.Registration{DeniedDomains: []string{"example.com"}}) {
+		t.Error("Expected any domain not denied to be allowed when no allowed domains are set")
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// selfEditableFields are the fields that users can change on their own account.
+var selfEditableFields = []string{"email", "password", "currentPassword", "profile"}
+
+// selfChanges holds the changes of the authenticated user to their own account. The nil fields are left as
+// they are.
+type selfChanges struct {
+	Email           *string
+	Password        *string
+	CurrentPassword *string
+	// Profile replaces the profile of the user
+	Profile map[string]interface{}
+	// ProfilePatch is merged into the profile of the user
+	ProfilePatch map[string]interface{}
+}
+
+// selfPatchChanges converts a JSON merge patch of the authenticated user into self changes. The fields that
+// users cannot change on their own give a forbidden error listing the fields.
+func selfPatchChanges(patch map[string]interface{}) (*selfChanges, error) {
+	changes := &selfChanges{}
+	rejected := []string{}
+	for field, value := range patch {
+		if !containsString(selfEditableFields, field) {
+			rejected = append(rejected, field)
+			continue
+		}
+		attribute := "patch." + field
+		if field == "profile" {
+			if value == nil {
+				changes.Profile = map[string]interface{}{}
+				continue
+			}
+			profile, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, goa.InvalidAttributeTypeError(attribute, value, "object")
+			}
+			changes.ProfilePatch = profile
+			continue
+		}
+		s, ok := value.(string)
+		if !ok {
+			return nil, goa.InvalidAttributeTypeError(attribute, value, "string")
+		}
+		switch field {
+		case "email":
+			if err := goa.ValidateFormat(goa.FormatEmail, s); err != nil {
+				return nil, goa.InvalidFormatError(attribute, s, goa.FormatEmail, err)
+			}
+			changes.Email = &s
+		case "password":
+			changes.Password = &s
+		case "currentPassword":
+			changes.CurrentPassword = &s
+		}
+	}
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		return nil, errForbidden("not allowed to change the fields", "fields", rejected)
+	}
+	return changes, nil
+}
+
+// selfUser returns the authenticated user. Deleted users are not found.
+func (c *UserController) selfUser(ctx context.Context) (*store.UserRecord, error) {
+	if !auth.HasAuth(ctx) {
+		return nil, goa.ErrBadRequest("no-auth")
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", auth.GetAuth(ctx).UserID), user); err != nil {
+		return nil, err
+	}
+	if user.IsDeleted() {
+		return nil, backends.ErrNotFound("not found")
+	}
+	return user, nil
+}
+
+// updateSelf applies the changes of the user to their own account. Changing the email or the password needs
+// the current password. The profile is subject to the field policy as in Update, the email change waits for
+// confirmation as in RequestEmailChange and the password is changed as in ChangePassword. Every change is
+// checked before the user is saved, so that the account is changed either as a whole or not at all.
+func (c *UserController) updateSelf(ctx context.Context, user *store.UserRecord, changes *selfChanges) (*store.UserRecord, error) {
+	changeEmail := changes.Email != nil && normalizeEmail(*changes.Email) != user.Email
+	changePassword := changes.Password != nil && *changes.Password != ""
+	if changeEmail || changePassword {
+		currentPassword := ""
+		if changes.CurrentPassword != nil {
+			currentPassword = *changes.CurrentPassword
+		}
+		if err := c.checkCurrentPassword(user, currentPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	update := map[string]interface{}{}
+	if changes.Profile != nil || changes.ProfilePatch != nil {
+		profileUpdate, err := c.userUpdate(ctx, user, &userChanges{
+			Profile:      changes.Profile,
+			ProfilePatch: changes.ProfilePatch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		mergeUpdate(update, profileUpdate)
+	}
+
+	token := ""
+	if changeEmail {
+		emailUpdate, emailToken, err := c.emailChangeUpdate(user, *changes.Email)
+		if err != nil {
+			return nil, err
+		}
+		mergeUpdate(update, emailUpdate)
+		token = emailToken
+	}
+	if changePassword {
+		passwordUpdate, err := c.passwordUpdate(user, *changes.Password)
+		if err != nil {
+			return nil, err
+		}
+		mergeUpdate(update, passwordUpdate)
+	}
+
+	if len(update) == 0 {
+		return user, nil
+	}
+	updated, err := c.saveUser(user, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if changeEmail {
+		if err = c.sendEmailChange(user, *changes.Email, token); err != nil {
+			return nil, err
+		}
+	}
+	if changePassword {
+		c.notifyPasswordChanged(user)
+	}
+	return updated, nil
+}
+
+// mergeUpdate adds the fields of the given update to the update to save.
+func mergeUpdate(update, fields map[string]interface{}) {
+	for key, value := range fields {
+		update[key] = value
+	}
+}
+
+// UpdateMe runs the updateMe action.
+func (c *UserController) UpdateMe(ctx *app.UpdateMeUserContext) error {
+	user, err := c.selfUser(ctx)
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if ctx.IfMatch != nil && !matchesETag(*ctx.IfMatch, user) {
+		return ctx.PreconditionFailed(errPreconditionFailed("the user has been changed", "etag", userETag(user)))
+	}
+
+	updated, err := c.updateSelf(ctx, user, &selfChanges{
+		Email:           ctx.Payload.Email,
+		Password:        ctx.Payload.Password,
+		CurrentPassword: ctx.Payload.CurrentPassword,
+		Profile:         ctx.Payload.Profile,
+	})
+	if err != nil {
+		if err == errVersionConflict {
+			return ctx.PreconditionFailed(errPreconditionFailed(err))
+		}
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		switch errorStatus(err) {
+		case http.StatusBadRequest:
+			return ctx.BadRequest(err)
+		case http.StatusForbidden:
+			return ctx.Forbidden(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	ctx.ResponseData.Header().Set("ETag", userETag(updated))
+	return ctx.OK(c.userMedia(ctx, updated))
+}
+
+// PatchMe runs the patchMe action.
+func (c *UserController) PatchMe(ctx *app.PatchMeUserContext) error {
+	if !isMergePatch(ctx.Request.Header.Get("Content-Type")) {
+		return ctx.UnsupportedMediaType(errUnsupportedMediaType("the patch must be a JSON merge patch", "contentType", mergePatchMediaType))
+	}
+
+	changes, err := selfPatchChanges(ctx.Payload)
+	if err != nil {
+		if errorStatus(err) == http.StatusForbidden {
+			return ctx.Forbidden(err)
+		}
+		return ctx.BadRequest(err)
+	}
+
+	user, err := c.selfUser(ctx)
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if ctx.IfMatch != nil && !matchesETag(*ctx.IfMatch, user) {
+		return ctx.PreconditionFailed(errPreconditionFailed("the user has been changed", "etag", userETag(user)))
+	}
+
+	updated, err := c.updateSelf(ctx, user, changes)
+	if err != nil {
+		if err == errVersionConflict {
+			return ctx.PreconditionFailed(errPreconditionFailed(err))
+		}
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		switch errorStatus(err) {
+		case http.StatusBadRequest:
+			return ctx.BadRequest(err)
+		case http.StatusForbidden:
+			return ctx.Forbidden(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	ctx.ResponseData.Header().Set("ETag", userETag(updated))
+	return ctx.OK(c.userMedia(ctx, updated))
+}
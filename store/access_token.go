@@ -0,0 +1,51 @@
+package store
+
+import (
+	"github.com/Microkubes/microservice-user/app"
+)
+
+// AccessTokenRecord is a personal access token of a user. Only the hash of the token is stored.
+type AccessTokenRecord struct {
+	ID string `json:"id,omitempty" bson:"_id,omitempty"`
+	// ID of the user owning the token
+	UserID string `json:"userId" bson:"userId"`
+	// Name of the token
+	Name string `json:"name" bson:"name"`
+	// First characters of the token, for identification
+	Prefix string `json:"prefix" bson:"prefix"`
+	// SHA-256 hash of the token. Never exposed outside the service.
+	Hash string `json:"hash" bson:"hash"`
+	// Scopes of the token
+	Scopes []string `json:"scopes,omitempty" bson:"scopes"`
+	// Time of creating
+	CreatedAt int64 `json:"createdAt" bson:"createdAt"`
+	// Expiry time, 0 if the token does not expire
+	ExpiresAt int64 `json:"expiresAt,omitempty" bson:"expiresAt"`
+	// Time of the last use
+	LastUsedAt int64 `json:"lastUsedAt,omitempty" bson:"lastUsedAt"`
+}
+
+// IsExpired checks whether the token has expired at the given time.
+func (t *AccessTokenRecord) IsExpired(now int64) bool {
+	return t.ExpiresAt != 0 && now >= t.ExpiresAt
+}
+
+// ToAppAccessToken converts the access token record to the AccessToken media type.
+func (t *AccessTokenRecord) ToAppAccessToken() *app.AccessToken {
+	token := &app.AccessToken{
+		ID:        t.ID,
+		Name:      t.Name,
+		Prefix:    t.Prefix,
+		Scopes:    t.Scopes,
+		CreatedAt: int(t.CreatedAt),
+	}
+	if t.ExpiresAt != 0 {
+		expiresAt := int(t.ExpiresAt)
+		token.ExpiresAt = &expiresAt
+	}
+	if t.LastUsedAt != 0 {
+		lastUsedAt := int(t.LastUsedAt)
+		token.LastUsedAt = &lastUsedAt
+	}
+	return token
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"github.com/Microkubes/microservice-user/app"
+)
+
+// InvitationRecord is an invitation for a user to join with the given roles and memberships. Only the
+// hash of the invitation token is stored.
+type InvitationRecord struct {
+	ID string `json:"id,omitempty" bson:"_id,omitempty"`
+	// Email of the invitee, normalized
+	Email string `json:"email" bson:"email"`
+	// Email of the invitee as entered
+	DisplayEmail string `json:"displayEmail,omitempty" bson:"displayEmail"`
+	// Roles of the invited user
+	Roles []string `json:"roles,omitempty" bson:"roles"`
+	// Organizations of the invited user
+	Organizations []string `json:"organizations,omitempty" bson:"organizations"`
+	// Namespaces of the invited user
+	Namespaces []string `json:"namespaces,omitempty" bson:"namespaces"`
+	// Hash of the invitation token. Never exposed outside the service.
+	Token string `json:"token" bson:"token"`
+	// ID of the user that sent the invitation
+	InvitedBy string `json:"invitedBy,omitempty" bson:"invitedBy"`
+	// Time of creating
+	CreatedAt int64 `json:"createdAt" bson:"createdAt"`
+	// Expiry time
+	ExpiresAt int64 `json:"expiresAt" bson:"expiresAt"`
+}
+
+// IsExpired checks whether the invitation has expired at the given time.
+func (i *InvitationRecord) IsExpired(now int64) bool {
+	return now >= i.ExpiresAt
+}
+
+// ToAppInvitation converts the invitation record to the Invitation media type.
+func (i *InvitationRecord) ToAppInvitation() *app.Invitation {
+	invitation := &app.Invitation{
+		ID:            i.ID,
+		Email:         i.Email,
+		Roles:         i.Roles,
+		Organizations: i.Organizations,
+		Namespaces:    i.Namespaces,
+		CreatedAt:     int(i.CreatedAt),
+		ExpiresAt:     int(i.ExpiresAt),
+	}
+	if invitation.Roles == nil {
+		invitation.Roles = []string{}
+	}
+	if i.InvitedBy != "" {
+		invitedBy := i.InvitedBy
+		invitation.InvitedBy = &invitedBy
+	}
+	return invitation
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"github.com/Microkubes/microservice-user/app"
+)
+
+const (
+	// LoginSuccess is the outcome of a successful login.
+	LoginSuccess = "success"
+	// LoginFailure is the outcome of a login with invalid credentials.
+	LoginFailure = "failure"
+	// LoginLocked is the outcome of a login attempt on a locked account.
+	LoginLocked = "locked"
+)
+
+// LoginRecord is a single entry in the login history of a user.
+type LoginRecord struct {
+	ID string `json:"id,omitempty" bson:"_id,omitempty"`
+	// ID of the user
+	UserID string `json:"userId" bson:"userId"`
+	// Outcome of the login: success, failure or locked
+	Outcome string `json:"outcome" bson:"outcome"`
+	// IP address of the client
+	IP string `json:"ip,omitempty" bson:"ip"`
+	// User agent of the client
+	UserAgent string `json:"userAgent,omitempty" bson:"userAgent"`
+	// Time of the login
+	CreatedAt int64 `json:"createdAt" bson:"createdAt"`
+}
+
+// ToAppLogin converts the login record to the Login media type.
+func (l *LoginRecord) ToAppLogin() *app.Login {
+	login := &app.Login{
+		ID:        l.ID,
+		UserID:    l.UserID,
+		Outcome:   l.Outcome,
+		CreatedAt: int(l.CreatedAt),
+	}
+	if l.IP != "" {
+		login.IP = &l.IP
+	}
+	if l.UserAgent != "" {
+		login.UserAgent = &l.UserAgent
+	}
+	return login
+}
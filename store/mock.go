@@ -1,6 +1,10 @@
 package store
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/Microkubes/backends"
@@ -33,6 +37,54 @@ func NewDB() User {
 				"externalID": "some-id",
 				"roles":      []string{"user"},
 			},
+			"5df2103b5f1b640001142d3e": map[string]interface{}{
+				"id":         "5df2103b5f1b640001142d3e",
+				"email":      "keitaro-user3@gmail.com",
+				"password":   "keitaro",
+				"externalId": "some-id",
+				"roles":      []string{"user"},
+				"active":     true,
+			},
+			"5df2103b5f1b640001142d40": map[string]interface{}{
+				"id":         "5df2103b5f1b640001142d40",
+				"email":      "keitaro-user5@gmail.com",
+				"password":   "$2a$04$QehmCq6/bc7oQq9GOlh0L.T6PQ.g4GNs.aB42gwrb7z6vninI8xFC", // keitaro
+				"externalId": "some-id",
+				"roles":      []string{"user"},
+				"active":     true,
+			},
+			"5df2103b5f1b640001142d41": map[string]interface{}{
+				"id":         "5df2103b5f1b640001142d41",
+				"email":      "keitaro-user6@gmail.com",
+				"password":   "$2a$04$QehmCq6/bc7oQq9GOlh0L.T6PQ.g4GNs.aB42gwrb7z6vninI8xFC", // keitaro
+				"externalId": "some-id",
+				"roles":      []string{"user"},
+				"active":     true,
+			},
+			"5df2103b5f1b640001142d42": map[string]interface{}{
+				"id":         "5df2103b5f1b640001142d42",
+				"email":      "keitaro-user7@gmail.com",
+				"password":   "$2a$04$QehmCq6/bc7oQq9GOlh0L.T6PQ.g4GNs.aB42gwrb7z6vninI8xFC", // keitaro
+				"externalId": "some-id",
+				"roles":      []string{"user"},
+				"active":     true,
+			},
+			"5df2103b5f1b640001142d43": map[string]interface{}{
+				"id":         "5df2103b5f1b640001142d43",
+				"email":      "keitaro-user8@gmail.com",
+				"password":   "$2a$04$QehmCq6/bc7oQq9GOlh0L.T6PQ.g4GNs.aB42gwrb7z6vninI8xFC", // keitaro
+				"externalId": "some-id",
+				"roles":      []string{"user"},
+				"active":     true,
+			},
+			"5df2103b5f1b640001142d3f": map[string]interface{}{
+				"id":         "5df2103b5f1b640001142d3f",
+				"email":      "keitaro-user4@gmail.com",
+				"password":   "keitaro",
+				"externalId": "some-id",
+				"roles":      []string{"user"},
+				"active":     true,
+			},
 		},
 	}
 
@@ -41,14 +93,30 @@ func NewDB() User {
 			"z8cfa84f-bb6c-4c84-b39b-76dd32653999": map[string]interface{}{
 				"id":    "z8cfa84f-bb6c-4c84-b39b-76dd32653999",
 				"email": "keitaro-user1@gmail.com",
-				"token": "sdaewefdc234erfdd123erfdxc23edx",
+				// sdaewefdc234erfdd123erfdxc23edx, hashed with an empty secret
+				"token": "hmac-sha256:7339b204a6a980168aac32532df4e188bc190a8c8a6298bc2d7bd50ec30f1a46",
 			},
 		},
 	}
 
+	logins := &DB{
+		MapStore: map[string]interface{}{},
+	}
+
+	accessTokens := &DB{
+		MapStore: map[string]interface{}{},
+	}
+
+	invitations := &DB{
+		MapStore: map[string]interface{}{},
+	}
+
 	return User{
-		Users:  users,
-		Tokens: tokens,
+		Users:        users,
+		Tokens:       tokens,
+		Logins:       logins,
+		AccessTokens: accessTokens,
+		Invitations:  invitations,
 	}
 }
 
@@ -103,15 +171,18 @@ func (db *DB) GetOne(filter backends.Filter, result interface{}) (interface{}, e
 					return nil, backends.ErrBackendError(err)
 				}
 
-				break
+				return result, nil
 			}
 		}
+
+		return nil, backends.ErrNotFound(NOT_FOUND)
 	}
 
 	if token, ok := filter["token"]; ok {
 		tokenString := token.(string)
 
-		if tokenString == "internal-error-token" {
+		// internal-error-token, hashed with an empty secret
+		if tokenString == "hmac-sha256:8c56e57a4c9d595881f65ee587733e38261d78608040ebb8f266e6fef7ec660b" {
 			return nil, backends.ErrBackendError(INTERNAL_ERROR)
 		}
 
@@ -128,22 +199,103 @@ func (db *DB) GetOne(filter backends.Filter, result interface{}) (interface{}, e
 					return nil, backends.ErrBackendError(err)
 				}
 
-				break
+				return result, nil
+			}
+		}
+
+		return nil, backends.ErrNotFound(NOT_FOUND)
+	}
+
+	if token, ok := filter["forgotPasswordTokens.token"]; ok {
+		for _, r := range db.MapStore {
+			record := r.(map[string]interface{})
+
+			fpToken := FPToken{}
+			if err := backends.MapToInterface(record["forgotPasswordTokens"], &fpToken); err == nil && fpToken.Token == token {
+				err := backends.MapToInterface(record, &result)
+				if err != nil {
+					return nil, backends.ErrBackendError(err)
+				}
+
+				return result, nil
+			}
+		}
+
+		return nil, backends.ErrNotFound(NOT_FOUND)
+	}
+
+	for _, key := range []string{"pendingEmailToken", "magicLinkToken"} {
+		token, ok := filter[key]
+		if !ok {
+			continue
+		}
+		for _, r := range db.MapStore {
+			record := r.(map[string]interface{})
+
+			if record[key] == token {
+				err := backends.MapToInterface(record, &result)
+				if err != nil {
+					return nil, backends.ErrBackendError(err)
+				}
+
+				return result, nil
+			}
+		}
+
+		return nil, backends.ErrNotFound(NOT_FOUND)
+	}
+
+	if hash, ok := filter["hash"]; ok {
+		for _, r := range db.MapStore {
+			record := r.(map[string]interface{})
+
+			if record["hash"] == hash {
+				err := backends.MapToInterface(record, &result)
+				if err != nil {
+					return nil, backends.ErrBackendError(err)
+				}
+
+				return result, nil
 			}
 		}
+
+		return nil, backends.ErrNotFound(NOT_FOUND)
 	}
 
 	return result, nil
 }
 
 func (db *DB) GetAll(filter backends.Filter, results interface{}, order string, sorting string, limit int, offset int) (interface{}, error) {
+	db.Lock()
+	defer db.Unlock()
+
 	if offset > 2 {
 		return nil, backends.ErrNotFound("offset is too high")
 	}
 
 	var users []map[string]interface{}
 	for _, v := range db.MapStore {
-		users = append(users, v.(map[string]interface{}))
+		record := v.(map[string]interface{})
+		if matchesFilter(record, filter) {
+			users = append(users, record)
+		}
+	}
+
+	if order != "" {
+		sort.SliceStable(users, func(i, j int) bool {
+			if sorting == "desc" {
+				i, j = j, i
+			}
+			return lessValue(users[i][order], users[j][order])
+		})
+	}
+
+	if offset > len(users) {
+		offset = len(users)
+	}
+	users = users[offset:]
+	if limit > 0 && limit < len(users) {
+		users = users[:limit]
 	}
 
 	if len(users) == 0 {
@@ -153,6 +305,57 @@ func (db *DB) GetAll(filter backends.Filter, results interface{}, order string,
 	return users, nil
 }
 
+// matchesVersion checks the version of a record against a version filter: a version, or a MongoDB "$in"
+// of versions where nil stands for no version.
+func matchesVersion(recordVersion, version interface{}) bool {
+	if spec, ok := version.(map[string]interface{}); ok {
+		for _, v := range spec["$in"].([]interface{}) {
+			if fmt.Sprint(recordVersion) == fmt.Sprint(v) {
+				return true
+			}
+		}
+		return false
+	}
+	return fmt.Sprint(recordVersion) == fmt.Sprint(version)
+}
+
+// lessValue compares two record values, numerically when both are numbers.
+func lessValue(a, b interface{}) bool {
+	x, errA := strconv.ParseFloat(fmt.Sprint(a), 64)
+	y, errB := strconv.ParseFloat(fmt.Sprint(b), 64)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+// matchesFilter checks whether the record has the exact values given in the filter.
+func matchesFilter(record map[string]interface{}, filter backends.Filter) bool {
+	for key, value := range filter {
+		if fmt.Sprint(recordValue(record, key)) != fmt.Sprint(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordValue returns the value of the record property. Properties of nested documents are given with a
+// dotted path, as in "profile.city".
+func recordValue(record map[string]interface{}, key string) interface{} {
+	if value, ok := record[key]; ok {
+		return value
+	}
+	dot := strings.Index(key, ".")
+	if dot < 0 {
+		return nil
+	}
+	nested, ok := record[key[:dot]].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return recordValue(nested, key[dot+1:])
+}
+
 func (db *DB) Save(object interface{}, filter backends.Filter) (interface{}, error) {
 
 	db.Lock()
@@ -176,9 +379,16 @@ func (db *DB) Save(object interface{}, filter backends.Filter) (interface{}, err
 		(*payload)["id"] = id
 
 		db.MapStore[id] = *payload
-		result = &UserRecord{
-			ExternalID: "",
-			Roles:      []string{},
+		if _, ok := object.(*UserRecord); ok {
+			result = &UserRecord{
+				ExternalID: "",
+				Roles:      []string{},
+			}
+		} else {
+			if err := backends.MapToInterface(payload, object); err != nil {
+				return nil, backends.ErrBackendError(err)
+			}
+			result = object
 		}
 	} else {
 
@@ -199,6 +409,9 @@ func (db *DB) Save(object interface{}, filter backends.Filter) (interface{}, err
 			}
 
 			updateRecord := record.(map[string]interface{})
+			if version, ok := filter["version"]; ok && !matchesVersion(updateRecord["version"], version) {
+				return nil, backends.ErrNotFound(NOT_FOUND)
+			}
 			for k, v := range *payload {
 				updateRecord[k] = v
 			}
@@ -242,6 +455,24 @@ func (db *DB) DeleteOne(filter backends.Filter) error {
 	db.Lock()
 	defer db.Unlock()
 
+	if id, ok := filter["id"]; ok {
+		idString := id.(string)
+
+		if idString == "bad-id" {
+			return backends.ErrInvalidInput(BAD_REQUEST)
+		}
+
+		if idString == "internal-err-id" {
+			return backends.ErrBackendError(INTERNAL_ERROR)
+		}
+
+		if _, ok := db.MapStore[idString]; !ok {
+			return backends.ErrNotFound(NOT_FOUND)
+		}
+
+		delete(db.MapStore, idString)
+	}
+
 	if token, ok := filter["token"]; ok {
 		tokenString := token.(string)
 
@@ -260,5 +491,15 @@ func (db *DB) DeleteOne(filter backends.Filter) error {
 }
 
 func (db *DB) DeleteAll(filter backends.Filter) error {
+
+	db.Lock()
+	defer db.Unlock()
+
+	for key, r := range db.MapStore {
+		if matchesFilter(r.(map[string]interface{}), filter) {
+			delete(db.MapStore, key)
+		}
+	}
+
 	return nil
 }
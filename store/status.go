@@ -0,0 +1,134 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/Microkubes/backends"
+)
+
+const (
+	// StatusPendingVerification is the status of a newly created user that has not verified the email yet.
+	StatusPendingVerification = "pending_verification"
+	// StatusActive is the status of a verified user that can use the system.
+	StatusActive = "active"
+	// StatusSuspended is the status of a user suspended by an admin.
+	StatusSuspended = "suspended"
+	// StatusLocked is the status of a user locked out by the system (ex. after too many failed logins).
+	StatusLocked = "locked"
+	// StatusDeactivated is the status of a user whose account has been deactivated.
+	StatusDeactivated = "deactivated"
+	// StatusDeleted is the status of a soft-deleted user.
+	StatusDeleted = "deleted"
+)
+
+// allowedTransitions maps each status to the statuses a user can move to from it.
+var allowedTransitions = map[string][]string{
+	StatusPendingVerification: {StatusActive, StatusSuspended, StatusLocked, StatusDeactivated, StatusDeleted},
+	StatusActive:              {StatusSuspended, StatusLocked, StatusDeactivated, StatusDeleted},
+	StatusSuspended:           {StatusActive, StatusDeactivated, StatusDeleted},
+	StatusLocked:              {StatusPendingVerification, StatusActive, StatusSuspended, StatusDeactivated, StatusDeleted},
+	StatusDeactivated:         {StatusActive, StatusSuspended, StatusDeleted},
+	StatusDeleted:             {StatusPendingVerification, StatusActive, StatusSuspended, StatusLocked, StatusDeactivated},
+}
+
+// StatusTransition records a single change of the user account status.
+type StatusTransition struct {
+	// Status before the transition
+	From string `json:"from"`
+	// Status after the transition
+	To string `json:"to"`
+	// Reason for changing the status
+	Reason string `json:"reason,omitempty"`
+	// ID of the user (or "system") that changed the status
+	Actor string `json:"actor,omitempty"`
+	// Time of the transition
+	At int64 `json:"at"`
+}
+
+// CanTransition checks whether a user can move from one status to another.
+func CanTransition(from, to string) bool {
+	for _, status := range allowedTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrInvalidTransition is returned when the requested status change is not allowed.
+func ErrInvalidTransition(from, to string) error {
+	return backends.ErrInvalidInput(fmt.Sprintf("cannot change user status from %s to %s", from, to))
+}
+
+// CurrentStatus returns the status of the user. Records created before the status was
+// introduced have no status set, so it is derived from the legacy flags instead.
+func (u *UserRecord) CurrentStatus() string {
+	if u.Status != "" {
+		return u.Status
+	}
+	if u.DeletedAt != 0 {
+		return StatusDeleted
+	}
+	if u.Active {
+		return StatusActive
+	}
+	return StatusPendingVerification
+}
+
+// CanLogin checks whether the user is allowed to authenticate with the current status.
+func (u *UserRecord) CanLogin() bool {
+	status := u.CurrentStatus()
+	return status == StatusActive || status == StatusPendingVerification
+}
+
+// StatusBefore returns the status the user had before moving to the given status (ex. before being
+// soft-deleted or locked).
+func (u *UserRecord) StatusBefore(status string) string {
+	for i := len(u.StatusHistory) - 1; i >= 0; i-- {
+		if transition := u.StatusHistory[i]; transition.To == status {
+			return transition.From
+		}
+	}
+	if u.Active {
+		return StatusActive
+	}
+	return StatusPendingVerification
+}
+
+// StatusUpdate builds the update for moving the user to the given status. The transition is recorded
+// in the status history of the user and the legacy "active" flag is kept in sync with the status.
+// Leaving the locked status resets the failed logins counter.
+func (u *UserRecord) StatusUpdate(to, reason, actor string, now int64) (map[string]interface{}, error) {
+	from := u.CurrentStatus()
+	if !CanTransition(from, to) {
+		return nil, ErrInvalidTransition(from, to)
+	}
+
+	history := append(u.StatusHistory, StatusTransition{
+		From:   from,
+		To:     to,
+		Reason: reason,
+		Actor:  actor,
+		At:     now,
+	})
+
+	update := map[string]interface{}{
+		"status":        to,
+		"active":        to == StatusActive,
+		"statusHistory": history,
+		"modifiedAt":    now,
+	}
+
+	if to == StatusDeleted {
+		update["deletedAt"] = now
+	} else if from == StatusDeleted {
+		update["deletedAt"] = 0
+	}
+
+	if from == StatusLocked {
+		update["failedLogins"] = 0
+		update["lockedUntil"] = 0
+	}
+
+	return update, nil
+}
@@ -0,0 +1,86 @@
+package store
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	if !CanTransition(StatusPendingVerification, StatusActive) {
+		t.Error("Expected pending_verification => active to be allowed")
+	}
+	if !CanTransition(StatusActive, StatusSuspended) {
+		t.Error("Expected active => suspended to be allowed")
+	}
+	if CanTransition(StatusSuspended, StatusLocked) {
+		t.Error("Expected suspended => locked not to be allowed")
+	}
+	if CanTransition(StatusActive, StatusActive) {
+		t.Error("Expected active => active not to be allowed")
+	}
+}
+
+func TestCurrentStatus(t *testing.T) {
+	if status := (&UserRecord{}).CurrentStatus(); status != StatusPendingVerification {
+		t.Errorf("Expected %s, got %s", StatusPendingVerification, status)
+	}
+	if status := (&UserRecord{Active: true}).CurrentStatus(); status != StatusActive {
+		t.Errorf("Expected %s, got %s", StatusActive, status)
+	}
+	if status := (&UserRecord{Active: true, DeletedAt: 1}).CurrentStatus(); status != StatusDeleted {
+		t.Errorf("Expected %s, got %s", StatusDeleted, status)
+	}
+	if status := (&UserRecord{Active: true, Status: StatusLocked}).CurrentStatus(); status != StatusLocked {
+		t.Errorf("Expected %s, got %s", StatusLocked, status)
+	}
+}
+
+func TestStatusUpdate(t *testing.T) {
+	user := &UserRecord{Status: StatusActive, Active: true}
+
+	update, err := user.StatusUpdate(StatusDeleted, "cleanup", "admin-id", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update["active"] != false {
+		t.Error("Expected the deleted user not to be active")
+	}
+	if update["deletedAt"] != int64(1000) {
+		t.Errorf("Expected deletedAt to be set, got %v", update["deletedAt"])
+	}
+	history := update["statusHistory"].([]StatusTransition)
+	if len(history) != 1 || history[0].From != StatusActive || history[0].Actor != "admin-id" {
+		t.Errorf("Unexpected status history: %v", history)
+	}
+
+	user.Status = StatusDeleted
+	user.StatusHistory = history
+	if status := user.StatusBefore(StatusDeleted); status != StatusActive {
+		t.Errorf("Expected %s before delete, got %s", StatusActive, status)
+	}
+
+	if _, err := user.StatusUpdate(StatusDeleted, "", "", 2000); err == nil {
+		t.Error("Expected deleted => deleted to fail")
+	}
+}
+
+func TestStatusUpdateUnlock(t *testing.T) {
+	user := &UserRecord{
+		Status:       StatusLocked,
+		FailedLogins: 5,
+		LockedUntil:  1000,
+		StatusHistory: []StatusTransition{
+			{From: StatusPendingVerification, To: StatusLocked},
+		},
+	}
+
+	to := user.StatusBefore(StatusLocked)
+	if to != StatusPendingVerification {
+		t.Errorf("Expected %s before lock, got %s", StatusPendingVerification, to)
+	}
+
+	update, err := user.StatusUpdate(to, "unlocked", "admin-id", 2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update["failedLogins"] != 0 || update["lockedUntil"] != 0 {
+		t.Errorf("Expected the failed logins to be reset, got %v", update)
+	}
+}
@@ -11,12 +11,21 @@ type FPToken struct {
 	ExpDate string `form:"expDate" json:"expDate" yaml:"expDate" xml:"expDate"`
 }
 
+// PrivateFields are the fields of the user record that are never exposed outside the service.
+var PrivateFields = []string{"password", "passwordHistory", "totpSecret", "pendingTotpSecret", "totpLastCounter", "recoveryCodes", "pendingEmailToken", "magicLinkToken"}
+
 type UserRecord struct {
 	ID bson.ObjectId `json:"id" bson:"_id"`
 	// Status of user account
 	Active bool `form:"active" json:"active" yaml:"active" xml:"active"`
-	// Email of user
+	// Lifecycle status of user account (pending_verification, active, suspended, locked, deactivated, deleted)
+	Status string `form:"status,omitempty" json:"status,omitempty" yaml:"status,omitempty" xml:"status,omitempty"`
+	// History of the status changes
+	StatusHistory []StatusTransition `json:"statusHistory,omitempty" bson:"statusHistory"`
+	// Email of user, normalized
 	Email string `form:"email" json:"email" yaml:"email" xml:"email"`
+	// Email of user as entered
+	DisplayEmail string `json:"displayEmail,omitempty" bson:"displayEmail"`
 	// External id of user
 	ExternalID string `form:"externalId,omitempty" json:"externalId,omitempty" yaml:"externalId,omitempty" xml:"externalId,omitempty"`
 	// List of namespaces this user belongs to
@@ -25,9 +34,11 @@ type UserRecord struct {
 	Organizations []string `form:"organizations,omitempty" json:"organizations,omitempty" yaml:"organizations,omitempty" xml:"organizations,omitempty"`
 	// Password of user
 	Password string `form:"password,omitempty" json:"password,omitempty" yaml:"password,omitempty" xml:"password,omitempty"`
+	// Hashes of the recent passwords of user, the most recent first. Never exposed outside the service.
+	PasswordHistory []string `json:"passwordHistory,omitempty" bson:"passwordHistory"`
 	// Roles of user
 	Roles []string `form:"roles,omitempty" json:"roles,omitempty" yaml:"roles,omitempty" xml:"roles,omitempty"`
-	// Token for email verification
+	// Token for email verification. No longer set: the verification tokens are kept, hashed, in the tokens collection.
 	Token string `form:"token,omitempty" json:"token,omitempty" yaml:"token,omitempty" xml:"token,omitempty"`
 	// Tokens for forgotten password
 	FPToken FPToken `form:"forgotPasswordTokens" json:"forgotPasswordTokens" yaml:"forgotPasswordTokens" xml:"forgotPasswordTokens"`
@@ -35,23 +46,84 @@ type UserRecord struct {
 	CreatedAt int64 `json:"createdAt,omitempty" bson:"createdAt"`
 	// Time of modifying
 	ModifiedAt int64 `json:"modifiedAt,omitempty" bson:"modifiedAt"`
+	// Version of the record, incremented on every versioned update. Zero for records saved before versioning.
+	Version int64 `json:"version,omitempty" bson:"version"`
+	// Time of (soft) deleting. Zero if the user is not deleted.
+	DeletedAt int64 `json:"deletedAt,omitempty" bson:"deletedAt"`
+	// Number of consecutive failed logins
+	FailedLogins int `json:"failedLogins,omitempty" bson:"failedLogins"`
+	// Time of the last failed login
+	LastFailedLoginAt int64 `json:"lastFailedLoginAt,omitempty" bson:"lastFailedLoginAt"`
+	// Time until the account is locked after too many failed logins
+	LockedUntil int64 `json:"lockedUntil,omitempty" bson:"lockedUntil"`
+	// Time of the last successful login
+	LastLoginAt int64 `json:"lastLoginAt,omitempty" bson:"lastLoginAt"`
+	// Whether multi-factor authentication is enabled
+	MFAEnabled bool `json:"mfaEnabled,omitempty" bson:"mfaEnabled"`
+	// TOTP secret of the confirmed enrollment. Never exposed outside the service.
+	TOTPSecret string `json:"totpSecret,omitempty" bson:"totpSecret"`
+	// TOTP secret of an enrollment waiting for confirmation. Never exposed outside the service.
+	PendingTOTPSecret string `json:"pendingTotpSecret,omitempty" bson:"pendingTotpSecret"`
+	// Time step of the last accepted TOTP code, used to reject replayed codes
+	TOTPLastCounter int64 `json:"totpLastCounter,omitempty" bson:"totpLastCounter"`
+	// Hashes of the unused recovery codes. Never exposed outside the service.
+	RecoveryCodes []string `json:"recoveryCodes,omitempty" bson:"recoveryCodes"`
+	// New email of user, waiting for confirmation
+	PendingEmail string `json:"pendingEmail,omitempty" bson:"pendingEmail"`
+	// Hash of the token confirming the new email. Never exposed outside the service.
+	PendingEmailToken string `json:"pendingEmailToken,omitempty" bson:"pendingEmailToken"`
+	// Expiry time of the email change token
+	PendingEmailExpiresAt int64 `json:"pendingEmailExpiresAt,omitempty" bson:"pendingEmailExpiresAt"`
+	// Hash of the magic link sign-in token. Never exposed outside the service.
+	MagicLinkToken string `json:"magicLinkToken,omitempty" bson:"magicLinkToken"`
+	// Expiry time of the magic link sign-in token
+	MagicLinkExpiresAt int64 `json:"magicLinkExpiresAt,omitempty" bson:"magicLinkExpiresAt"`
+	// Profile attributes of user, as declared in the profile schema of the service
+	Profile map[string]interface{} `json:"profile,omitempty" bson:"profile"`
+}
+
+// IsDeleted returns true if the user has been soft-deleted.
+func (u *UserRecord) IsDeleted() bool {
+	return u.CurrentStatus() == StatusDeleted
 }
 
 func (u *UserRecord) ToAppUsers() *app.Users {
+	status := u.CurrentStatus()
+	mfaEnabled := u.MFAEnabled
 	au := &app.Users{
 		Active:        u.Active,
 		Email:         u.Email,
-		ExternalID:    u.ExternalID,
 		ID:            u.ID.Hex(),
 		Namespaces:    u.Namespaces,
 		Organizations: u.Organizations,
 		Roles:         u.Roles,
+		Status:        &status,
+		MfaEnabled:    &mfaEnabled,
+	}
+	if u.ExternalID != "" {
+		externalID := u.ExternalID
+		au.ExternalID = &externalID
+	}
+	if u.DisplayEmail != "" {
+		displayEmail := u.DisplayEmail
+		au.DisplayEmail = &displayEmail
+	}
+	if u.PendingEmail != "" {
+		pendingEmail := u.PendingEmail
+		au.PendingEmail = &pendingEmail
+	}
+	if u.LastLoginAt != 0 {
+		lastLoginAt := int(u.LastLoginAt)
+		au.LastLoginAt = &lastLoginAt
 	}
 	return au
 }
 
 // User wraps User's collections/tables. Implements backneds.Repository interface
 type User struct {
-	Users  backends.Repository
-	Tokens backends.Repository
+	Users        backends.Repository
+	Tokens       backends.Repository
+	Logins       backends.Repository
+	AccessTokens backends.Repository
+	Invitations  backends.Repository
 }
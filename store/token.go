@@ -0,0 +1,19 @@
+package store
+
+// TokenRecord is an email verification token.
+type TokenRecord struct {
+	ID string `json:"id,omitempty" bson:"_id,omitempty"`
+	// Email of the user the token has been issued for
+	Email string `json:"email" bson:"email"`
+	// The verification token
+	Token string `json:"token" bson:"token"`
+	// Time of issuing the token
+	IssuedAt int64 `json:"issuedAt,omitempty" bson:"issuedAt"`
+	// Expiry time of the token. Tokens issued before the expiry was introduced have no expiry time.
+	ExpiresAt int64 `json:"expiresAt,omitempty" bson:"expiresAt"`
+}
+
+// IsExpired checks whether the token has expired at the given time.
+func (t *TokenRecord) IsExpired(now int64) bool {
+	return t.ExpiresAt != 0 && now >= t.ExpiresAt
+}
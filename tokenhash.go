@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/store"
+)
+
+// tokenHashPrefix marks the stored token hashes, so that they can be told apart from the plaintext tokens
+// stored by previous versions of the service.
+const tokenHashPrefix = "hmac-sha256:"
+
+// TokenHasher computes keyed hashes of the email verification, password reset and personal access tokens, so
+// that only the hashes are stored and the tokens cannot be used by anyone with read access to the database.
+type TokenHasher struct {
+	secret []byte
+}
+
+// NewTokenHasher creates a TokenHasher with the given secret key.
+func NewTokenHasher(secret []byte) *TokenHasher {
+	return &TokenHasher{
+		secret: secret,
+	}
+}
+
+// Hash returns the keyed hash of the token.
+func (h *TokenHasher) Hash(token string) string {
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(token))
+	return tokenHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Matches checks, in constant time, whether the hash is the hash of the token.
+func (h *TokenHasher) Matches(hash, token string) bool {
+	return hmac.Equal([]byte(hash), []byte(h.Hash(token)))
+}
+
+// isTokenHash checks whether the stored value is a token hash rather than a plaintext token.
+func isTokenHash(value string) bool {
+	return strings.HasPrefix(value, tokenHashPrefix)
+}
+
+// migratePlaintextTokens replaces the plaintext tokens stored by previous versions of the service with their
+// hashes: the email verification tokens and the password reset tokens of the users. The migration is
+// idempotent. Returns the number of migrated tokens.
+func migratePlaintextTokens(users store.User, hasher *TokenHasher) (int, error) {
+	migrated := 0
+
+	var typeHint map[string]interface{}
+	result, err := users.Tokens.GetAll(backends.NewFilter(), typeHint, "", "", 0, 0)
+	if err != nil && !backends.IsErrNotFound(err) {
+		return migrated, err
+	}
+	tokens := []*store.TokenRecord{}
+	if err == nil && result != nil {
+		if err = backends.MapToInterface(result, &tokens); err != nil {
+			return migrated, err
+		}
+	}
+	for _, token := range tokens {
+		if token.Token == "" || isTokenHash(token.Token) {
+			continue
+		}
+		// The token is the hash key on some backends, so the record is replaced instead of updated.
+		tokenPayload := map[string]interface{}{
+			"email":     token.Email,
+			"token":     hasher.Hash(token.Token),
+			"issuedAt":  token.IssuedAt,
+			"expiresAt": token.ExpiresAt,
+		}
+		if _, err = users.Tokens.Save(&tokenPayload, nil); err != nil {
+			return migrated, err
+		}
+		if err = users.Tokens.DeleteOne(backends.NewFilter().Match("token", token.Token)); err != nil && !backends.IsErrNotFound(err) {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	result, err = users.Users.GetAll(backends.NewFilter(), typeHint, "", "", 0, 0)
+	if err != nil && !backends.IsErrNotFound(err) {
+		return migrated, err
+	}
+	records := []*store.UserRecord{}
+	if err == nil && result != nil {
+		if err = backends.MapToInterface(result, &records); err != nil {
+			return migrated, err
+		}
+	}
+	for _, user := range records {
+		if user.FPToken.Token == "" || isTokenHash(user.FPToken.Token) {
+			continue
+		}
+		update := map[string]interface{}{
+			"forgotPasswordTokens": store.FPToken{
+				Token:   hasher.Hash(user.FPToken.Token),
+				ExpDate: user.FPToken.ExpDate,
+			},
+		}
+		if _, err = saveUserRecord(users.Users, user, update); err != nil {
+			// the user has been changed since it was read, possibly by another instance
+			if err == errVersionConflict {
+				continue
+			}
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
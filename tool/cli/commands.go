@@ -29,6 +29,34 @@ import (
 )
 
 type (
+	// AcceptInvitationUserCommand is the command line data structure for the acceptInvitation action of user
+	AcceptInvitationUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// ChangePasswordUserCommand is the command line data structure for the changePassword action of user
+	ChangePasswordUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// ConfirmEmailChangeUserCommand is the command line data structure for the confirmEmailChange action of user
+	ConfirmEmailChangeUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// ConfirmTotpUserCommand is the command line data structure for the confirmTotp action of user
+	ConfirmTotpUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
 	// CreateUserCommand is the command line data structure for the create action of user
 	CreateUserCommand struct {
 		Payload     string
@@ -36,6 +64,41 @@ type (
 		PrettyPrint bool
 	}
 
+	// CreateInvitationUserCommand is the command line data structure for the createInvitation action of user
+	CreateInvitationUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// CreateTokenUserCommand is the command line data structure for the createToken action of user
+	CreateTokenUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// DeactivateUserCommand is the command line data structure for the deactivate action of user
+	DeactivateUserCommand struct {
+		Payload     string
+		ContentType string
+		// User ID
+		UserID      string
+		PrettyPrint bool
+	}
+
+	// DeleteUserCommand is the command line data structure for the delete action of user
+	DeleteUserCommand struct {
+		// User ID
+		UserID      string
+		PrettyPrint bool
+	}
+
+	// EnrollTotpUserCommand is the command line data structure for the enrollTotp action of user
+	EnrollTotpUserCommand struct {
+		PrettyPrint bool
+	}
+
 	// FindUserCommand is the command line data structure for the find action of user
 	FindUserCommand struct {
 		Payload     string
@@ -50,6 +113,20 @@ type (
 		PrettyPrint bool
 	}
 
+	// FindByMagicLinkUserCommand is the command line data structure for the findByMagicLink action of user
+	FindByMagicLinkUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// FindByTokenUserCommand is the command line data structure for the findByToken action of user
+	FindByTokenUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
 	// FindUsersUserCommand is the command line data structure for the findUsers action of user
 	FindUsersUserCommand struct {
 		Payload     string
@@ -90,11 +167,117 @@ type (
 		PrettyPrint bool
 	}
 
+	// GetDuplicatesUserCommand is the command line data structure for the getDuplicates action of user
+	GetDuplicatesUserCommand struct {
+		PrettyPrint bool
+	}
+
+	// GetLoginsUserCommand is the command line data structure for the getLogins action of user
+	GetLoginsUserCommand struct {
+		// User ID
+		UserID string
+		// Limit logins per page
+		Limit int
+		// Number of logins to skip
+		Offset      int
+		PrettyPrint bool
+	}
+
 	// GetMeUserCommand is the command line data structure for the getMe action of user
 	GetMeUserCommand struct {
 		PrettyPrint bool
 	}
 
+	// GetMyLoginsUserCommand is the command line data structure for the getMyLogins action of user
+	GetMyLoginsUserCommand struct {
+		// Limit logins per page
+		Limit int
+		// Number of logins to skip
+		Offset      int
+		PrettyPrint bool
+	}
+
+	// ListInvitationsUserCommand is the command line data structure for the listInvitations action of user
+	ListInvitationsUserCommand struct {
+		PrettyPrint bool
+	}
+
+	// ListTokensUserCommand is the command line data structure for the listTokens action of user
+	ListTokensUserCommand struct {
+		PrettyPrint bool
+	}
+
+	// MergeUsersUserCommand is the command line data structure for the mergeUsers action of user
+	MergeUsersUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// PatchUserCommand is the command line data structure for the patch action of user
+	PatchUserCommand struct {
+		Payload     string
+		ContentType string
+		// User ID
+		UserID string
+		// ETag of the user, the update is rejected if the user has been changed since
+		IfMatch     string
+		PrettyPrint bool
+	}
+
+	// PatchMeUserCommand is the command line data structure for the patchMe action of user
+	PatchMeUserCommand struct {
+		Payload     string
+		ContentType string
+		// ETag of the user, the update is rejected if the user has been changed since
+		IfMatch     string
+		PrettyPrint bool
+	}
+
+	// PurgeUserCommand is the command line data structure for the purge action of user
+	PurgeUserCommand struct {
+		// User ID
+		UserID      string
+		PrettyPrint bool
+	}
+
+	// ReactivateUserCommand is the command line data structure for the reactivate action of user
+	ReactivateUserCommand struct {
+		Payload     string
+		ContentType string
+		// User ID
+		UserID      string
+		PrettyPrint bool
+	}
+
+	// RegisterUserCommand is the command line data structure for the register action of user
+	RegisterUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// RequestEmailChangeUserCommand is the command line data structure for the requestEmailChange action of user
+	RequestEmailChangeUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// RequestMagicLinkUserCommand is the command line data structure for the requestMagicLink action of user
+	RequestMagicLinkUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
+	// ResetMfaUserCommand is the command line data structure for the resetMfa action of user
+	ResetMfaUserCommand struct {
+		// User ID
+		UserID      string
+		PrettyPrint bool
+	}
+
 	// ResetVerificationTokenUserCommand is the command line data structure for the resetVerificationToken action of user
 	ResetVerificationTokenUserCommand struct {
 		Payload     string
@@ -102,12 +285,62 @@ type (
 		PrettyPrint bool
 	}
 
+	// RestoreUserCommand is the command line data structure for the restore action of user
+	RestoreUserCommand struct {
+		// User ID
+		UserID      string
+		PrettyPrint bool
+	}
+
+	// RevokeInvitationUserCommand is the command line data structure for the revokeInvitation action of user
+	RevokeInvitationUserCommand struct {
+		// Invitation ID
+		InvitationID string
+		PrettyPrint  bool
+	}
+
+	// RevokeTokenUserCommand is the command line data structure for the revokeToken action of user
+	RevokeTokenUserCommand struct {
+		// Access token ID
+		TokenID     string
+		PrettyPrint bool
+	}
+
+	// SuspendUserCommand is the command line data structure for the suspend action of user
+	SuspendUserCommand struct {
+		Payload     string
+		ContentType string
+		// User ID
+		UserID      string
+		PrettyPrint bool
+	}
+
+	// UnlockUserCommand is the command line data structure for the unlock action of user
+	UnlockUserCommand struct {
+		Payload     string
+		ContentType string
+		// User ID
+		UserID      string
+		PrettyPrint bool
+	}
+
 	// UpdateUserCommand is the command line data structure for the update action of user
 	UpdateUserCommand struct {
 		Payload     string
 		ContentType string
 		// User ID
-		UserID      string
+		UserID string
+		// ETag of the user, the update is rejected if the user has been changed since
+		IfMatch     string
+		PrettyPrint bool
+	}
+
+	// UpdateMeUserCommand is the command line data structure for the updateMe action of user
+	UpdateMeUserCommand struct {
+		Payload     string
+		ContentType string
+		// ETag of the user, the update is rejected if the user has been changed since
+		IfMatch     string
 		PrettyPrint bool
 	}
 
@@ -118,6 +351,13 @@ type (
 		PrettyPrint bool
 	}
 
+	// VerifyMfaUserCommand is the command line data structure for the verifyMfa action of user
+	VerifyMfaUserCommand struct {
+		Payload     string
+		ContentType string
+		PrettyPrint bool
+	}
+
 	// DownloadCommand is the command line data structure for the download command.
 	DownloadCommand struct {
 		// OutFile is the path to the download output file.
@@ -129,35 +369,23 @@ type (
 func RegisterCommands(app *cobra.Command, c *client.Client) {
 	var command, sub *cobra.Command
 	command = &cobra.Command{
-		Use:   "create",
-		Short: `Creates user`,
+		Use:   "accept-invitation",
+		Short: `Accept an invitation. Creates an active user with the invited roles and memberships.`,
 	}
-	tmp1 := new(CreateUserCommand)
+	tmp1 := new(AcceptInvitationUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users"]`,
+		Use:   `user ["/users/invitations/accept"]`,
 		Short: ``,
 		Long: `
 
 Payload example:
 
 {
-   "active": true,
-   "email": "myles_lemke@gaylord.name",
-   "externalId": "Tenetur animi a sunt deserunt tempora quam.",
-   "namespaces": [
-      "Amet occaecati.",
-      "Amet occaecati."
-   ],
-   "organizations": [
-      "Et deleniti quis et consequuntur officiis.",
-      "Et deleniti quis et consequuntur officiis.",
-      "Et deleniti quis et consequuntur officiis."
-   ],
-   "password": "34i2en7cd7",
-   "roles": [
-      "Nam velit incidunt sunt sed provident."
-   ],
-   "token": "Et omnis neque consequatur."
+   "password": "Esse aliquid optio soluta.",
+   "profile": {
+      "Pariatur consequatur accusantium occaecati sint.": "1992-02-11T22:50:18Z"
+   },
+   "token": "Vitae sed aut explicabo."
 }`,
 		RunE: func(cmd *cobra.Command, args []string) error { return tmp1.Run(c, args) },
 	}
@@ -166,20 +394,20 @@ Payload example:
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "find",
-		Short: `Find a user by email+password`,
+		Use:   "change-password",
+		Short: `Change the password of the authenticated user`,
 	}
-	tmp2 := new(FindUserCommand)
+	tmp2 := new(ChangePasswordUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/find"]`,
+		Use:   `user ["/users/me/password"]`,
 		Short: ``,
 		Long: `
 
 Payload example:
 
 {
-   "email": "alva@grahamklein.org",
-   "password": "kzsr2yeiek"
+   "currentPassword": "Ut ipsam corrupti suscipit aliquid explicabo.",
+   "newPassword": "Et maxime explicabo natus."
 }`,
 		RunE: func(cmd *cobra.Command, args []string) error { return tmp2.Run(c, args) },
 	}
@@ -188,19 +416,19 @@ Payload example:
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "find-by-email",
-		Short: `Find a user by email`,
+		Use:   "confirm-email-change",
+		Short: `Confirm an email change with the token sent to the new address`,
 	}
-	tmp3 := new(FindByEmailUserCommand)
+	tmp3 := new(ConfirmEmailChangeUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/find/email"]`,
+		Use:   `user ["/users/email/confirm"]`,
 		Short: ``,
 		Long: `
 
 Payload example:
 
 {
-   "email": "janie@gaylord.info"
+   "token": "Pariatur et inventore ex inventore."
 }`,
 		RunE: func(cmd *cobra.Command, args []string) error { return tmp3.Run(c, args) },
 	}
@@ -209,30 +437,19 @@ Payload example:
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "find-users",
-		Short: `Find (filter) users by some filter.`,
+		Use:   "confirm-totp",
+		Short: `Confirm TOTP enrollment with the first code from the authenticator and enable MFA`,
 	}
-	tmp4 := new(FindUsersUserCommand)
+	tmp4 := new(ConfirmTotpUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/list"]`,
+		Use:   `user ["/users/me/mfa/totp/confirm"]`,
 		Short: ``,
 		Long: `
 
 Payload example:
 
 {
-   "filter": [
-      {
-         "property": "Soluta omnis et pariatur consequatur accusantium occaecati.",
-         "value": "Harum ipsam impedit vitae sed."
-      }
-   ],
-   "page": 2650884019839767564,
-   "pageSize": 4871160491463818035,
-   "sort": {
-      "direction": "Ut ipsam corrupti suscipit aliquid explicabo.",
-      "property": "Et maxime explicabo natus."
-   }
+   "code": "Eligendi ad ad eveniet doloribus."
 }`,
 		RunE: func(cmd *cobra.Command, args []string) error { return tmp4.Run(c, args) },
 	}
@@ -241,19 +458,40 @@ Payload example:
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "forgot-password",
-		Short: `Forgot password action (sending email to user with link for resseting password)`,
+		Use:   "create",
+		Short: `Creates user`,
 	}
-	tmp5 := new(ForgotPasswordUserCommand)
+	tmp5 := new(CreateUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/password/forgot"]`,
+		Use:   `user ["/users"]`,
 		Short: ``,
 		Long: `
 
 Payload example:
 
 {
-   "email": "janie@gaylord.info"
+   "active": false,
+   "email": "noah_kassulke@rosenbaum.info",
+   "externalId": "Dolorem quibusdam et odit eveniet eum architecto.",
+   "namespaces": [
+      "Amet occaecati.",
+      "Amet occaecati.",
+      "Amet occaecati."
+   ],
+   "organizations": [
+      "Et deleniti quis et consequuntur officiis.",
+      "Et deleniti quis et consequuntur officiis.",
+      "Et deleniti quis et consequuntur officiis."
+   ],
+   "password": "Veritatis consectetur reprehenderit ratione eaque.",
+   "profile": {
+      "Expedita est illum natus quaerat.": 7838815073912181037
+   },
+   "roles": [
+      "Sit officia.",
+      "Sit officia."
+   ],
+   "token": "Illo pariatur quis dolores dignissimos."
 }`,
 		RunE: func(cmd *cobra.Command, args []string) error { return tmp5.Run(c, args) },
 	}
@@ -262,21 +500,30 @@ Payload example:
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "forgot-password-update",
-		Short: `Password token validation & password update`,
+		Use:   "create-invitation",
+		Short: `Invite a user. The invitation is sent by email, the invitee accepts it by setting a password, or by registering in the invite-only registration mode.`,
 	}
-	tmp6 := new(ForgotPasswordUpdateUserCommand)
+	tmp6 := new(CreateInvitationUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/password/forgot"]`,
+		Use:   `user ["/users/invitations"]`,
 		Short: ``,
 		Long: `
 
 Payload example:
 
 {
-   "email": "caleb@pagac.org",
-   "password": "mdm045mvqp",
-   "token": "Aut dignissimos dolorem quibusdam."
+   "email": "myrtice_nader@spinkagerhold.org",
+   "expiresAt": 8499370275914273973,
+   "namespaces": [
+      "Labore facere quasi et perspiciatis."
+   ],
+   "organizations": [
+      "Libero blanditiis quia.",
+      "Libero blanditiis quia."
+   ],
+   "roles": [
+      "In sed totam."
+   ]
 }`,
 		RunE: func(cmd *cobra.Command, args []string) error { return tmp6.Run(c, args) },
 	}
@@ -285,40 +532,59 @@ Payload example:
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "get",
-		Short: `Get user by id`,
+		Use:   "create-token",
+		Short: `Create a personal access token for the authenticated user. The token is returned only once.`,
 	}
-	tmp7 := new(GetUserCommand)
+	tmp7 := new(CreateTokenUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/USERID"]`,
+		Use:   `user ["/users/me/tokens"]`,
 		Short: ``,
-		RunE:  func(cmd *cobra.Command, args []string) error { return tmp7.Run(c, args) },
+		Long: `
+
+Payload example:
+
+{
+   "expiresAt": 6926941875255756230,
+   "name": "xpiqgl",
+   "scopes": [
+      "Praesentium doloremque consequuntur doloremque nam.",
+      "Praesentium doloremque consequuntur doloremque nam."
+   ]
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp7.Run(c, args) },
 	}
 	tmp7.RegisterFlags(sub, c)
 	sub.PersistentFlags().BoolVar(&tmp7.PrettyPrint, "pp", false, "Pretty print response body")
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "get-all",
-		Short: `Retrieves all active users`,
+		Use:   "deactivate",
+		Short: `Deactivate user`,
 	}
-	tmp8 := new(GetAllUserCommand)
+	tmp8 := new(DeactivateUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users"]`,
+		Use:   `user ["/users/USERID/deactivate"]`,
 		Short: ``,
-		RunE:  func(cmd *cobra.Command, args []string) error { return tmp8.Run(c, args) },
+		Long: `
+
+Payload example:
+
+{
+   "reason": "bbr78bwgp6"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp8.Run(c, args) },
 	}
 	tmp8.RegisterFlags(sub, c)
 	sub.PersistentFlags().BoolVar(&tmp8.PrettyPrint, "pp", false, "Pretty print response body")
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "get-me",
-		Short: `Retrieves the user information for the authenticated user`,
+		Use:   "delete",
+		Short: `Soft-delete user. The user is hidden from all lookups until restored.`,
 	}
-	tmp9 := new(GetMeUserCommand)
+	tmp9 := new(DeleteUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/me"]`,
+		Use:   `user ["/users/USERID"]`,
 		Short: ``,
 		RunE:  func(cmd *cobra.Command, args []string) error { return tmp9.Run(c, args) },
 	}
@@ -327,57 +593,34 @@ Payload example:
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "reset-verification-token",
-		Short: `Reset verification token`,
+		Use:   "enroll-totp",
+		Short: `Start TOTP multi-factor authentication enrollment for the authenticated user`,
 	}
-	tmp10 := new(ResetVerificationTokenUserCommand)
+	tmp10 := new(EnrollTotpUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/verification/reset"]`,
+		Use:   `user ["/users/me/mfa/totp"]`,
 		Short: ``,
-		Long: `
-
-Payload example:
-
-{
-   "email": "janie@gaylord.info"
-}`,
-		RunE: func(cmd *cobra.Command, args []string) error { return tmp10.Run(c, args) },
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp10.Run(c, args) },
 	}
 	tmp10.RegisterFlags(sub, c)
 	sub.PersistentFlags().BoolVar(&tmp10.PrettyPrint, "pp", false, "Pretty print response body")
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "update",
-		Short: `Update user`,
+		Use:   "find",
+		Short: `Find a user by email+password`,
 	}
-	tmp11 := new(UpdateUserCommand)
+	tmp11 := new(FindUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/USERID"]`,
+		Use:   `user ["/users/find"]`,
 		Short: ``,
 		Long: `
 
 Payload example:
 
 {
-   "active": false,
-   "email": "marques@spinka.org",
-   "externalId": "Voluptas eveniet sunt nemo qui nam.",
-   "namespaces": [
-      "Voluptas sunt voluptatem doloremque id.",
-      "Voluptas sunt voluptatem doloremque id.",
-      "Voluptas sunt voluptatem doloremque id."
-   ],
-   "organizations": [
-      "Facere vel.",
-      "Facere vel.",
-      "Facere vel."
-   ],
-   "password": "y9m9wo4go9",
-   "roles": [
-      "Labore facere quasi et perspiciatis."
-   ],
-   "token": "Nihil libero."
+   "email": "gudrun@fisher.com",
+   "password": "Reprehenderit omnis."
 }`,
 		RunE: func(cmd *cobra.Command, args []string) error { return tmp11.Run(c, args) },
 	}
@@ -386,241 +629,1775 @@ Payload example:
 	command.AddCommand(sub)
 	app.AddCommand(command)
 	command = &cobra.Command{
-		Use:   "verify",
-		Short: `Verify a user by token`,
+		Use:   "find-by-email",
+		Short: `Find a user by email`,
 	}
-	tmp12 := new(VerifyUserCommand)
+	tmp12 := new(FindByEmailUserCommand)
 	sub = &cobra.Command{
-		Use:   `user ["/users/verify"]`,
+		Use:   `user ["/users/find/email"]`,
 		Short: ``,
-		RunE:  func(cmd *cobra.Command, args []string) error { return tmp12.Run(c, args) },
+		Long: `
+
+Payload example:
+
+{
+   "email": "marshall@yundtmante.biz"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp12.Run(c, args) },
 	}
 	tmp12.RegisterFlags(sub, c)
 	sub.PersistentFlags().BoolVar(&tmp12.PrettyPrint, "pp", false, "Pretty print response body")
 	command.AddCommand(sub)
 	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "find-by-magic-link",
+		Short: `Find a user by magic link token. The token is consumed. Intended for internal use.`,
+	}
+	tmp13 := new(FindByMagicLinkUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/find/magic-link"]`,
+		Short: ``,
+		Long: `
 
-	dl := new(DownloadCommand)
-	dlc := &cobra.Command{
-		Use:   "download [PATH]",
-		Short: "Download file with given path",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return dl.Run(c, args)
-		},
+Payload example:
+
+{
+   "token": "Pariatur et inventore ex inventore."
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp13.Run(c, args) },
+	}
+	tmp13.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp13.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "find-by-token",
+		Short: `Find a user by personal access token. Intended for internal use.`,
+	}
+	tmp14 := new(FindByTokenUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/find/token"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "token": "Tenetur illo quisquam dignissimos mollitia corporis consequuntur."
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp14.Run(c, args) },
+	}
+	tmp14.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp14.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "find-users",
+		Short: `Find (filter) users by some filter.`,
+	}
+	tmp15 := new(FindUsersUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/list"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "anyOf": [
+      {
+         "filter": [
+            {
+               "operator": "exists",
+               "property": "Laudantium enim et.",
+               "value": "Labore incidunt.",
+               "values": [
+                  "Minima voluptatibus odio."
+               ]
+            },
+            {
+               "operator": "exists",
+               "property": "Laudantium enim et.",
+               "value": "Labore incidunt.",
+               "values": [
+                  "Minima voluptatibus odio."
+               ]
+            }
+         ]
+      },
+      {
+         "filter": [
+            {
+               "operator": "exists",
+               "property": "Laudantium enim et.",
+               "value": "Labore incidunt.",
+               "values": [
+                  "Minima voluptatibus odio."
+               ]
+            },
+            {
+               "operator": "exists",
+               "property": "Laudantium enim et.",
+               "value": "Labore incidunt.",
+               "values": [
+                  "Minima voluptatibus odio."
+               ]
+            }
+         ]
+      },
+      {
+         "filter": [
+            {
+               "operator": "exists",
+               "property": "Laudantium enim et.",
+               "value": "Labore incidunt.",
+               "values": [
+                  "Minima voluptatibus odio."
+               ]
+            },
+            {
+               "operator": "exists",
+               "property": "Laudantium enim et.",
+               "value": "Labore incidunt.",
+               "values": [
+                  "Minima voluptatibus odio."
+               ]
+            }
+         ]
+      }
+   ],
+   "cursor": "Dolore veniam et quisquam perferendis et.",
+   "filter": [
+      {
+         "operator": "exists",
+         "property": "Laudantium enim et.",
+         "value": "Labore incidunt.",
+         "values": [
+            "Minima voluptatibus odio."
+         ]
+      },
+      {
+         "operator": "exists",
+         "property": "Laudantium enim et.",
+         "value": "Labore incidunt.",
+         "values": [
+            "Minima voluptatibus odio."
+         ]
+      }
+   ],
+   "includeTotal": false,
+   "page": 4471653454094357389,
+   "pageSize": 8132601584804142802,
+   "sort": {
+      "direction": "Natus non.",
+      "property": "Natus autem voluptas facilis sed."
+   }
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp15.Run(c, args) },
+	}
+	tmp15.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp15.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "forgot-password",
+		Short: `Forgot password action (sending email to user with link for resseting password)`,
+	}
+	tmp16 := new(ForgotPasswordUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/password/forgot"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "email": "marshall@yundtmante.biz"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp16.Run(c, args) },
+	}
+	tmp16.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp16.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "forgot-password-update",
+		Short: `Password token validation & password update`,
+	}
+	tmp17 := new(ForgotPasswordUpdateUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/password/forgot"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "email": "perry.kulas@ernserschaden.com",
+   "password": "Dolores velit quibusdam consequatur.",
+   "token": "Sequi exercitationem itaque ut accusantium architecto."
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp17.Run(c, args) },
+	}
+	tmp17.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp17.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "get",
+		Short: `Get user by id`,
+	}
+	tmp18 := new(GetUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp18.Run(c, args) },
+	}
+	tmp18.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp18.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "get-all",
+		Short: `Retrieves all active users`,
+	}
+	tmp19 := new(GetAllUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp19.Run(c, args) },
+	}
+	tmp19.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp19.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "get-duplicates",
+		Short: `Report the users whose emails differ only in the letter case or the form of the domain`,
+	}
+	tmp20 := new(GetDuplicatesUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/duplicates"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp20.Run(c, args) },
+	}
+	tmp20.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp20.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "get-logins",
+		Short: `Retrieves the login history of a user, the most recent first`,
+	}
+	tmp21 := new(GetLoginsUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID/logins"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp21.Run(c, args) },
+	}
+	tmp21.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp21.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "get-me",
+		Short: `Retrieves the user information for the authenticated user`,
+	}
+	tmp22 := new(GetMeUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/me"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp22.Run(c, args) },
+	}
+	tmp22.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp22.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "get-my-logins",
+		Short: `Retrieves the login history of the authenticated user, the most recent first`,
+	}
+	tmp23 := new(GetMyLoginsUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/me/logins"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp23.Run(c, args) },
+	}
+	tmp23.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp23.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "list-invitations",
+		Short: `List the pending invitations`,
+	}
+	tmp24 := new(ListInvitationsUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/invitations"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp24.Run(c, args) },
+	}
+	tmp24.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp24.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "list-tokens",
+		Short: `List the personal access tokens of the authenticated user`,
+	}
+	tmp25 := new(ListTokensUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/me/tokens"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp25.Run(c, args) },
+	}
+	tmp25.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp25.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "merge-users",
+		Short: `Merge duplicate users into one user. The roles and memberships are combined and the duplicates are deleted.`,
+	}
+	tmp26 := new(MergeUsersUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/duplicates/merge"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "duplicateIds": [
+      "Facere nostrum facere et nihil ut necessitatibus."
+   ],
+   "userId": "Mollitia rerum enim in placeat."
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp26.Run(c, args) },
+	}
+	tmp26.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp26.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "patch",
+		Short: `Partially update user with a JSON merge patch (application/merge-patch+json). Absent fields are left as they are, null fields are cleared.`,
+	}
+	tmp27 := new(PatchUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "Odit mollitia sit quia et est quod.": 0.42751694188621564
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp27.Run(c, args) },
+	}
+	tmp27.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp27.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "patch-me",
+		Short: `Partially update the profile of the authenticated user with a JSON merge patch (application/merge-patch+json). Only the fields of UpdateMePayload are accepted.`,
+	}
+	tmp28 := new(PatchMeUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/me"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "Odit mollitia sit quia et est quod.": 0.42751694188621564
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp28.Run(c, args) },
+	}
+	tmp28.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp28.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "purge",
+		Short: `Permanently remove user and all of the user's tokens. Admin only.`,
+	}
+	tmp29 := new(PurgeUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID/purge"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp29.Run(c, args) },
+	}
+	tmp29.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp29.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "reactivate",
+		Short: `Reactivate suspended, locked or deactivated user`,
+	}
+	tmp30 := new(ReactivateUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID/reactivate"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "reason": "bbr78bwgp6"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp30.Run(c, args) },
+	}
+	tmp30.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp30.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "register",
+		Short: `Self-service registration. The user is created inactive with the user role, and a verification email is sent. In the invite-only mode, the invitation is accepted as with acceptInvitation.`,
+	}
+	tmp31 := new(RegisterUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/register"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "email": "electa@schillercummerata.com",
+   "invitationToken": "Voluptas et libero ut non.",
+   "password": "Est cum ut vitae quibusdam odio.",
+   "profile": {
+      "Quia reprehenderit.": 8855762371511236101
+   }
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp31.Run(c, args) },
+	}
+	tmp31.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp31.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "request-email-change",
+		Short: `Request an email change for the authenticated user. The email is changed once the token sent to the new address is confirmed.`,
+	}
+	tmp32 := new(RequestEmailChangeUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/me/email"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "currentPassword": "Tempora enim minus consequatur beatae.",
+   "email": "harrison_heaney@hayes.biz"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp32.Run(c, args) },
+	}
+	tmp32.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp32.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "request-magic-link",
+		Short: `Send a single-use sign-in link to the email of the user`,
+	}
+	tmp33 := new(RequestMagicLinkUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/magic-link"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "email": "marshall@yundtmante.biz"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp33.Run(c, args) },
+	}
+	tmp33.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp33.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "reset-mfa",
+		Short: `Disable MFA for a user and remove the TOTP secret and recovery codes`,
+	}
+	tmp34 := new(ResetMfaUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID/mfa"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp34.Run(c, args) },
+	}
+	tmp34.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp34.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "reset-verification-token",
+		Short: `Reset verification token`,
+	}
+	tmp35 := new(ResetVerificationTokenUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/verification/reset"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "email": "marshall@yundtmante.biz"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp35.Run(c, args) },
+	}
+	tmp35.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp35.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "restore",
+		Short: `Restore soft-deleted user`,
+	}
+	tmp36 := new(RestoreUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID/restore"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp36.Run(c, args) },
+	}
+	tmp36.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp36.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "revoke-invitation",
+		Short: `Revoke a pending invitation`,
+	}
+	tmp37 := new(RevokeInvitationUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/invitations/INVITATIONID"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp37.Run(c, args) },
+	}
+	tmp37.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp37.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "revoke-token",
+		Short: `Revoke a personal access token of the authenticated user`,
+	}
+	tmp38 := new(RevokeTokenUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/me/tokens/TOKENID"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp38.Run(c, args) },
+	}
+	tmp38.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp38.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "suspend",
+		Short: `Suspend user`,
+	}
+	tmp39 := new(SuspendUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID/suspend"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "reason": "bbr78bwgp6"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp39.Run(c, args) },
+	}
+	tmp39.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp39.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "unlock",
+		Short: `Unlock user locked after too many failed logins`,
+	}
+	tmp40 := new(UnlockUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID/unlock"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "reason": "bbr78bwgp6"
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp40.Run(c, args) },
+	}
+	tmp40.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp40.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "update",
+		Short: `Update user`,
+	}
+	tmp41 := new(UpdateUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/USERID"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "active": true,
+   "email": "hazel.konopelski@homenick.org",
+   "externalId": "Aperiam non voluptatem et non dicta et.",
+   "namespaces": [
+      "Sequi cumque consequuntur natus assumenda aut.",
+      "Sequi cumque consequuntur natus assumenda aut."
+   ],
+   "organizations": [
+      "Tempora et officiis repudiandae aliquam fuga aliquid.",
+      "Tempora et officiis repudiandae aliquam fuga aliquid.",
+      "Tempora et officiis repudiandae aliquam fuga aliquid."
+   ],
+   "password": "Est deserunt placeat aut adipisci.",
+   "profile": {
+      "Perspiciatis aut voluptatem molestiae ipsam aut voluptatem.": 0.6787437518082242
+   },
+   "roles": [
+      "Occaecati rem tenetur.",
+      "Occaecati rem tenetur.",
+      "Occaecati rem tenetur."
+   ],
+   "token": "Ea saepe voluptatem nesciunt ad accusantium inventore."
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp41.Run(c, args) },
+	}
+	tmp41.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp41.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "update-me",
+		Short: `Update the profile of the authenticated user. The email and the password are changed through their usual flows, both need the current password.`,
+	}
+	tmp42 := new(UpdateMeUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/me"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "currentPassword": "Sit ut porro ea.",
+   "email": "rory@willms.name",
+   "password": "Nobis et rerum.",
+   "profile": {
+      "Quia quo ut autem eos.": "6a002b71-33f3-4252-aea8-7409e4f78630"
+   }
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp42.Run(c, args) },
+	}
+	tmp42.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp42.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "verify",
+		Short: `Verify a user by token`,
+	}
+	tmp43 := new(VerifyUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/verify"]`,
+		Short: ``,
+		RunE:  func(cmd *cobra.Command, args []string) error { return tmp43.Run(c, args) },
+	}
+	tmp43.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp43.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+	command = &cobra.Command{
+		Use:   "verify-mfa",
+		Short: `Verify the second factor (TOTP or recovery code) of a user. Intended for internal use after find.`,
+	}
+	tmp44 := new(VerifyMfaUserCommand)
+	sub = &cobra.Command{
+		Use:   `user ["/users/mfa/verify"]`,
+		Short: ``,
+		Long: `
+
+Payload example:
+
+{
+   "code": "Voluptas omnis molestias corrupti delectus aut nisi.",
+   "userId": "Nobis quod inventore."
+}`,
+		RunE: func(cmd *cobra.Command, args []string) error { return tmp44.Run(c, args) },
+	}
+	tmp44.RegisterFlags(sub, c)
+	sub.PersistentFlags().BoolVar(&tmp44.PrettyPrint, "pp", false, "Pretty print response body")
+	command.AddCommand(sub)
+	app.AddCommand(command)
+
+	dl := new(DownloadCommand)
+	dlc := &cobra.Command{
+		Use:   "download [PATH]",
+		Short: "Download file with given path",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return dl.Run(c, args)
+		},
+	}
+	dlc.Flags().StringVar(&dl.OutFile, "out", "", "Output file")
+	app.AddCommand(dlc)
+}
+
+func intFlagVal(name string, parsed int) *int {
+	if hasFlag(name) {
+		return &parsed
+	}
+	return nil
+}
+
+func float64FlagVal(name string, parsed float64) *float64 {
+	if hasFlag(name) {
+		return &parsed
+	}
+	return nil
+}
+
+func boolFlagVal(name string, parsed bool) *bool {
+	if hasFlag(name) {
+		return &parsed
+	}
+	return nil
+}
+
+func stringFlagVal(name string, parsed string) *string {
+	if hasFlag(name) {
+		return &parsed
+	}
+	return nil
+}
+
+func hasFlag(name string) bool {
+	for _, arg := range os.Args[1:] {
+		if strings.HasPrefix(arg, "--"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonVal(val string) (*interface{}, error) {
+	var t interface{}
+	err := json.Unmarshal([]byte(val), &t)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func jsonArray(ins []string) ([]interface{}, error) {
+	if ins == nil {
+		return nil, nil
+	}
+	var vals []interface{}
+	for _, id := range ins {
+		val, err := jsonVal(id)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+func timeVal(val string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func timeArray(ins []string) ([]time.Time, error) {
+	if ins == nil {
+		return nil, nil
+	}
+	var vals []time.Time
+	for _, id := range ins {
+		val, err := timeVal(id)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, *val)
+	}
+	return vals, nil
+}
+
+func uuidVal(val string) (*uuid.UUID, error) {
+	t, err := uuid.FromString(val)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func uuidArray(ins []string) ([]uuid.UUID, error) {
+	if ins == nil {
+		return nil, nil
+	}
+	var vals []uuid.UUID
+	for _, id := range ins {
+		val, err := uuidVal(id)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, *val)
+	}
+	return vals, nil
+}
+
+func float64Val(val string) (*float64, error) {
+	t, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func float64Array(ins []string) ([]float64, error) {
+	if ins == nil {
+		return nil, nil
+	}
+	var vals []float64
+	for _, id := range ins {
+		val, err := float64Val(id)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, *val)
+	}
+	return vals, nil
+}
+
+func boolVal(val string) (*bool, error) {
+	t, err := strconv.ParseBool(val)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func boolArray(ins []string) ([]bool, error) {
+	if ins == nil {
+		return nil, nil
+	}
+	var vals []bool
+	for _, id := range ins {
+		val, err := boolVal(id)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, *val)
+	}
+	return vals, nil
+}
+
+// Run downloads files with given paths.
+func (cmd *DownloadCommand) Run(c *client.Client, args []string) error {
+	var (
+		fnf func(context.Context, string) (int64, error)
+		fnd func(context.Context, string, string) (int64, error)
+
+		rpath   = args[0]
+		outfile = cmd.OutFile
+		logger  = goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+		ctx     = goa.WithLogger(context.Background(), logger)
+		err     error
+	)
+
+	if rpath[0] != '/' {
+		rpath = "/" + rpath
+	}
+	if rpath == "/swagger.json" {
+		fnf = c.DownloadSwaggerJSON
+		if outfile == "" {
+			outfile = "swagger.json"
+		}
+		goto found
+	}
+	if strings.HasPrefix(rpath, "/swagger-ui/") {
+		fnd = c.DownloadSwaggerUI
+		rpath = rpath[12:]
+		if outfile == "" {
+			_, outfile = path.Split(rpath)
+		}
+		goto found
+	}
+	return fmt.Errorf("don't know how to download %s", rpath)
+found:
+	ctx = goa.WithLogContext(ctx, "file", outfile)
+	if fnf != nil {
+		_, err = fnf(ctx, outfile)
+	} else {
+		_, err = fnd(ctx, rpath, outfile)
+	}
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// Run makes the HTTP request corresponding to the AcceptInvitationUserCommand command.
+func (cmd *AcceptInvitationUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/invitations/accept"
+	}
+	var payload client.AcceptInvitationPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.AcceptInvitationUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *AcceptInvitationUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the ChangePasswordUserCommand command.
+func (cmd *ChangePasswordUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/me/password"
+	}
+	var payload client.ChangePasswordPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.ChangePasswordUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *ChangePasswordUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the ConfirmEmailChangeUserCommand command.
+func (cmd *ConfirmEmailChangeUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/email/confirm"
+	}
+	var payload client.TokenPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.ConfirmEmailChangeUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *ConfirmEmailChangeUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the ConfirmTotpUserCommand command.
+func (cmd *ConfirmTotpUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/me/mfa/totp/confirm"
+	}
+	var payload client.MFACodePayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.ConfirmTotpUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *ConfirmTotpUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the CreateUserCommand command.
+func (cmd *CreateUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users"
+	}
+	var payload client.CreateUserPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.CreateUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *CreateUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the CreateInvitationUserCommand command.
+func (cmd *CreateInvitationUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/invitations"
+	}
+	var payload client.InvitationPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.CreateInvitationUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *CreateInvitationUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the CreateTokenUserCommand command.
+func (cmd *CreateTokenUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/me/tokens"
+	}
+	var payload client.CreateAccessTokenPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.CreateTokenUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *CreateTokenUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the DeactivateUserCommand command.
+func (cmd *DeactivateUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = fmt.Sprintf("/users/%v/deactivate", url.QueryEscape(cmd.UserID))
+	}
+	var payload client.StatusChangePayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.DeactivateUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *DeactivateUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
+}
+
+// Run makes the HTTP request corresponding to the DeleteUserCommand command.
+func (cmd *DeleteUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = fmt.Sprintf("/users/%v", url.QueryEscape(cmd.UserID))
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.DeleteUser(ctx, path)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *DeleteUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
+}
+
+// Run makes the HTTP request corresponding to the EnrollTotpUserCommand command.
+func (cmd *EnrollTotpUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/me/mfa/totp"
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.EnrollTotpUser(ctx, path)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *EnrollTotpUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+}
+
+// Run makes the HTTP request corresponding to the FindUserCommand command.
+func (cmd *FindUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/find"
+	}
+	var payload client.Credentials
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.FindUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *FindUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the FindByEmailUserCommand command.
+func (cmd *FindByEmailUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/find/email"
+	}
+	var payload client.EmailPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.FindByEmailUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *FindByEmailUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the FindByMagicLinkUserCommand command.
+func (cmd *FindByMagicLinkUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/find/magic-link"
+	}
+	var payload client.TokenPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.FindByMagicLinkUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *FindByMagicLinkUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the FindByTokenUserCommand command.
+func (cmd *FindByTokenUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/find/token"
+	}
+	var payload client.AccessTokenPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.FindByTokenUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *FindByTokenUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the FindUsersUserCommand command.
+func (cmd *FindUsersUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/list"
+	}
+	var payload client.FilterPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.FindUsersUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *FindUsersUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the ForgotPasswordUserCommand command.
+func (cmd *ForgotPasswordUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/password/forgot"
+	}
+	var payload client.EmailPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.ForgotPasswordUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *ForgotPasswordUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the ForgotPasswordUpdateUserCommand command.
+func (cmd *ForgotPasswordUpdateUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/password/forgot"
+	}
+	var payload client.ForgotPasswordPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.ForgotPasswordUpdateUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *ForgotPasswordUpdateUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the GetUserCommand command.
+func (cmd *GetUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = fmt.Sprintf("/users/%v", url.QueryEscape(cmd.UserID))
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.GetUser(ctx, path)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
 	}
-	dlc.Flags().StringVar(&dl.OutFile, "out", "", "Output file")
-	app.AddCommand(dlc)
-}
 
-func intFlagVal(name string, parsed int) *int {
-	if hasFlag(name) {
-		return &parsed
-	}
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
 	return nil
 }
 
-func float64FlagVal(name string, parsed float64) *float64 {
-	if hasFlag(name) {
-		return &parsed
-	}
-	return nil
+// RegisterFlags registers the command flags with the command line.
+func (cmd *GetUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
 }
 
-func boolFlagVal(name string, parsed bool) *bool {
-	if hasFlag(name) {
-		return &parsed
+// Run makes the HTTP request corresponding to the GetAllUserCommand command.
+func (cmd *GetAllUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users"
 	}
-	return nil
-}
-
-func stringFlagVal(name string, parsed string) *string {
-	if hasFlag(name) {
-		return &parsed
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.GetAllUser(ctx, path, intFlagVal("limit", cmd.Limit), intFlagVal("offset", cmd.Offset), stringFlagVal("order", cmd.Order), stringFlagVal("sorting", cmd.Sorting))
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
 	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
 	return nil
 }
 
-func hasFlag(name string) bool {
-	for _, arg := range os.Args[1:] {
-		if strings.HasPrefix(arg, "--"+name) {
-			return true
-		}
-	}
-	return false
+// RegisterFlags registers the command flags with the command line.
+func (cmd *GetAllUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var limit int
+	cc.Flags().IntVar(&cmd.Limit, "limit", limit, `Limit users per page`)
+	var offset int
+	cc.Flags().IntVar(&cmd.Offset, "offset", offset, `Number of users to skip`)
+	var order string
+	cc.Flags().StringVar(&cmd.Order, "order", order, `Order by`)
+	var sorting string
+	cc.Flags().StringVar(&cmd.Sorting, "sorting", sorting, ``)
 }
 
-func jsonVal(val string) (*interface{}, error) {
-	var t interface{}
-	err := json.Unmarshal([]byte(val), &t)
+// Run makes the HTTP request corresponding to the GetDuplicatesUserCommand command.
+func (cmd *GetDuplicatesUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/duplicates"
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.GetDuplicatesUser(ctx, path)
 	if err != nil {
-		return nil, err
+		goa.LogError(ctx, "failed", "err", err)
+		return err
 	}
-	return &t, nil
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
 }
 
-func jsonArray(ins []string) ([]interface{}, error) {
-	if ins == nil {
-		return nil, nil
-	}
-	var vals []interface{}
-	for _, id := range ins {
-		val, err := jsonVal(id)
-		if err != nil {
-			return nil, err
-		}
-		vals = append(vals, val)
-	}
-	return vals, nil
+// RegisterFlags registers the command flags with the command line.
+func (cmd *GetDuplicatesUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 }
 
-func timeVal(val string) (*time.Time, error) {
-	t, err := time.Parse(time.RFC3339, val)
+// Run makes the HTTP request corresponding to the GetLoginsUserCommand command.
+func (cmd *GetLoginsUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = fmt.Sprintf("/users/%v/logins", url.QueryEscape(cmd.UserID))
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.GetLoginsUser(ctx, path, intFlagVal("limit", cmd.Limit), intFlagVal("offset", cmd.Offset))
 	if err != nil {
-		return nil, err
+		goa.LogError(ctx, "failed", "err", err)
+		return err
 	}
-	return &t, nil
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
 }
 
-func timeArray(ins []string) ([]time.Time, error) {
-	if ins == nil {
-		return nil, nil
-	}
-	var vals []time.Time
-	for _, id := range ins {
-		val, err := timeVal(id)
-		if err != nil {
-			return nil, err
-		}
-		vals = append(vals, *val)
-	}
-	return vals, nil
+// RegisterFlags registers the command flags with the command line.
+func (cmd *GetLoginsUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
+	var limit int
+	cc.Flags().IntVar(&cmd.Limit, "limit", limit, `Limit logins per page`)
+	var offset int
+	cc.Flags().IntVar(&cmd.Offset, "offset", offset, `Number of logins to skip`)
 }
 
-func uuidVal(val string) (*uuid.UUID, error) {
-	t, err := uuid.FromString(val)
+// Run makes the HTTP request corresponding to the GetMeUserCommand command.
+func (cmd *GetMeUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/me"
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.GetMeUser(ctx, path)
 	if err != nil {
-		return nil, err
+		goa.LogError(ctx, "failed", "err", err)
+		return err
 	}
-	return &t, nil
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
 }
 
-func uuidArray(ins []string) ([]uuid.UUID, error) {
-	if ins == nil {
-		return nil, nil
-	}
-	var vals []uuid.UUID
-	for _, id := range ins {
-		val, err := uuidVal(id)
-		if err != nil {
-			return nil, err
-		}
-		vals = append(vals, *val)
-	}
-	return vals, nil
+// RegisterFlags registers the command flags with the command line.
+func (cmd *GetMeUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 }
 
-func float64Val(val string) (*float64, error) {
-	t, err := strconv.ParseFloat(val, 64)
+// Run makes the HTTP request corresponding to the GetMyLoginsUserCommand command.
+func (cmd *GetMyLoginsUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/me/logins"
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.GetMyLoginsUser(ctx, path, intFlagVal("limit", cmd.Limit), intFlagVal("offset", cmd.Offset))
 	if err != nil {
-		return nil, err
+		goa.LogError(ctx, "failed", "err", err)
+		return err
 	}
-	return &t, nil
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
 }
 
-func float64Array(ins []string) ([]float64, error) {
-	if ins == nil {
-		return nil, nil
-	}
-	var vals []float64
-	for _, id := range ins {
-		val, err := float64Val(id)
-		if err != nil {
-			return nil, err
-		}
-		vals = append(vals, *val)
-	}
-	return vals, nil
+// RegisterFlags registers the command flags with the command line.
+func (cmd *GetMyLoginsUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var limit int
+	cc.Flags().IntVar(&cmd.Limit, "limit", limit, `Limit logins per page`)
+	var offset int
+	cc.Flags().IntVar(&cmd.Offset, "offset", offset, `Number of logins to skip`)
 }
 
-func boolVal(val string) (*bool, error) {
-	t, err := strconv.ParseBool(val)
+// Run makes the HTTP request corresponding to the ListInvitationsUserCommand command.
+func (cmd *ListInvitationsUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/invitations"
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.ListInvitationsUser(ctx, path)
 	if err != nil {
-		return nil, err
+		goa.LogError(ctx, "failed", "err", err)
+		return err
 	}
-	return &t, nil
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
 }
 
-func boolArray(ins []string) ([]bool, error) {
-	if ins == nil {
-		return nil, nil
+// RegisterFlags registers the command flags with the command line.
+func (cmd *ListInvitationsUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+}
+
+// Run makes the HTTP request corresponding to the ListTokensUserCommand command.
+func (cmd *ListTokensUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/me/tokens"
 	}
-	var vals []bool
-	for _, id := range ins {
-		val, err := boolVal(id)
-		if err != nil {
-			return nil, err
-		}
-		vals = append(vals, *val)
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.ListTokensUser(ctx, path)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
 	}
-	return vals, nil
-}
 
-// Run downloads files with given paths.
-func (cmd *DownloadCommand) Run(c *client.Client, args []string) error {
-	var (
-		fnf func(context.Context, string) (int64, error)
-		fnd func(context.Context, string, string) (int64, error)
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
 
-		rpath   = args[0]
-		outfile = cmd.OutFile
-		logger  = goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
-		ctx     = goa.WithLogger(context.Background(), logger)
-		err     error
-	)
+// RegisterFlags registers the command flags with the command line.
+func (cmd *ListTokensUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+}
 
-	if rpath[0] != '/' {
-		rpath = "/" + rpath
-	}
-	if rpath == "/swagger.json" {
-		fnf = c.DownloadSwaggerJSON
-		if outfile == "" {
-			outfile = "swagger.json"
-		}
-		goto found
+// Run makes the HTTP request corresponding to the MergeUsersUserCommand command.
+func (cmd *MergeUsersUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/duplicates/merge"
 	}
-	if strings.HasPrefix(rpath, "/swagger-ui/") {
-		fnd = c.DownloadSwaggerUI
-		rpath = rpath[12:]
-		if outfile == "" {
-			_, outfile = path.Split(rpath)
+	var payload client.MergeUsersPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
 		}
-		goto found
 	}
-	return fmt.Errorf("don't know how to download %s", rpath)
-found:
-	ctx = goa.WithLogContext(ctx, "file", outfile)
-	if fnf != nil {
-		_, err = fnf(ctx, outfile)
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.MergeUsersUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *MergeUsersUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
+
+// Run makes the HTTP request corresponding to the PatchUserCommand command.
+func (cmd *PatchUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
 	} else {
-		_, err = fnd(ctx, rpath, outfile)
+		path = fmt.Sprintf("/users/%v", url.QueryEscape(cmd.UserID))
+	}
+	var payload client.PatchUserPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
 	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.PatchUser(ctx, path, payload, stringFlagVal("If-Match", cmd.IfMatch), cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
 	}
 
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
 	return nil
 }
 
-// Run makes the HTTP request corresponding to the CreateUserCommand command.
-func (cmd *CreateUserCommand) Run(c *client.Client, args []string) error {
+// RegisterFlags registers the command flags with the command line.
+func (cmd *PatchUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
+	cc.Flags().StringVar(&cmd.IfMatch, "If-Match", "", `ETag of the user, the update is rejected if the user has been changed since`)
+}
+
+// Run makes the HTTP request corresponding to the PatchMeUserCommand command.
+func (cmd *PatchMeUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users"
+		path = "/users/me"
 	}
-	var payload client.CreateUserPayload
+	var payload client.PatchMeUserPayload
 	if cmd.Payload != "" {
 		err := json.Unmarshal([]byte(cmd.Payload), &payload)
 		if err != nil {
@@ -629,7 +2406,7 @@ func (cmd *CreateUserCommand) Run(c *client.Client, args []string) error {
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.CreateUser(ctx, path, &payload, cmd.ContentType)
+	resp, err := c.PatchMeUser(ctx, path, payload, stringFlagVal("If-Match", cmd.IfMatch), cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -640,20 +2417,47 @@ func (cmd *CreateUserCommand) Run(c *client.Client, args []string) error {
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *CreateUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *PatchMeUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
 	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+	cc.Flags().StringVar(&cmd.IfMatch, "If-Match", "", `ETag of the user, the update is rejected if the user has been changed since`)
 }
 
-// Run makes the HTTP request corresponding to the FindUserCommand command.
-func (cmd *FindUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the PurgeUserCommand command.
+func (cmd *PurgeUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users/find"
+		path = fmt.Sprintf("/users/%v/purge", url.QueryEscape(cmd.UserID))
 	}
-	var payload client.Credentials
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.PurgeUser(ctx, path)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *PurgeUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
+}
+
+// Run makes the HTTP request corresponding to the ReactivateUserCommand command.
+func (cmd *ReactivateUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = fmt.Sprintf("/users/%v/reactivate", url.QueryEscape(cmd.UserID))
+	}
+	var payload client.StatusChangePayload
 	if cmd.Payload != "" {
 		err := json.Unmarshal([]byte(cmd.Payload), &payload)
 		if err != nil {
@@ -662,7 +2466,7 @@ func (cmd *FindUserCommand) Run(c *client.Client, args []string) error {
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.FindUser(ctx, path, &payload, cmd.ContentType)
+	resp, err := c.ReactivateUser(ctx, path, &payload, cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -673,20 +2477,22 @@ func (cmd *FindUserCommand) Run(c *client.Client, args []string) error {
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *FindUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *ReactivateUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
 	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
 }
 
-// Run makes the HTTP request corresponding to the FindByEmailUserCommand command.
-func (cmd *FindByEmailUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the RegisterUserCommand command.
+func (cmd *RegisterUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users/find/email"
+		path = "/users/register"
 	}
-	var payload client.EmailPayload
+	var payload client.RegisterPayload
 	if cmd.Payload != "" {
 		err := json.Unmarshal([]byte(cmd.Payload), &payload)
 		if err != nil {
@@ -695,7 +2501,7 @@ func (cmd *FindByEmailUserCommand) Run(c *client.Client, args []string) error {
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.FindByEmailUser(ctx, path, &payload, cmd.ContentType)
+	resp, err := c.RegisterUser(ctx, path, &payload, cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -706,20 +2512,20 @@ func (cmd *FindByEmailUserCommand) Run(c *client.Client, args []string) error {
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *FindByEmailUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *RegisterUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
 	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
 }
 
-// Run makes the HTTP request corresponding to the FindUsersUserCommand command.
-func (cmd *FindUsersUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the RequestEmailChangeUserCommand command.
+func (cmd *RequestEmailChangeUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users/list"
+		path = "/users/me/email"
 	}
-	var payload client.FilterPayload
+	var payload client.ChangeEmailPayload
 	if cmd.Payload != "" {
 		err := json.Unmarshal([]byte(cmd.Payload), &payload)
 		if err != nil {
@@ -728,7 +2534,7 @@ func (cmd *FindUsersUserCommand) Run(c *client.Client, args []string) error {
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.FindUsersUser(ctx, path, &payload, cmd.ContentType)
+	resp, err := c.RequestEmailChangeUser(ctx, path, &payload, cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -739,18 +2545,18 @@ func (cmd *FindUsersUserCommand) Run(c *client.Client, args []string) error {
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *FindUsersUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *RequestEmailChangeUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
 	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
 }
 
-// Run makes the HTTP request corresponding to the ForgotPasswordUserCommand command.
-func (cmd *ForgotPasswordUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the RequestMagicLinkUserCommand command.
+func (cmd *RequestMagicLinkUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users/password/forgot"
+		path = "/users/magic-link"
 	}
 	var payload client.EmailPayload
 	if cmd.Payload != "" {
@@ -761,7 +2567,7 @@ func (cmd *ForgotPasswordUserCommand) Run(c *client.Client, args []string) error
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.ForgotPasswordUser(ctx, path, &payload, cmd.ContentType)
+	resp, err := c.RequestMagicLinkUser(ctx, path, &payload, cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -772,20 +2578,46 @@ func (cmd *ForgotPasswordUserCommand) Run(c *client.Client, args []string) error
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *ForgotPasswordUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *RequestMagicLinkUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
 	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
 }
 
-// Run makes the HTTP request corresponding to the ForgotPasswordUpdateUserCommand command.
-func (cmd *ForgotPasswordUpdateUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the ResetMfaUserCommand command.
+func (cmd *ResetMfaUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users/password/forgot"
+		path = fmt.Sprintf("/users/%v/mfa", url.QueryEscape(cmd.UserID))
 	}
-	var payload client.ForgotPasswordPayload
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.ResetMfaUser(ctx, path)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *ResetMfaUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
+}
+
+// Run makes the HTTP request corresponding to the ResetVerificationTokenUserCommand command.
+func (cmd *ResetVerificationTokenUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/verification/reset"
+	}
+	var payload client.EmailPayload
 	if cmd.Payload != "" {
 		err := json.Unmarshal([]byte(cmd.Payload), &payload)
 		if err != nil {
@@ -794,7 +2626,7 @@ func (cmd *ForgotPasswordUpdateUserCommand) Run(c *client.Client, args []string)
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.ForgotPasswordUpdateUser(ctx, path, &payload, cmd.ContentType)
+	resp, err := c.ResetVerificationTokenUser(ctx, path, &payload, cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -805,22 +2637,22 @@ func (cmd *ForgotPasswordUpdateUserCommand) Run(c *client.Client, args []string)
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *ForgotPasswordUpdateUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *ResetVerificationTokenUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
 	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
 }
 
-// Run makes the HTTP request corresponding to the GetUserCommand command.
-func (cmd *GetUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the RestoreUserCommand command.
+func (cmd *RestoreUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = fmt.Sprintf("/users/%v", url.QueryEscape(cmd.UserID))
+		path = fmt.Sprintf("/users/%v/restore", url.QueryEscape(cmd.UserID))
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.GetUser(ctx, path)
+	resp, err := c.RestoreUser(ctx, path)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -831,22 +2663,22 @@ func (cmd *GetUserCommand) Run(c *client.Client, args []string) error {
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *GetUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *RestoreUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 	var userID string
 	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
 }
 
-// Run makes the HTTP request corresponding to the GetAllUserCommand command.
-func (cmd *GetAllUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the RevokeInvitationUserCommand command.
+func (cmd *RevokeInvitationUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users"
+		path = fmt.Sprintf("/users/invitations/%v", url.QueryEscape(cmd.InvitationID))
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.GetAllUser(ctx, path, intFlagVal("limit", cmd.Limit), intFlagVal("offset", cmd.Offset), stringFlagVal("order", cmd.Order), stringFlagVal("sorting", cmd.Sorting))
+	resp, err := c.RevokeInvitationUser(ctx, path)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -857,28 +2689,22 @@ func (cmd *GetAllUserCommand) Run(c *client.Client, args []string) error {
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *GetAllUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
-	var limit int
-	cc.Flags().IntVar(&cmd.Limit, "limit", limit, `Limit users per page`)
-	var offset int
-	cc.Flags().IntVar(&cmd.Offset, "offset", offset, `Number of users to skip`)
-	var order string
-	cc.Flags().StringVar(&cmd.Order, "order", order, `Order by`)
-	var sorting string
-	cc.Flags().StringVar(&cmd.Sorting, "sorting", sorting, ``)
+func (cmd *RevokeInvitationUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var invitationID string
+	cc.Flags().StringVar(&cmd.InvitationID, "invitationId", invitationID, `Invitation ID`)
 }
 
-// Run makes the HTTP request corresponding to the GetMeUserCommand command.
-func (cmd *GetMeUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the RevokeTokenUserCommand command.
+func (cmd *RevokeTokenUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users/me"
+		path = fmt.Sprintf("/users/me/tokens/%v", url.QueryEscape(cmd.TokenID))
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.GetMeUser(ctx, path)
+	resp, err := c.RevokeTokenUser(ctx, path)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -889,18 +2715,20 @@ func (cmd *GetMeUserCommand) Run(c *client.Client, args []string) error {
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *GetMeUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *RevokeTokenUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	var tokenID string
+	cc.Flags().StringVar(&cmd.TokenID, "tokenId", tokenID, `Access token ID`)
 }
 
-// Run makes the HTTP request corresponding to the ResetVerificationTokenUserCommand command.
-func (cmd *ResetVerificationTokenUserCommand) Run(c *client.Client, args []string) error {
+// Run makes the HTTP request corresponding to the SuspendUserCommand command.
+func (cmd *SuspendUserCommand) Run(c *client.Client, args []string) error {
 	var path string
 	if len(args) > 0 {
 		path = args[0]
 	} else {
-		path = "/users/verification/reset"
+		path = fmt.Sprintf("/users/%v/suspend", url.QueryEscape(cmd.UserID))
 	}
-	var payload client.EmailPayload
+	var payload client.StatusChangePayload
 	if cmd.Payload != "" {
 		err := json.Unmarshal([]byte(cmd.Payload), &payload)
 		if err != nil {
@@ -909,7 +2737,7 @@ func (cmd *ResetVerificationTokenUserCommand) Run(c *client.Client, args []strin
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.ResetVerificationTokenUser(ctx, path, &payload, cmd.ContentType)
+	resp, err := c.SuspendUser(ctx, path, &payload, cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -920,9 +2748,46 @@ func (cmd *ResetVerificationTokenUserCommand) Run(c *client.Client, args []strin
 }
 
 // RegisterFlags registers the command flags with the command line.
-func (cmd *ResetVerificationTokenUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+func (cmd *SuspendUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
+}
+
+// Run makes the HTTP request corresponding to the UnlockUserCommand command.
+func (cmd *UnlockUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = fmt.Sprintf("/users/%v/unlock", url.QueryEscape(cmd.UserID))
+	}
+	var payload client.StatusChangePayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.UnlockUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *UnlockUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
 	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
 	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+	var userID string
+	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
 }
 
 // Run makes the HTTP request corresponding to the UpdateUserCommand command.
@@ -942,7 +2807,7 @@ func (cmd *UpdateUserCommand) Run(c *client.Client, args []string) error {
 	}
 	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
 	ctx := goa.WithLogger(context.Background(), logger)
-	resp, err := c.UpdateUser(ctx, path, &payload, cmd.ContentType)
+	resp, err := c.UpdateUser(ctx, path, &payload, stringFlagVal("If-Match", cmd.IfMatch), cmd.ContentType)
 	if err != nil {
 		goa.LogError(ctx, "failed", "err", err)
 		return err
@@ -958,6 +2823,41 @@ func (cmd *UpdateUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client)
 	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
 	var userID string
 	cc.Flags().StringVar(&cmd.UserID, "userId", userID, `User ID`)
+	cc.Flags().StringVar(&cmd.IfMatch, "If-Match", "", `ETag of the user, the update is rejected if the user has been changed since`)
+}
+
+// Run makes the HTTP request corresponding to the UpdateMeUserCommand command.
+func (cmd *UpdateMeUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/me"
+	}
+	var payload client.UpdateMePayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.UpdateMeUser(ctx, path, &payload, stringFlagVal("If-Match", cmd.IfMatch), cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *UpdateMeUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+	cc.Flags().StringVar(&cmd.IfMatch, "If-Match", "", `ETag of the user, the update is rejected if the user has been changed since`)
 }
 
 // Run makes the HTTP request corresponding to the VerifyUserCommand command.
@@ -985,3 +2885,36 @@ func (cmd *VerifyUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client)
 	var token string
 	cc.Flags().StringVar(&cmd.Token, "token", token, `Token`)
 }
+
+// Run makes the HTTP request corresponding to the VerifyMfaUserCommand command.
+func (cmd *VerifyMfaUserCommand) Run(c *client.Client, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "/users/mfa/verify"
+	}
+	var payload client.MFAVerifyPayload
+	if cmd.Payload != "" {
+		err := json.Unmarshal([]byte(cmd.Payload), &payload)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize payload: %s", err)
+		}
+	}
+	logger := goa.NewLogger(log.New(os.Stderr, "", log.LstdFlags))
+	ctx := goa.WithLogger(context.Background(), logger)
+	resp, err := c.VerifyMfaUser(ctx, path, &payload, cmd.ContentType)
+	if err != nil {
+		goa.LogError(ctx, "failed", "err", err)
+		return err
+	}
+
+	goaclient.HandleResponse(c.Client, resp, cmd.PrettyPrint)
+	return nil
+}
+
+// RegisterFlags registers the command flags with the command line.
+func (cmd *VerifyMfaUserCommand) RegisterFlags(cc *cobra.Command, c *client.Client) {
+	cc.Flags().StringVar(&cmd.Payload, "payload", "", "Request body encoded in JSON")
+	cc.Flags().StringVar(&cmd.ContentType, "content", "", "Request content type override, e.g. 'application/x-www-form-urlencoded'")
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// totpPeriod is the TOTP time step, in seconds.
+	totpPeriod = 30
+	// totpDigits is the number of digits of a TOTP code.
+	totpDigits = 6
+	// totpSkew is the number of time steps before and after the current one in which a code is accepted,
+	// to allow for clock drift.
+	totpSkew = 1
+	// totpSecretLength is the length of the TOTP secret, in bytes.
+	totpSecretLength = 20
+	// recoveryCodeLength is the length of a recovery code, in bytes.
+	recoveryCodeLength = 5
+)
+
+// totpEncoding is the base32 encoding (without padding) used for TOTP secrets by the authenticator apps.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateTOTPSecret generates a random base32 encoded TOTP secret.
+func generateTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// totpURI builds the otpauth URI for the secret, used by the authenticator apps to set up the account.
+func totpURI(issuer, account, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", totpDigits))
+	params.Set("period", fmt.Sprintf("%d", totpPeriod))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// totpCode generates the code for the given time step (RFC 6238, HMAC-SHA1).
+func totpCode(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// validateTOTP checks the code against the secret at the given time (in milliseconds). Codes of time steps
+// not after lastCounter are rejected, so that a code cannot be used twice. Returns the time step of the
+// accepted code.
+func validateTOTP(secret, code string, now, lastCounter int64) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now / 1000 / totpPeriod
+	for counter := current - totpSkew; counter <= current+totpSkew; counter++ {
+		if counter <= lastCounter {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, counter)), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+	return 0, false
+}
+
+// generateRecoveryCodes generates n random one-time recovery codes. Returns the codes to be shown to the user
+// and their hashes to be stored.
+func generateRecoveryCodes(n int) ([]string, []string, error) {
+	codes := []string{}
+	hashes := []string{}
+	for i := 0; i < n; i++ {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := hex.EncodeToString(b)
+		code = code[:len(code)/2] + "-" + code[len(code)/2:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode hashes the recovery code. The codes are random, so SHA-256 is sufficient. Dashes,
+// spaces and letter case are ignored.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// useRecoveryCode looks up the code in the hashed recovery codes. Returns the remaining hashes without
+// the used one, and whether the code has been found.
+func useRecoveryCode(hashes []string, code string) ([]string, bool) {
+	hash := []byte(hashRecoveryCode(code))
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), hash) == 1 {
+			remaining := append([]string{}, hashes[:i]...)
+			return append(remaining, hashes[i+1:]...), true
+		}
+	}
+	return hashes, false
+}
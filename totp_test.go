@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 test vectors (SHA1), truncated to 6 digits
+	key := []byte("12345678901234567890")
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for seconds, expected := range vectors {
+		if code := totpCode(key, seconds/totpPeriod); code != expected {
+			t.Errorf("Expected code %s at %d, got %s", expected, seconds, code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := totpEncoding.DecodeString(secret)
+
+	now := int64(1600000000000)
+	current := now / 1000 / totpPeriod
+
+	counter, ok := validateTOTP(secret, totpCode(key, current), now, 0)
+	if !ok || counter != current {
+		t.Fatalf("Expected the current code to be accepted")
+	}
+	if _, ok := validateTOTP(secret, totpCode(key, current-1), now, 0); !ok {
+		t.Error("Expected the previous code to be accepted because of the clock drift")
+	}
+	if _, ok := validateTOTP(secret, totpCode(key, current-2), now, 0); ok {
+		t.Error("Expected an old code to be rejected")
+	}
+	if _, ok := validateTOTP(secret, totpCode(key, current), now, current); ok {
+		t.Error("Expected an already used code to be rejected")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := totpURI("Microkubes", "user@example.com", "SECRET")
+	if !strings.HasPrefix(uri, "otpauth://totp/Microkubes:user@example.com?") {
+		t.Errorf("Unexpected URI %s", uri)
+	}
+	if !strings.Contains(uri, "secret=SECRET") || !strings.Contains(uri, "issuer=Microkubes") {
+		t.Errorf("Expected secret and issuer in the URI, got %s", uri)
+	}
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 3 || len(hashes) != 3 {
+		t.Fatalf("Expected 3 recovery codes, got %d", len(codes))
+	}
+
+	remaining, ok := useRecoveryCode(hashes, strings.ToUpper(codes[1]))
+	if !ok || len(remaining) != 2 {
+		t.Fatal("Expected the recovery code to be accepted regardless of case")
+	}
+	if _, ok := useRecoveryCode(remaining, codes[1]); ok {
+		t.Error("Expected the used recovery code to be rejected")
+	}
+	if len(hashes) != 3 {
+		t.Error("Expected the original hashes to be unchanged")
+	}
+}
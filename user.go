@@ -1,17 +1,21 @@
 package main
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Microkubes/backends"
 	"github.com/Microkubes/microservice-security/auth"
 	"github.com/Microkubes/microservice-tools/rabbitmq"
 	"github.com/Microkubes/microservice-user/app"
+	"github.com/Microkubes/microservice-user/config"
 	"github.com/Microkubes/microservice-user/helpers"
 	"github.com/Microkubes/microservice-user/store"
 	"github.com/keitaroinc/goa"
@@ -21,6 +25,9 @@ import (
 
 const MaxResultsPerPage = 500
 
+// errForbidden is returned when the authenticated user is not allowed to perform an action.
+var errForbidden = goa.NewErrorClass("forbidden", 403)
+
 // Email holds info for the email template
 type Email struct {
 	ID    string `json:"id,omitempty"`
@@ -40,14 +47,25 @@ type UserController struct {
 	*goa.Controller
 	Store           store.User
 	ChannelRabbitMQ rabbitmq.Channel
+	Config          *config.ServiceConfig
+	PasswordPolicy  *PasswordPolicy
+	Passwords       PasswordHasher
+	TokenHasher     *TokenHasher
 }
 
 // NewUserController creates a user controller.
-func NewUserController(service *goa.Service, store store.User, rmqChannel rabbitmq.Channel) *UserController {
+func NewUserController(service *goa.Service, store store.User, rmqChannel rabbitmq.Channel, cfg *config.ServiceConfig, passwordPolicy *PasswordPolicy, passwords PasswordHasher) *UserController {
+	if cfg == nil {
+		cfg = &config.ServiceConfig{}
+	}
 	return &UserController{
 		Controller:      service.NewController("UserController"),
 		Store:           store,
 		ChannelRabbitMQ: rmqChannel,
+		Config:          cfg,
+		PasswordPolicy:  passwordPolicy,
+		Passwords:       passwords,
+		TokenHasher:     NewTokenHasher([]byte(cfg.TokenSecret)),
 	}
 }
 
@@ -61,34 +79,48 @@ func (c *UserController) Create(ctx *app.CreateUserContext) error {
 		ctx.Payload.Roles = append(ctx.Payload.Roles, "user")
 	}
 
-	// Hashing password
-	if ctx.Payload.Password != nil {
-		hashedPassword, err := stringToBcryptHash(*ctx.Payload.Password)
-		if err != nil {
-			return ctx.InternalServerError(goa.ErrInternal(err))
-		}
-
-		ctx.Payload.Password = &hashedPassword
-	}
+	displayEmail := strings.TrimSpace(ctx.Payload.Email)
+	ctx.Payload.Email = normalizeEmail(ctx.Payload.Email)
 
 	user := &store.UserRecord{
-		Active: false,
-		Email:  ctx.Payload.Email,
+		Active:       false,
+		Status:       store.StatusPendingVerification,
+		Email:        ctx.Payload.Email,
+		DisplayEmail: displayEmail,
 		//ExternalID:    ctx.Payload.ExternalID == nil ? "": *ctx.Payload.ExternalID,
 		Namespaces:    ctx.Payload.Namespaces,
 		Organizations: ctx.Payload.Organizations,
-		Password:      *ctx.Payload.Password,
 		Roles:         ctx.Payload.Roles,
 		CreatedAt:     helpers.CurrentTimeMilliseconds(),
+		Version:       1,
 		//Token:         ctx.Payload.Token,
 	}
 
+	// Hashing password
+	if ctx.Payload.Password != nil {
+		if err := c.PasswordPolicy.ValidationError(*ctx.Payload.Password, ctx.Payload.Email); err != nil {
+			return ctx.BadRequest(err)
+		}
+
+		hashedPassword, err := c.Passwords.Hash(*ctx.Payload.Password)
+		if err != nil {
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+
+		user.Password = hashedPassword
+		user.PasswordHistory = c.PasswordPolicy.NextPasswordHistory(hashedPassword, nil)
+	}
+
 	if ctx.Payload.ExternalID != nil {
 		user.ExternalID = *ctx.Payload.ExternalID
 	}
-	if ctx.Payload.Token != nil {
-		user.Token = *ctx.Payload.Token
+	if err := validateProfile(c.Config.GetProfile(), ctx.Payload.Profile); err != nil {
+		if errorStatus(err) == http.StatusBadRequest {
+			return ctx.BadRequest(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
+	user.Profile = ctx.Payload.Profile
 
 	result, err := c.Store.Users.Save(user, nil)
 	if err != nil {
@@ -103,23 +135,26 @@ func (c *UserController) Create(ctx *app.CreateUserContext) error {
 		ctx.Payload.Token = &token
 	}
 
-	tokenPayload := map[string]interface{}{
-		"email": ctx.Payload.Email,
-		"token": ctx.Payload.Token,
-	}
-
-	_, err = c.Store.Tokens.Save(&tokenPayload, nil)
+	_, err = c.saveVerificationToken(ctx.Payload.Email, *ctx.Payload.Token)
 	if err != nil {
 		return ctx.InternalServerError(err)
 	}
 
-	return ctx.Created(result.(*store.UserRecord).ToAppUsers())
+	created := result.(*store.UserRecord)
+
+	// The user has been created, so failing to send the email is not reported to the caller. A new
+	// verification email can be requested with ResetVerificationToken.
+	if err = c.sendVerificationEmail(created.ID.Hex(), ctx.Payload.Email, *ctx.Payload.Token); err != nil {
+		c.Service.LogError("User: failed to send verification email.", "err", err.Error())
+	}
+
+	return ctx.Created(c.userMedia(ctx, created))
 }
 
 // Get runs the get action.
 func (c *UserController) Get(ctx *app.GetUserContext) error {
 
-	user := &app.Users{}
+	user := &store.UserRecord{}
 
 	// Return one user by id.
 	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.UserID), user); err != nil {
@@ -132,7 +167,12 @@ func (c *UserController) Get(ctx *app.GetUserContext) error {
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	return ctx.OK(user)
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	ctx.ResponseData.Header().Set("ETag", userETag(user))
+	return ctx.OK(c.userMedia(ctx, user))
 }
 
 // GetMe runs the getMe action.
@@ -145,7 +185,7 @@ func (c *UserController) GetMe(ctx *app.GetMeUserContext) error {
 
 	userID := auth.GetAuth(ctx.Context).UserID
 
-	user := &app.Users{}
+	user := &store.UserRecord{}
 	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", userID), user); err != nil {
 		if backends.IsErrNotFound(err) {
 			return ctx.NotFound(goa.ErrNotFound(err))
@@ -156,7 +196,67 @@ func (c *UserController) GetMe(ctx *app.GetMeUserContext) error {
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	return ctx.OK(user)
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	ctx.ResponseData.Header().Set("ETag", userETag(user))
+	return ctx.OK(c.userMedia(ctx, user))
+}
+
+// GetLogins returns the login history of a user, the most recent first.
+func (c *UserController) GetLogins(ctx *app.GetLoginsUserContext) error {
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	limit, offset := 0, 0
+	if ctx.Limit != nil {
+		limit = *ctx.Limit
+	}
+	if ctx.Offset != nil {
+		offset = *ctx.Offset
+	}
+
+	logins, err := c.loginHistory(ctx.UserID, limit, offset)
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK(toAppLogins(logins))
+}
+
+// GetMyLogins returns the login history of the authenticated user, the most recent first.
+func (c *UserController) GetMyLogins(ctx *app.GetMyLoginsUserContext) error {
+
+	if !auth.HasAuth(ctx.Context) {
+		return ctx.InternalServerError(goa.ErrBadRequest("no-auth"))
+	}
+
+	userID := auth.GetAuth(ctx.Context).UserID
+
+	limit, offset := 0, 0
+	if ctx.Limit != nil {
+		limit = *ctx.Limit
+	}
+	if ctx.Offset != nil {
+		offset = *ctx.Offset
+	}
+
+	logins, err := c.loginHistory(userID, limit, offset)
+	if err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK(toAppLogins(logins))
 }
 
 //GetAll retrives all active users
@@ -196,7 +296,32 @@ func (c *UserController) GetAll(ctx *app.GetAllUserContext) error {
 		return ctx.InternalServerError(goa.ErrInternal(err.Error()))
 	}
 
-	resp, err := json.Marshal(users)
+	var records []map[string]interface{}
+	if err = backends.MapToInterface(users, &records); err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err.Error()))
+	}
+
+	activeUsers := []map[string]interface{}{}
+	for _, record := range records {
+		user := &store.UserRecord{}
+		if err = backends.MapToInterface(record, user); err != nil {
+			return ctx.InternalServerError(goa.ErrInternal(err.Error()))
+		}
+		if user.CurrentStatus() != store.StatusActive {
+			continue
+		}
+		// never expose the password hashes and the MFA secrets
+		for _, field := range store.PrivateFields {
+			delete(record, field)
+		}
+		delete(record, "profile")
+		if profile := c.visibleProfile(ctx, user); len(profile) > 0 {
+			record["profile"] = profile
+		}
+		activeUsers = append(activeUsers, record)
+	}
+
+	resp, err := json.Marshal(activeUsers)
 	if err != nil {
 		return ctx.InternalServerError(goa.ErrInternal(err.Error()))
 	}
@@ -207,40 +332,210 @@ func (c *UserController) GetAll(ctx *app.GetAllUserContext) error {
 // Update runs the update action.
 func (c *UserController) Update(ctx *app.UpdateUserContext) error {
 
-	payload := map[string]interface{}{}
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
 
-	payload["active"] = ctx.Payload.Active
-	payload["modifiedAt"] = helpers.CurrentTimeMilliseconds()
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
 
-	if ctx.Payload.Email != nil {
-		payload["email"] = ctx.Payload.Email
+	if ctx.IfMatch != nil && !matchesETag(*ctx.IfMatch, user) {
+		return ctx.PreconditionFailed(errPreconditionFailed("the user has been changed", "etag", userETag(user)))
 	}
-	if ctx.Payload.ExternalID != nil {
-		payload["externalId"] = ctx.Payload.ExternalID
+
+	changes := &userChanges{
+		Active:        &ctx.Payload.Active,
+		Email:         ctx.Payload.Email,
+		Password:      ctx.Payload.Password,
+		ExternalID:    ctx.Payload.ExternalID,
+		Roles:         ctx.Payload.Roles,
+		Organizations: ctx.Payload.Organizations,
+		Namespaces:    ctx.Payload.Namespaces,
+		Profile:       ctx.Payload.Profile,
+	}
+	payload, err := c.userUpdate(ctx, user, changes)
+	if err != nil {
+		switch errorStatus(err) {
+		case http.StatusBadRequest:
+			return ctx.BadRequest(err)
+		case http.StatusForbidden:
+			return ctx.Forbidden(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	if ctx.Payload.Password != nil && *ctx.Payload.Password != "" {
-		hashedPassword, err := stringToBcryptHash(*ctx.Payload.Password)
+	updated, err := c.saveUser(user, payload)
+	if err != nil {
+		if err == errVersionConflict {
+			return ctx.PreconditionFailed(errPreconditionFailed(err))
+		}
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	ctx.ResponseData.Header().Set("ETag", userETag(updated))
+	return ctx.OK(c.userMedia(ctx, updated))
+}
+
+// userChanges holds the requested changes of a user. The nil fields are left as they are.
+type userChanges struct {
+	Active        *bool
+	Email         *string
+	Password      *string
+	ExternalID    *string
+	Roles         []string
+	Organizations []string
+	Namespaces    []string
+	// Profile replaces the profile of the user
+	Profile map[string]interface{}
+	// ProfilePatch is merged into the profile of the user
+	ProfilePatch map[string]interface{}
+}
+
+// profile returns the profile of the user after the changes, or nil if the profile is not changed.
+func (changes *userChanges) profile(user *store.UserRecord) map[string]interface{} {
+	if changes.Profile != nil {
+		return changes.Profile
+	}
+	if changes.ProfilePatch != nil {
+		return mergeProfile(user.Profile, changes.ProfilePatch)
+	}
+	return nil
+}
+
+// userUpdate validates the changes of the user against the field policy and the field rules, and returns the
+// fields to save. Changes of fields the caller may not change give a forbidden error listing the fields, invalid
+// changes give a bad request error.
+func (c *UserController) userUpdate(ctx context.Context, user *store.UserRecord, changes *userChanges) (map[string]interface{}, error) {
+	if rejected := c.rejectedFields(ctx, user, changedFields(user, changes)); len(rejected) > 0 {
+		return nil, errForbidden("not allowed to change the fields", "fields", rejected)
+	}
+
+	now := helpers.CurrentTimeMilliseconds()
+	update := map[string]interface{}{}
+
+	// The "active" flag is mapped to a status transition: activating an inactive user
+	// and deactivating an active one. Otherwise the status is left as is.
+	if changes.Active != nil {
+		status := ""
+		if *changes.Active && user.CurrentStatus() != store.StatusActive {
+			status = store.StatusActive
+		} else if !*changes.Active && user.CurrentStatus() == store.StatusActive {
+			status = store.StatusDeactivated
+		}
+		if status != "" {
+			statusUpdate, err := user.StatusUpdate(status, "user updated", actorID(ctx), now)
+			if err != nil {
+				return nil, goa.ErrBadRequest(err)
+			}
+			update = statusUpdate
+		}
+	}
+
+	update["modifiedAt"] = now
+
+	// The email is changed only after the new address is verified, see RequestEmailChange.
+	if changes.Email != nil && normalizeEmail(*changes.Email) != user.Email {
+		return nil, goa.ErrBadRequest("the email can only be changed with an email change request")
+	}
+	if changes.ExternalID != nil {
+		update["externalId"] = *changes.ExternalID
+	}
+
+	if changes.Password != nil && *changes.Password != "" {
+		if err := c.PasswordPolicy.ValidationError(*changes.Password, user.Email); err != nil {
+			return nil, err
+		}
+		if err := c.PasswordPolicy.ReuseError(*changes.Password, user, c.Passwords); err != nil {
+			return nil, err
+		}
+
+		hashedPassword, err := c.Passwords.Hash(*changes.Password)
 		if err != nil {
-			return ctx.InternalServerError(goa.ErrInternal(err))
+			return nil, err
 		}
-		payload["password"] = hashedPassword
+		update["password"] = hashedPassword
+		update["passwordHistory"] = c.PasswordPolicy.NextPasswordHistory(hashedPassword, user.PasswordHistory)
 	}
 
-	if ctx.Payload.Roles != nil {
-		payload["roles"] = ctx.Payload.Roles
+	if changes.Roles != nil {
+		update["roles"] = changes.Roles
 	}
 
-	if ctx.Payload.Organizations != nil {
-		payload["organizations"] = ctx.Payload.Organizations
+	if changes.Organizations != nil {
+		update["organizations"] = changes.Organizations
 	}
 
-	if ctx.Payload.Namespaces != nil {
-		payload["namespaces"] = ctx.Payload.Namespaces
+	if changes.Namespaces != nil {
+		update["namespaces"] = changes.Namespaces
 	}
 
-	result, err := c.Store.Users.Save(&payload, backends.NewFilter().Match("id", ctx.UserID))
+	if profile := changes.profile(user); profile != nil {
+		if err := validateProfile(c.Config.GetProfile(), profile); err != nil {
+			return nil, err
+		}
+		update["profile"] = profile
+	}
 
+	return update, nil
+}
+
+// errorStatus returns the HTTP status of the error, internal server error for errors other than goa errors.
+func errorStatus(err error) int {
+	if serviceErr, ok := err.(goa.ServiceError); ok {
+		return serviceErr.ResponseStatus()
+	}
+	return http.StatusInternalServerError
+}
+
+// Delete runs the delete action. The user is soft-deleted - the record is kept in the store,
+// but it is hidden from all lookups until restored.
+func (c *UserController) Delete(ctx *app.DeleteUserContext) error {
+	if _, err := c.changeStatus(ctx, ctx.UserID, store.StatusDeleted, "user deleted"); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.NoContent()
+}
+
+// Restore runs the restore action. Restores a previously soft-deleted user back to the
+// status the user had before being deleted.
+func (c *UserController) Restore(ctx *app.RestoreUserContext) error {
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if !user.IsDeleted() {
+		return ctx.BadRequest(goa.ErrBadRequest("user is not deleted"))
+	}
+
+	restored, err := c.saveStatus(user, user.StatusBefore(store.StatusDeleted), "user restored", actorID(ctx))
 	if err != nil {
 		if backends.IsErrNotFound(err) {
 			return ctx.NotFound(goa.ErrNotFound(err))
@@ -251,12 +546,133 @@ func (c *UserController) Update(ctx *app.UpdateUserContext) error {
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	user := &app.Users{}
-	if err = backends.MapToInterface(result, user); err != nil {
+	return ctx.OK(c.userMedia(ctx, restored))
+}
+
+// Suspend runs the suspend action.
+func (c *UserController) Suspend(ctx *app.SuspendUserContext) error {
+	user, err := c.changeStatus(ctx, ctx.UserID, store.StatusSuspended, statusChangeReason(ctx.Payload))
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK(c.userMedia(ctx, user))
+}
+
+// Reactivate runs the reactivate action. Only suspended, locked or deactivated users can be reactivated.
+func (c *UserController) Reactivate(ctx *app.ReactivateUserContext) error {
+	user, err := c.changeStatus(ctx, ctx.UserID, store.StatusActive, statusChangeReason(ctx.Payload),
+		store.StatusSuspended, store.StatusLocked, store.StatusDeactivated)
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK(c.userMedia(ctx, user))
+}
+
+// Unlock runs the unlock action. Unlocks a user locked after too many failed logins and moves it back
+// to the status it had before being locked.
+func (c *UserController) Unlock(ctx *app.UnlockUserContext) error {
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	if user.CurrentStatus() != store.StatusLocked {
+		return ctx.BadRequest(goa.ErrBadRequest("user is not locked"))
+	}
+
+	unlocked, err := c.saveStatus(user, user.StatusBefore(store.StatusLocked), statusChangeReason(ctx.Payload), actorID(ctx))
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	return ctx.OK(user)
+	return ctx.OK(c.userMedia(ctx, unlocked))
+}
+
+// Deactivate runs the deactivate action.
+func (c *UserController) Deactivate(ctx *app.DeactivateUserContext) error {
+	user, err := c.changeStatus(ctx, ctx.UserID, store.StatusDeactivated, statusChangeReason(ctx.Payload))
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK(c.userMedia(ctx, user))
+}
+
+// Purge runs the purge action. Permanently removes the user (deleted or not) along with
+// all tokens issued for that user. Only admin and system users may purge users.
+func (c *UserController) Purge(ctx *app.PurgeUserContext) error {
+	if !hasAnyRole(auth.GetAuth(ctx), "admin", "system") {
+		return ctx.Forbidden(errForbidden("only admin can purge users"))
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", ctx.UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if err := c.purgeUser(user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.NoContent()
+}
+
+// purgeUser permanently removes the user along with the verification tokens and the access tokens of the user.
+func (c *UserController) purgeUser(user *store.UserRecord) error {
+	if err := c.Store.Tokens.DeleteAll(backends.NewFilter().Match("email", user.Email)); err != nil && !backends.IsErrNotFound(err) {
+		return err
+	}
+
+	if err := c.Store.AccessTokens.DeleteAll(backends.NewFilter().Match("userId", user.ID.Hex())); err != nil && !backends.IsErrNotFound(err) {
+		return err
+	}
+
+	return c.Store.Users.DeleteOne(backends.NewFilter().Match("id", user.ID.Hex()))
 }
 
 // FindUsers find users matching a filter
@@ -268,22 +684,22 @@ func (c *UserController) FindUsers(ctx *app.FindUsersUserContext) error {
 	}
 
 	page := ctx.Payload.Page
-	if page < 1 {
+	if page < 1 && ctx.Payload.Cursor == nil {
 		return ctx.BadRequest(goa.ErrBadRequest("invalid page number"))
 	}
-	page--
 	pageSize := ctx.Payload.PageSize
+	if pageSize < 1 {
+		return ctx.BadRequest(goa.ErrBadRequest("invalid page size"))
+	}
 	if pageSize > MaxResultsPerPage {
 		pageSize = MaxResultsPerPage
 	}
 
-	var bf backends.Filter
-	if ctx.Payload.Filter != nil && len(ctx.Payload.Filter) > 0 {
-		bf = backends.NewFilter()
-		for _, filterProp := range ctx.Payload.Filter {
-			bf.Match(filterProp.Property, filterProp.Value)
-		}
+	filter, err := c.compileUserFilter(ctx, ctx.Payload)
+	if err != nil {
+		return ctx.BadRequest(err)
 	}
+	filter.ActiveOnly = true
 
 	sortBy := "createdAt"
 	sortDir := "asc"
@@ -291,30 +707,55 @@ func (c *UserController) FindUsers(ctx *app.FindUsersUserContext) error {
 	if ctx.Payload.Sort != nil {
 		sortBy = ctx.Payload.Sort.Property
 		sortDir = ctx.Payload.Sort.Direction
-	}
-
-	result, err := c.Store.Users.GetAll(bf, &store.UserRecord{}, sortBy, sortDir, pageSize, page)
-	if err != nil {
-		if backends.IsErrInvalidInput(err) {
-			return ctx.BadRequest(goa.ErrBadRequest(err))
+		if err = c.checkSortField(ctx, sortBy); err != nil {
+			return ctx.BadRequest(err)
 		}
-		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	users := *(result.(*[]*store.UserRecord))
-
 	usersPage := &app.UsersPage{
-		Page:     &page,
 		PageSize: &pageSize,
 		Items:    []*app.Users{},
 	}
 
-	for _, userItem := range users {
-		user := userItem
-		if !user.Active {
-			continue
+	var users []*store.UserRecord
+	var total int
+	if ctx.Payload.Cursor != nil {
+		if ctx.Payload.Sort != nil && sortBy != "id" {
+			return ctx.BadRequest(goa.ErrBadRequest("cursor mode is sorted by id"))
+		}
+		cursor, err := decodeCursor(*ctx.Payload.Cursor, sortDir == "desc")
+		if err != nil {
+			return ctx.BadRequest(err)
+		}
+		// one more user tells whether there is a next page
+		users, total, err = c.listUsers(filter, cursor.condition(), "id", cursor.direction(), pageSize+1, 0, ctx.Payload.IncludeTotal)
+		if err != nil {
+			if backends.IsErrInvalidInput(err) {
+				return ctx.BadRequest(goa.ErrBadRequest(err))
+			}
+			return ctx.InternalServerError(goa.ErrInternal(err))
 		}
-		usersPage.Items = append(usersPage.Items, user.ToAppUsers())
+		if len(users) > pageSize {
+			users = users[:pageSize]
+			nextCursor := encodeCursor(&usersCursor{After: users[pageSize-1].ID.Hex(), Desc: cursor.Desc})
+			usersPage.NextCursor = &nextCursor
+		}
+	} else {
+		users, total, err = c.listUsers(filter, nil, sortBy, sortDir, pageSize, (page-1)*pageSize, ctx.Payload.IncludeTotal)
+		if err != nil {
+			if backends.IsErrInvalidInput(err) {
+				return ctx.BadRequest(goa.ErrBadRequest(err))
+			}
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+		usersPage.Page = &page
+	}
+	if ctx.Payload.IncludeTotal {
+		usersPage.Total = &total
+	}
+
+	for _, user := range users {
+		usersPage.Items = append(usersPage.Items, c.userMedia(ctx, user))
 	}
 
 	return ctx.OK(usersPage)
@@ -322,9 +763,10 @@ func (c *UserController) FindUsers(ctx *app.FindUsersUserContext) error {
 
 // Find looks up a user by its email and password. Intended for internal use.
 func (c *UserController) Find(ctx *app.FindUserContext) error {
+	ctx.Payload.Email = normalizeEmail(ctx.Payload.Email)
 
-	var userData map[string]interface{}
-	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("email", ctx.Payload.Email), &userData); err != nil {
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("email", ctx.Payload.Email), user); err != nil {
 		if backends.IsErrNotFound(err) {
 			return ctx.NotFound(goa.ErrNotFound(err))
 		}
@@ -335,27 +777,58 @@ func (c *UserController) Find(ctx *app.FindUserContext) error {
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	if _, ok := userData["password"]; !ok {
+	if user.Password == "" {
 		return ctx.NotFound(goa.ErrNotFound("not found"))
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(userData["password"].(string)), []byte(ctx.Payload.Password)); err != nil {
-		fmt.Println(ctx.Payload.Password)
+	now := helpers.CurrentTimeMilliseconds()
+	if isLocked(user, now) {
+		c.logLogin(ctx.Request, user.ID.Hex(), store.LoginLocked, now)
+		return ctx.Locked(errLocked("account is locked", "lockedUntil", user.LockedUntil))
+	}
+
+	// Users with expired lock are allowed to try again.
+	if !user.CanLogin() && user.CurrentStatus() != store.StatusLocked {
 		return ctx.NotFound(goa.ErrNotFound("not found"))
 	}
 
-	user := &app.Users{}
-	if err := backends.MapToInterface(userData, user); err != nil {
-		return ctx.InternalServerError(goa.ErrInternal(err))
+	if err := c.Passwords.Compare(user.Password, ctx.Payload.Password); err != nil {
+		locked, err := c.recordFailedLogin(user, now)
+		if err != nil {
+			c.Service.LogError("User: failed to record failed login.", "err", err.Error())
+		}
+		if locked {
+			c.logLogin(ctx.Request, user.ID.Hex(), store.LoginLocked, now)
+			return ctx.Locked(errLocked("account is locked"))
+		}
+		c.logLogin(ctx.Request, user.ID.Hex(), store.LoginFailure, now)
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	// The login of users with MFA enabled is successful only once the second factor is verified with VerifyMfa.
+	if !user.MFAEnabled {
+		if err := c.recordSuccessfulLogin(user, now); err != nil {
+			c.Service.LogError("User: failed to record successful login.", "err", err.Error())
+		}
+		c.logLogin(ctx.Request, user.ID.Hex(), store.LoginSuccess, now)
+	}
+
+	// Upgrade the stored hash to the current algorithm and parameters. The login is
+	// successful regardless of the outcome.
+	if c.Passwords.NeedsRehash(user.Password) {
+		if err := c.rehashPassword(user, ctx.Payload.Password); err != nil {
+			c.Service.LogError("User: failed to rehash password.", "err", err.Error())
+		}
 	}
 
-	return ctx.OK(user)
+	return ctx.OK(c.userMedia(ctx, user))
 }
 
 // FindByEmail looks up a user by its email.
 func (c *UserController) FindByEmail(ctx *app.FindByEmailUserContext) error {
+	ctx.Payload.Email = normalizeEmail(ctx.Payload.Email)
 
-	user := &app.Users{}
+	user := &store.UserRecord{}
 	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("email", ctx.Payload.Email), user); err != nil {
 		if backends.IsErrNotFound(err) {
 			return ctx.NotFound(goa.ErrNotFound(err))
@@ -363,7 +836,11 @@ func (c *UserController) FindByEmail(ctx *app.FindByEmailUserContext) error {
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	return ctx.OK(user)
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	return ctx.OK(c.userMedia(ctx, user))
 }
 
 // Verify performs verification of the given token and activates the user for which
@@ -374,8 +851,10 @@ func (c *UserController) Verify(ctx *app.VerifyUserContext) error {
 		return ctx.BadRequest(goa.ErrBadRequest("token is missing from the payload"))
 	}
 
-	user := &app.Users{}
-	_, err := c.Store.Tokens.GetOne(backends.NewFilter().Match("token", *ctx.Token), user)
+	tokenHash := c.TokenHasher.Hash(*ctx.Token)
+
+	token := &store.TokenRecord{}
+	_, err := c.Store.Tokens.GetOne(backends.NewFilter().Match("token", tokenHash), token)
 	if err != nil {
 		if backends.IsErrNotFound(err) {
 			return ctx.NotFound(goa.ErrNotFound(err))
@@ -383,19 +862,46 @@ func (c *UserController) Verify(ctx *app.VerifyUserContext) error {
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	update := map[string]interface{}{
-		"active":     true,
-		"modifiedAt": helpers.CurrentTimeMilliseconds(),
+	if !c.TokenHasher.Matches(token.Token, *ctx.Token) {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
 	}
-	_, err = c.Store.Users.Save(&update, backends.NewFilter().Match("email", user.Email))
-	if err != nil {
+
+	if token.IsExpired(helpers.CurrentTimeMilliseconds()) {
+		if err = c.Store.Tokens.DeleteOne(backends.NewFilter().Match("token", tokenHash)); err != nil && !backends.IsErrNotFound(err) {
+			c.Service.LogError("User: failed to delete expired verification token.", "err", err.Error())
+		}
+		return ctx.BadRequest(goa.ErrBadRequest("verification token has expired"))
+	}
+
+	userRecord := &store.UserRecord{}
+	if _, err = c.Store.Users.GetOne(backends.NewFilter().Match("email", normalizeEmail(token.Email)), userRecord); err != nil {
 		if backends.IsErrNotFound(err) {
 			return ctx.NotFound(goa.ErrNotFound(err))
 		}
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	err = c.Store.Tokens.DeleteOne(backends.NewFilter().Match("token", *ctx.Token))
+	// Verification only activates accounts waiting for it, so that an old link cannot reactivate a
+	// suspended, deactivated or deleted account.
+	switch userRecord.CurrentStatus() {
+	case store.StatusActive:
+	case store.StatusPendingVerification:
+		if _, err = c.saveStatus(userRecord, store.StatusActive, "email verified", userRecord.ID.Hex()); err != nil {
+			if backends.IsErrNotFound(err) {
+				return ctx.NotFound(goa.ErrNotFound(err))
+			}
+			if backends.IsErrInvalidInput(err) {
+				return ctx.BadRequest(goa.ErrBadRequest(err))
+			}
+			return ctx.InternalServerError(goa.ErrInternal(err))
+		}
+	case store.StatusDeleted:
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	default:
+		return ctx.BadRequest(goa.ErrBadRequest("the account cannot be verified"))
+	}
+
+	err = c.Store.Tokens.DeleteOne(backends.NewFilter().Match("token", tokenHash))
 	if err != nil {
 		if backends.IsErrNotFound(err) {
 			return ctx.NotFound(goa.ErrNotFound(err))
@@ -411,8 +917,9 @@ func (c *UserController) Verify(ctx *app.VerifyUserContext) error {
 // ResetVerificationToken resets a verification token for a given user (by email). Generates a new value for the token
 // and resets the expiration time for the token.
 func (c *UserController) ResetVerificationToken(ctx *app.ResetVerificationTokenUserContext) error {
+	ctx.Payload.Email = normalizeEmail(ctx.Payload.Email)
 
-	user := &app.Users{}
+	user := &store.UserRecord{}
 	_, err := c.Store.Users.GetOne(backends.NewFilter().Match("email", ctx.Payload.Email), user)
 	if err != nil {
 		if backends.IsErrNotFound(err) {
@@ -428,22 +935,27 @@ func (c *UserController) ResetVerificationToken(ctx *app.ResetVerificationTokenU
 		return ctx.NotFound(fmt.Errorf("not-found"))
 	}
 
-	if user.Active {
-		return ctx.BadRequest(goa.ErrBadRequest("already active"))
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
 	}
 
-	if err := c.Store.Tokens.DeleteOne(backends.NewFilter().Match("email", ctx.Payload.Email)); err != nil {
-		return ctx.InternalServerError(goa.ErrInternal(err))
+	if user.CurrentStatus() != store.StatusPendingVerification {
+		return ctx.BadRequest(goa.ErrBadRequest("already active"))
 	}
 
-	token := generateToken(42)
+	// Only the hash of the new token is stored, so the token must be either sent or returned. The current
+	// token is kept if it can be neither.
+	externalEmail := c.Config.GetVerificationToken().ExternalEmail
+	if !externalEmail && c.ChannelRabbitMQ == nil {
+		return ctx.InternalServerError(goa.ErrInternal("no mail channel to send the verification token"))
+	}
 
-	tokenPayload := map[string]interface{}{
-		"email": ctx.Payload.Email,
-		"token": token,
+	if err := c.Store.Tokens.DeleteAll(backends.NewFilter().Match("email", ctx.Payload.Email)); err != nil && !backends.IsErrNotFound(err) {
+		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	result, err := c.Store.Tokens.Save(&tokenPayload, nil)
+	token := generateToken(42)
+	result, err := c.saveVerificationToken(ctx.Payload.Email, token)
 	if err != nil {
 		return ctx.InternalServerError(err)
 	}
@@ -452,12 +964,122 @@ func (c *UserController) ResetVerificationToken(ctx *app.ResetVerificationTokenU
 	if err = backends.MapToInterface(result, resetToken); err != nil {
 		return ctx.InternalServerError(err)
 	}
+	resetToken.Token = nil
+
+	if err = c.sendVerificationEmail(user.ID.Hex(), ctx.Payload.Email, token); err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	// The token is returned to callers that send their own email.
+	if externalEmail {
+		resetToken.Token = &token
+	}
 
 	return ctx.OK(resetToken)
 }
 
+// ChangePassword changes the password of the authenticated user. The current password must be provided.
+func (c *UserController) ChangePassword(ctx *app.ChangePasswordUserContext) error {
+	if !auth.HasAuth(ctx.Context) {
+		return ctx.InternalServerError(goa.ErrBadRequest("no-auth"))
+	}
+
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", auth.GetAuth(ctx.Context).UserID), user); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if backends.IsErrInvalidInput(err) {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	if user.IsDeleted() {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
+	}
+
+	if err := c.checkCurrentPassword(user, ctx.Payload.CurrentPassword); err != nil {
+		return ctx.Forbidden(err)
+	}
+
+	if _, err := c.changeUserPassword(user, ctx.Payload.NewPassword); err != nil {
+		if backends.IsErrNotFound(err) {
+			return ctx.NotFound(goa.ErrNotFound(err))
+		}
+		if errorStatus(err) == http.StatusBadRequest {
+			return ctx.BadRequest(err)
+		}
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
+
+	return ctx.OK([]byte{})
+}
+
+// checkCurrentPassword returns a forbidden error if the password is not the current password of the user.
+func (c *UserController) checkCurrentPassword(user *store.UserRecord, password string) error {
+	if user.Password == "" || c.Passwords.Compare(user.Password, password) != nil {
+		return errForbidden("invalid current password")
+	}
+	return nil
+}
+
+// changeUserPassword sets a new password of the user and notifies the user by email. Passwords that do not
+// satisfy the password policy give a bad request error.
+func (c *UserController) changeUserPassword(user *store.UserRecord, password string) (*store.UserRecord, error) {
+	update, err := c.passwordUpdate(user, password)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := c.saveUser(user, update)
+	if err != nil {
+		return nil, err
+	}
+
+	c.notifyPasswordChanged(user)
+	return updated, nil
+}
+
+// passwordUpdate checks the new password of the user against the password policy and returns the fields to
+// save.
+func (c *UserController) passwordUpdate(user *store.UserRecord, password string) (map[string]interface{}, error) {
+	if err := c.PasswordPolicy.ValidationError(password, user.Email); err != nil {
+		return nil, err
+	}
+	if err := c.PasswordPolicy.ReuseError(password, user, c.Passwords); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := c.Passwords.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Any outstanding forgot-password token is invalidated along with the old password.
+	return map[string]interface{}{
+		"password":             hashedPassword,
+		"passwordHistory":      c.PasswordPolicy.NextPasswordHistory(hashedPassword, user.PasswordHistory),
+		"forgotPasswordTokens": store.FPToken{},
+		"modifiedAt":           helpers.CurrentTimeMilliseconds(),
+	}, nil
+}
+
+// notifyPasswordChanged notifies the user that the password has been changed. The password has been changed
+// at this point, so failing to notify the user is only logged.
+func (c *UserController) notifyPasswordChanged(user *store.UserRecord) {
+	messageData := map[string]string{
+		"name":  "User",
+		"email": user.Email,
+	}
+	if err := c.sendEmail(user.Email, "passwordChanged", messageData); err != nil {
+		c.Service.LogError("User: failed to send password changed email.", "err", err.Error())
+	}
+}
+
 // ForgotPassword is used for verifying user and sending mail with generated token
 func (c *UserController) ForgotPassword(ctx *app.ForgotPasswordUserContext) error {
+	ctx.Payload.Email = normalizeEmail(ctx.Payload.Email)
+
 	userRecord := &store.UserRecord{}
 	_, err := c.Store.Users.GetOne(backends.NewFilter().Match("email", ctx.Payload.Email), userRecord)
 	if err != nil {
@@ -469,36 +1091,32 @@ func (c *UserController) ForgotPassword(ctx *app.ForgotPasswordUserContext) erro
 		}
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
-	fpToken := store.FPToken{}
-	fpToken.Token = generateToken(42)
-	fpToken.ExpDate = generateExpDate()
-	userRecord.FPToken = fpToken
-	userRecord.ModifiedAt = helpers.CurrentTimeMilliseconds()
-	_, err = c.Store.Users.Save(userRecord, backends.NewFilter().Match("id", userRecord.ID.Hex()))
-	if err != nil {
-		return ctx.InternalServerError(goa.ErrInternal(err))
+
+	// Users that cannot log in get the same answer as unknown emails. Users with expired lock are allowed to
+	// reset their password, as they are allowed to log in.
+	if userRecord.IsDeleted() || (!userRecord.CanLogin() && userRecord.CurrentStatus() != store.StatusLocked) {
+		return ctx.OK([]byte{})
 	}
 
-	if c.ChannelRabbitMQ != nil {
-		messageData := map[string]string{
-			"name":  "User",
-			"email": ctx.Payload.Email,
-			"token": fpToken.Token,
-		}
-		amqpMessage := AMQPMessage{
-			Email:        userRecord.Email,
-			Data:         messageData,
-			TemplateName: "forgotPassword",
-		}
-		body, err := json.Marshal(amqpMessage)
+	token := generateToken(42)
+	update := map[string]interface{}{
+		"forgotPasswordTokens": store.FPToken{
+			Token:   c.TokenHasher.Hash(token),
+			ExpDate: generateExpDate(),
+		},
+		"modifiedAt": helpers.CurrentTimeMilliseconds(),
+	}
+	if _, err = c.saveUser(userRecord, update); err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
+	}
 
-		if err != nil {
-			c.Service.LogError("User: failed to serialize AMQPMessage.", "err", err.Error())
-		}
-		if err := c.ChannelRabbitMQ.Send("email-queue", body); err != nil {
-			c.Service.LogError("User: failed to send message on AMQP Channel.", "err", err.Error())
-			return ctx.InternalServerError(goa.ErrInternal(err))
-		}
+	messageData := map[string]string{
+		"name":  "User",
+		"email": ctx.Payload.Email,
+		"token": token,
+	}
+	if err := c.sendEmail(userRecord.Email, "forgotPassword", messageData); err != nil {
+		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 	return ctx.OK([]byte{})
 }
@@ -506,10 +1124,10 @@ func (c *UserController) ForgotPassword(ctx *app.ForgotPasswordUserContext) erro
 // ForgotPasswordUpdate endpoint for changing old password with new one
 func (c *UserController) ForgotPasswordUpdate(ctx *app.ForgotPasswordUpdateUserContext) error {
 	userRecord := &store.UserRecord{}
-	_, err := c.Store.Users.GetOne(backends.NewFilter().Match("forgotPasswordTokens.token", ctx.Payload.Token), userRecord)
+	_, err := c.Store.Users.GetOne(backends.NewFilter().Match("forgotPasswordTokens.token", c.TokenHasher.Hash(ctx.Payload.Token)), userRecord)
 	if err != nil {
 		if backends.IsErrNotFound(err) {
-			return ctx.OK([]byte{})
+			return ctx.NotFound(goa.ErrNotFound(err))
 		}
 		if backends.IsErrInvalidInput(err) {
 			return ctx.BadRequest(goa.ErrBadRequest(err))
@@ -517,30 +1135,148 @@ func (c *UserController) ForgotPasswordUpdate(ctx *app.ForgotPasswordUpdateUserC
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
-	if userRecord.FPToken.Token != ctx.Payload.Token {
-		return ctx.BadRequest(goa.ErrBadRequest(err))
+	if userRecord.IsDeleted() || (!userRecord.CanLogin() && userRecord.CurrentStatus() != store.StatusLocked) {
+		return ctx.NotFound(goa.ErrNotFound("not found"))
 	}
-	if !checkExpDate(userRecord.FPToken.ExpDate) {
-		return ctx.BadRequest(goa.ErrBadRequest(err))
+
+	if !c.TokenHasher.Matches(userRecord.FPToken.Token, ctx.Payload.Token) || !checkExpDate(userRecord.FPToken.ExpDate) {
+		return ctx.BadRequest(goa.ErrBadRequest("invalid or expired token"))
 	}
 
-	hashedPassword, err := stringToBcryptHash(ctx.Payload.Password)
+	if err := c.PasswordPolicy.ValidationError(ctx.Payload.Password, userRecord.Email); err != nil {
+		return ctx.BadRequest(err)
+	}
+	if err := c.PasswordPolicy.ReuseError(ctx.Payload.Password, userRecord, c.Passwords); err != nil {
+		return ctx.BadRequest(err)
+	}
+
+	hashedPassword, err := c.Passwords.Hash(ctx.Payload.Password)
 	if err != nil {
 		return ctx.BadRequest(goa.ErrBadRequest(err))
 	}
 
-	userRecord.FPToken.ExpDate = "0"
-	userRecord.Password = hashedPassword
-	userRecord.ModifiedAt = helpers.CurrentTimeMilliseconds()
-
-	_, err = c.Store.Users.Save(userRecord, backends.NewFilter().Match("id", userRecord.ID.Hex()))
-	if err != nil {
+	update := map[string]interface{}{
+		"forgotPasswordTokens": store.FPToken{
+			Token:   userRecord.FPToken.Token,
+			ExpDate: "0",
+		},
+		"password":        hashedPassword,
+		"passwordHistory": c.PasswordPolicy.NextPasswordHistory(hashedPassword, userRecord.PasswordHistory),
+		"modifiedAt":      helpers.CurrentTimeMilliseconds(),
+	}
+	if _, err = c.saveUser(userRecord, update); err != nil {
 		return ctx.InternalServerError(goa.ErrInternal(err))
 	}
 
 	return ctx.OK([]byte{})
 }
 
+// changeStatus moves the user with the given ID to a new status. If allowedFrom is given, the
+// user must currently be in one of those statuses. Deleted users are treated as not found.
+func (c *UserController) changeStatus(ctx context.Context, userID, to, reason string, allowedFrom ...string) (*store.UserRecord, error) {
+	user := &store.UserRecord{}
+	if _, err := c.Store.Users.GetOne(backends.NewFilter().Match("id", userID), user); err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, backends.ErrNotFound("not found")
+	}
+
+	if len(allowedFrom) > 0 {
+		allowed := false
+		for _, status := range allowedFrom {
+			if user.CurrentStatus() == status {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, store.ErrInvalidTransition(user.CurrentStatus(), to)
+		}
+	}
+
+	return c.saveStatus(user, to, reason, actorID(ctx))
+}
+
+// saveStatus stores the status transition of the given user and returns the updated user.
+func (c *UserController) saveStatus(user *store.UserRecord, to, reason, actor string) (*store.UserRecord, error) {
+	update, err := user.StatusUpdate(to, reason, actor, helpers.CurrentTimeMilliseconds())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.saveUser(user, update)
+}
+
+// sendEmail publishes a message on the "email-queue" AMQP channel for sending an email with the given
+// template. Does nothing if the messaging channel is not set up.
+func (c *UserController) sendEmail(email, templateName string, data map[string]string) error {
+	if c.ChannelRabbitMQ == nil {
+		return nil
+	}
+
+	amqpMessage := AMQPMessage{
+		Email:        email,
+		Data:         data,
+		TemplateName: templateName,
+	}
+	body, err := json.Marshal(amqpMessage)
+	if err != nil {
+		c.Service.LogError("User: failed to serialize AMQPMessage.", "err", err.Error())
+		return err
+	}
+	if err := c.ChannelRabbitMQ.Send("email-queue", body); err != nil {
+		c.Service.LogError("User: failed to send message on AMQP Channel.", "err", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// rehashPassword replaces the stored password hash of the user with a hash generated by the current hasher.
+// The hash is not replaced if the password has been changed in the meantime.
+func (c *UserController) rehashPassword(user *store.UserRecord, password string) error {
+	hashedPassword, err := c.Passwords.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	current := user.Password
+	_, err = c.updateUser(user, func(user *store.UserRecord) (map[string]interface{}, error) {
+		if user.Password != current {
+			return nil, nil
+		}
+		update := map[string]interface{}{
+			"password": hashedPassword,
+		}
+		// the most recent entry in the history is the current password
+		if len(user.PasswordHistory) > 0 && user.PasswordHistory[0] == user.Password {
+			history := append([]string{hashedPassword}, user.PasswordHistory[1:]...)
+			update["passwordHistory"] = history
+		}
+		return update, nil
+	})
+	return err
+}
+
+// statusChangeReason returns the reason from the (optional) status change payload.
+func statusChangeReason(payload *app.StatusChangePayload) string {
+	if payload == nil || payload.Reason == nil {
+		return ""
+	}
+	return *payload.Reason
+}
+
+// actorID returns the ID of the authenticated user performing the action, or "system"
+// if there is no authenticated user.
+func actorID(ctx context.Context) string {
+	if authObj := auth.GetAuth(ctx); authObj != nil && authObj.UserID != "" {
+		return authObj.UserID
+	}
+	return "system"
+}
+
 // generateToken generates random string with length of n
 func generateToken(n int) string {
 	rv := make([]byte, n)
@@ -578,3 +1314,18 @@ func stringToBcryptHash(str string) (string, error) {
 
 	return string(hashedString), nil
 }
+
+// hasAnyRole checks whether the authenticated user has at least one of the given roles.
+func hasAnyRole(authObj *auth.Auth, roles ...string) bool {
+	if authObj == nil {
+		return false
+	}
+	for _, userRole := range authObj.Roles {
+		for _, role := range roles {
+			if userRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -2,21 +2,31 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Microkubes/backends"
 	"github.com/Microkubes/microservice-security/auth"
+	"github.com/Microkubes/microservice-tools/rabbitmq"
 	"github.com/Microkubes/microservice-user/app"
 	"github.com/Microkubes/microservice-user/app/test"
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/helpers"
 	"github.com/Microkubes/microservice-user/store"
 	"github.com/keitaroinc/goa"
+	"gopkg.in/mgo.v2/bson"
 )
 
 var db = store.NewDB()
+var passwordPolicy, _ = NewPasswordPolicy(nil)
+var passwordHashing, _ = NewPasswordHashing(nil)
 var (
 	service          = goa.New("user-test")
-	ctrl             = NewUserController(service, db, nil)
+	ctrl             = NewUserController(service, db, nil, nil, passwordPolicy, passwordHashing)
 	ID               = "5df2103b5f1b640001142d3c"
 	notFoundID       = "5df2103b5f1b640001142d4c"
 	notFonundEmail   = "not-found@gmail.com"
@@ -28,6 +38,21 @@ var (
 	internalErrToken = "internal-error-token"
 )
 
+// recordingChannel is a mock AMQP channel that records the email messages sent through it.
+type recordingChannel struct {
+	rabbitmq.MockAMQPChannel
+	messages []*AMQPMessage
+}
+
+func (c *recordingChannel) Send(name string, body []byte) error {
+	message := &AMQPMessage{}
+	if err := json.Unmarshal(body, message); err != nil {
+		return err
+	}
+	c.messages = append(c.messages, message)
+	return nil
+}
+
 func TestGetUserOK(t *testing.T) {
 	// Call generated test helper, this checks that the returned media type is of the
 	// correct type (i.e. uses view "default") and validates the media type.
@@ -124,6 +149,16 @@ func TestCreateUserBadRequest(t *testing.T) {
 	test.CreateUserBadRequest(t, context.Background(), service, ctrl, CreateUserPayload)
 }
 
+func TestCreateUserBadRequestWeakPassword(t *testing.T) {
+	password := "pass"
+	CreateUserPayload := &app.CreateUserPayload{
+		Email:    "weak-password@gmail.com",
+		Password: &password,
+	}
+
+	test.CreateUserBadRequest(t, context.Background(), service, ctrl, CreateUserPayload)
+}
+
 func TestCreateUserInternalServerError(t *testing.T) {
 	password := "keitaro"
 	extID := "qwerc461f9f8eb02aae053f3"
@@ -142,7 +177,7 @@ func TestUpdateUserOK(t *testing.T) {
 	UpdateUserPayload := &app.UpdateUserPayload{
 		Roles: roles,
 	}
-	_, users := test.UpdateUserOK(t, context.Background(), service, ctrl, ID, UpdateUserPayload)
+	_, users := test.UpdateUserOK(t, context.Background(), service, ctrl, ID, nil, UpdateUserPayload)
 	if users == nil {
 		t.Fatal("Expected the update user data.")
 	}
@@ -153,7 +188,7 @@ func TestUpdateUserNotFound(t *testing.T) {
 		Roles: []string{"admin", "user"},
 	}
 
-	test.UpdateUserNotFound(t, context.Background(), service, ctrl, notFoundID, UpdateUserPayload)
+	test.UpdateUserNotFound(t, context.Background(), service, ctrl, notFoundID, nil, UpdateUserPayload)
 }
 
 func TestUpdateUserBadRequest(t *testing.T) {
@@ -161,7 +196,7 @@ func TestUpdateUserBadRequest(t *testing.T) {
 		Roles: []string{"admin", "user"},
 	}
 
-	test.UpdateUserBadRequest(t, context.Background(), service, ctrl, badID, UpdateUserPayload)
+	test.UpdateUserBadRequest(t, context.Background(), service, ctrl, badID, nil, UpdateUserPayload)
 }
 
 func TestUpdateUserInternalServerError(t *testing.T) {
@@ -169,7 +204,7 @@ func TestUpdateUserInternalServerError(t *testing.T) {
 		Roles: []string{"admin", "user"},
 	}
 
-	test.UpdateUserInternalServerError(t, context.Background(), service, ctrl, internalErrID, UpdateUserPayload)
+	test.UpdateUserInternalServerError(t, context.Background(), service, ctrl, internalErrID, nil, UpdateUserPayload)
 }
 
 func TestFindUserBadRequest(t *testing.T) {
@@ -235,9 +270,35 @@ func TestFindByEmailUserInternalServerError(t *testing.T) {
 }
 
 func TestResetVerificationTokenUserOK(t *testing.T) {
-	test.ResetVerificationTokenUserOK(t, context.Background(), service, ctrl, &app.EmailPayload{
+	channel := &recordingChannel{}
+	rmqCtrl := NewUserController(service, db, channel, nil, passwordPolicy, passwordHashing)
+
+	test.ResetVerificationTokenUserOK(t, context.Background(), service, rmqCtrl, &app.EmailPayload{
 		Email: "keitaro-user2@gmail.com",
 	})
+	if len(channel.messages) != 1 || channel.messages[0].TemplateName != "verification" {
+		t.Errorf("Expected verification email, got %v", channel.messages)
+	}
+}
+
+func TestResetVerificationTokenNoMailChannel(t *testing.T) {
+	tokensDB := store.NewDB()
+	tokensCtrl := NewUserController(service, tokensDB, nil, nil, passwordPolicy, passwordHashing)
+
+	// the token can be neither sent nor returned
+	test.ResetVerificationTokenUserInternalServerError(t, context.Background(), service, tokensCtrl, &app.EmailPayload{
+		Email: "keitaro-user1@gmail.com",
+	})
+
+	externalCtrl := NewUserController(service, tokensDB, nil, &config.ServiceConfig{
+		VerificationToken: &config.VerificationToken{ExternalEmail: true},
+	}, passwordPolicy, passwordHashing)
+	_, resetToken := test.ResetVerificationTokenUserOK(t, context.Background(), service, externalCtrl, &app.EmailPayload{
+		Email: "keitaro-user1@gmail.com",
+	})
+	if resetToken.Token == nil || *resetToken.Token == "" {
+		t.Error("Expected the token to be returned to the caller")
+	}
 }
 
 func TestResetVerificationTokenUserNotFound(t *testing.T) {
@@ -334,3 +395,1733 @@ func TestGetAllUserNotFound(t *testing.T) {
 func TestGetAllUserInternalServerError(t *testing.T) {
 	test.GetAllUserInternalServerError(t, context.Background(), service, ctrl, nil, nil, nil, nil)
 }
+
+func TestDeleteUserNoContent(t *testing.T) {
+	deleteID := "5df2103b5f1b640001142d3e"
+
+	test.DeleteUserNoContent(t, context.Background(), service, ctrl, deleteID)
+
+	test.GetUserNotFound(t, context.Background(), service, ctrl, deleteID)
+	test.FindByEmailUserNotFound(t, context.Background(), service, ctrl, &app.EmailPayload{
+		Email: "keitaro-user3@gmail.com",
+	})
+	test.DeleteUserNotFound(t, context.Background(), service, ctrl, deleteID)
+}
+
+func TestDeleteUserNotFound(t *testing.T) {
+	test.DeleteUserNotFound(t, context.Background(), service, ctrl, notFoundID)
+}
+
+func TestDeleteUserBadRequest(t *testing.T) {
+	test.DeleteUserBadRequest(t, context.Background(), service, ctrl, badID)
+}
+
+func TestDeleteUserInternalServerError(t *testing.T) {
+	test.DeleteUserInternalServerError(t, context.Background(), service, ctrl, internalErrID)
+}
+
+func TestRestoreUserOK(t *testing.T) {
+	restoreID := "5df2103b5f1b640001142d3e"
+
+	_, user := test.RestoreUserOK(t, context.Background(), service, ctrl, restoreID)
+	if user == nil {
+		t.Fatal("Expected the restored user")
+	}
+
+	test.GetUserOK(t, context.Background(), service, ctrl, restoreID)
+}
+
+func TestRestoreUserBadRequest(t *testing.T) {
+	// the user is not deleted
+	test.RestoreUserBadRequest(t, context.Background(), service, ctrl, ID)
+	test.RestoreUserBadRequest(t, context.Background(), service, ctrl, badID)
+}
+
+func TestRestoreUserNotFound(t *testing.T) {
+	test.RestoreUserNotFound(t, context.Background(), service, ctrl, notFoundID)
+}
+
+func TestPurgeUserForbidden(t *testing.T) {
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"user"}})
+
+	test.PurgeUserForbidden(t, ctx, service, ctrl, "5df2103b5f1b640001142d3f")
+}
+
+func TestPurgeUserNoContent(t *testing.T) {
+	purgeID := "5df2103b5f1b640001142d3f"
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"admin"}})
+
+	test.PurgeUserNoContent(t, ctx, service, ctrl, purgeID)
+
+	// purged users can't be restored
+	test.RestoreUserNotFound(t, context.Background(), service, ctrl, purgeID)
+	test.PurgeUserNotFound(t, ctx, service, ctrl, purgeID)
+}
+
+func TestSuspendUserOK(t *testing.T) {
+	userID := "5df2103b5f1b640001142d3e"
+	reason := "terms of service violation"
+
+	_, user := test.SuspendUserOK(t, context.Background(), service, ctrl, userID, &app.StatusChangePayload{
+		Reason: &reason,
+	})
+	if user == nil {
+		t.Fatal("Expected the suspended user")
+	}
+	if user.Status == nil || *user.Status != store.StatusSuspended {
+		t.Errorf("Expected status %s, got %v", store.StatusSuspended, user.Status)
+	}
+	if user.Active {
+		t.Error("Suspended user must not be active")
+	}
+
+	// suspended user can't be suspended again
+	test.SuspendUserBadRequest(t, context.Background(), service, ctrl, userID, &app.StatusChangePayload{})
+}
+
+func TestSuspendUserNotFound(t *testing.T) {
+	test.SuspendUserNotFound(t, context.Background(), service, ctrl, notFoundID, &app.StatusChangePayload{})
+}
+
+func TestReactivateUserOK(t *testing.T) {
+	userID := "5df2103b5f1b640001142d3e"
+
+	_, user := test.ReactivateUserOK(t, context.Background(), service, ctrl, userID, &app.StatusChangePayload{})
+	if user == nil {
+		t.Fatal("Expected the reactivated user")
+	}
+	if user.Status == nil || *user.Status != store.StatusActive {
+		t.Errorf("Expected status %s, got %v", store.StatusActive, user.Status)
+	}
+
+	// active user can't be reactivated
+	test.ReactivateUserBadRequest(t, context.Background(), service, ctrl, userID, &app.StatusChangePayload{})
+}
+
+func TestDeactivateUserOK(t *testing.T) {
+	userID := "5df2103b5f1b640001142d3e"
+
+	_, user := test.DeactivateUserOK(t, context.Background(), service, ctrl, userID, &app.StatusChangePayload{})
+	if user == nil {
+		t.Fatal("Expected the deactivated user")
+	}
+	if user.Status == nil || *user.Status != store.StatusDeactivated {
+		t.Errorf("Expected status %s, got %v", store.StatusDeactivated, user.Status)
+	}
+}
+
+func TestDeactivateUserInternalServerError(t *testing.T) {
+	test.DeactivateUserInternalServerError(t, context.Background(), service, ctrl, internalErrID, &app.StatusChangePayload{})
+}
+
+func TestUpdateUserBadRequestPasswordReuse(t *testing.T) {
+	password := "new-password-1"
+	payload := &app.UpdateUserPayload{
+		Active:   true,
+		Password: &password,
+	}
+
+	test.UpdateUserOK(t, context.Background(), service, ctrl, ID, nil, payload)
+
+	// the same password can't be set again
+	test.UpdateUserBadRequest(t, context.Background(), service, ctrl, ID, nil, payload)
+}
+
+func TestFindUserRehashPassword(t *testing.T) {
+	argon2Hashing, err := NewPasswordHashing(&config.PasswordHashing{
+		Algorithm: "argon2id",
+		Argon2: config.Argon2Params{
+			Memory:     1024,
+			Iterations: 1,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	argon2Ctrl := NewUserController(service, db, nil, nil, passwordPolicy, argon2Hashing)
+
+	payload := &app.Credentials{
+		Email:    "keitaro-user2@gmail.com",
+		Password: "keitaro",
+	}
+	test.FindUserOK(t, context.Background(), service, argon2Ctrl, payload)
+
+	user := &store.UserRecord{}
+	if _, err := db.Users.GetOne(backends.NewFilter().Match("email", payload.Email), user); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(user.Password, "$argon2id$") {
+		t.Errorf("Expected the password to be rehashed with argon2id, got %s", user.Password)
+	}
+
+	// both the old and the new controller can verify the rehashed password
+	test.FindUserOK(t, context.Background(), service, argon2Ctrl, payload)
+	test.FindUserOK(t, context.Background(), service, ctrl, payload)
+}
+
+func TestChangePasswordUserOK(t *testing.T) {
+	channel := &recordingChannel{}
+	rmqCtrl := NewUserController(service, db, channel, nil, passwordPolicy, passwordHashing)
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: "5df2103b5f1b640001142d40"})
+
+	test.ChangePasswordUserOK(t, ctx, service, rmqCtrl, &app.ChangePasswordPayload{
+		CurrentPassword: "keitaro",
+		NewPassword:     "new-keitaro-password",
+	})
+
+	if len(channel.messages) != 1 || channel.messages[0].TemplateName != "passwordChanged" {
+		t.Errorf("Expected passwordChanged email, got %v", channel.messages)
+	}
+
+	test.FindUserOK(t, context.Background(), service, ctrl, &app.Credentials{
+		Email:    "keitaro-user5@gmail.com",
+		Password: "new-keitaro-password",
+	})
+	test.FindUserNotFound(t, context.Background(), service, ctrl, &app.Credentials{
+		Email:    "keitaro-user5@gmail.com",
+		Password: "keitaro",
+	})
+}
+
+func TestChangePasswordUserForbidden(t *testing.T) {
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: "5df2103b5f1b640001142d40"})
+
+	test.ChangePasswordUserForbidden(t, ctx, service, ctrl, &app.ChangePasswordPayload{
+		CurrentPassword: "wrong-password",
+		NewPassword:     "other-keitaro-password",
+	})
+}
+
+func TestChangePasswordUserBadRequest(t *testing.T) {
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: "5df2103b5f1b640001142d40"})
+
+	// the new password does not satisfy the password policy
+	test.ChangePasswordUserBadRequest(t, ctx, service, ctrl, &app.ChangePasswordPayload{
+		CurrentPassword: "new-keitaro-password",
+		NewPassword:     "pass",
+	})
+	// the new password is the same as the current one
+	test.ChangePasswordUserBadRequest(t, ctx, service, ctrl, &app.ChangePasswordPayload{
+		CurrentPassword: "new-keitaro-password",
+		NewPassword:     "new-keitaro-password",
+	})
+}
+
+func TestChangePasswordUserNotFound(t *testing.T) {
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: notFoundID})
+
+	test.ChangePasswordUserNotFound(t, ctx, service, ctrl, &app.ChangePasswordPayload{
+		CurrentPassword: "keitaro",
+		NewPassword:     "new-keitaro-password",
+	})
+}
+
+func TestFindUserLocked(t *testing.T) {
+	lockoutCtrl := NewUserController(service, db, nil, &config.ServiceConfig{
+		Lockout: &config.Lockout{
+			MaxFailedLogins: 3,
+			LockDuration:    60,
+		},
+	}, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d41"
+	wrongCredentials := &app.Credentials{
+		Email:    "keitaro-user6@gmail.com",
+		Password: "wrong-password",
+	}
+	credentials := &app.Credentials{
+		Email:    "keitaro-user6@gmail.com",
+		Password: "keitaro",
+	}
+
+	test.FindUserNotFound(t, context.Background(), service, lockoutCtrl, wrongCredentials)
+	test.FindUserNotFound(t, context.Background(), service, lockoutCtrl, wrongCredentials)
+	test.FindUserLocked(t, context.Background(), service, lockoutCtrl, wrongCredentials)
+
+	// the correct password is rejected while the account is locked
+	test.FindUserLocked(t, context.Background(), service, lockoutCtrl, credentials)
+
+	_, user := test.UnlockUserOK(t, context.Background(), service, lockoutCtrl, userID, &app.StatusChangePayload{})
+	if user.Status == nil || *user.Status != store.StatusActive {
+		t.Errorf("Expected status %s after unlock, got %v", store.StatusActive, user.Status)
+	}
+	test.UnlockUserBadRequest(t, context.Background(), service, lockoutCtrl, userID, &app.StatusChangePayload{})
+
+	test.FindUserOK(t, context.Background(), service, lockoutCtrl, credentials)
+}
+
+func TestFindUserLockExpired(t *testing.T) {
+	lockoutCtrl := NewUserController(service, db, nil, &config.ServiceConfig{
+		Lockout: &config.Lockout{
+			MaxFailedLogins: 1,
+		},
+	}, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d41"
+	credentials := &app.Credentials{
+		Email:    "keitaro-user6@gmail.com",
+		Password: "keitaro",
+	}
+
+	test.FindUserLocked(t, context.Background(), service, lockoutCtrl, &app.Credentials{
+		Email:    credentials.Email,
+		Password: "wrong-password",
+	})
+
+	// expire the lock
+	update := map[string]interface{}{"lockedUntil": 1}
+	if _, err := db.Users.Save(&update, backends.NewFilter().Match("id", userID)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, user := test.FindUserOK(t, context.Background(), service, lockoutCtrl, credentials)
+	if user.Status == nil || *user.Status != store.StatusActive {
+		t.Errorf("Expected status %s after the lock expired, got %v", store.StatusActive, user.Status)
+	}
+}
+
+func TestRecordFailedLoginConcurrent(t *testing.T) {
+	lockoutDB := store.NewDB()
+	lockoutCtrl := NewUserController(service, lockoutDB, nil, &config.ServiceConfig{
+		Lockout: &config.Lockout{
+			MaxFailedLogins: 2,
+		},
+	}, passwordPolicy, passwordHashing)
+
+	// both failed logins read the user before either is saved
+	first := &store.UserRecord{}
+	if _, err := lockoutDB.Users.GetOne(backends.NewFilter().Match("id", "5df2103b5f1b640001142d41"), first); err != nil {
+		t.Fatal(err)
+	}
+	second := *first
+
+	if locked, err := lockoutCtrl.recordFailedLogin(first, 1000); err != nil || locked {
+		t.Fatalf("Expected the first failed login not to lock the account, got %v, %v", locked, err)
+	}
+	locked, err := lockoutCtrl.recordFailedLogin(&second, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !locked {
+		t.Errorf("Expected the second failed login to be counted and lock the account")
+	}
+}
+
+func TestLockDuration(t *testing.T) {
+	lockout := (&config.ServiceConfig{}).GetLockout()
+
+	if duration := lockDuration(lockout, lockout.MaxFailedLogins); duration != int64(lockout.LockDuration)*1000 {
+		t.Errorf("Expected the first lock to last %d seconds, got %d ms", lockout.LockDuration, duration)
+	}
+	if duration := lockDuration(lockout, lockout.MaxFailedLogins+1); duration != int64(lockout.LockDuration)*2000 {
+		t.Errorf("Expected the second lock to last twice as long, got %d ms", duration)
+	}
+	if duration := lockDuration(lockout, lockout.MaxFailedLogins+100); duration != int64(lockout.MaxLockDuration)*1000 {
+		t.Errorf("Expected the lock duration to be capped, got %d ms", duration)
+	}
+}
+
+func TestFindUserRecordsLogins(t *testing.T) {
+	historyCtrl := NewUserController(service, db, nil, &config.ServiceConfig{
+		LoginHistorySize: 2,
+	}, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d42"
+
+	for _, createdAt := range []int64{1, 2} {
+		login := map[string]interface{}{
+			"userId":    userID,
+			"outcome":   store.LoginFailure,
+			"createdAt": createdAt,
+		}
+		if _, err := db.Logins.Save(&login, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, user := test.FindUserOK(t, context.Background(), service, historyCtrl, &app.Credentials{
+		Email:    "keitaro-user7@gmail.com",
+		Password: "keitaro",
+	})
+	if user.LastLoginAt == nil || *user.LastLoginAt == 0 {
+		t.Error("Expected the last login time to be set")
+	}
+
+	_, logins := test.GetLoginsUserOK(t, context.Background(), service, historyCtrl, userID, nil, nil)
+	if len(logins) != 2 {
+		t.Fatalf("Expected the login history to be capped at 2 logins, got %d", len(logins))
+	}
+	if logins[0].Outcome != store.LoginSuccess {
+		t.Errorf("Expected the most recent login to be successful, got %s", logins[0].Outcome)
+	}
+	if logins[1].CreatedAt != 2 {
+		t.Errorf("Expected the oldest login to be removed, got login at %d", logins[1].CreatedAt)
+	}
+
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: userID})
+	limit := 1
+	_, myLogins := test.GetMyLoginsUserOK(t, ctx, service, historyCtrl, &limit, nil)
+	if len(myLogins) != 1 || myLogins[0].Outcome != store.LoginSuccess {
+		t.Errorf("Expected only the most recent login, got %v", myLogins)
+	}
+}
+
+func TestGetLoginsUserNotFound(t *testing.T) {
+	test.GetLoginsUserNotFound(t, context.Background(), service, ctrl, notFoundID, nil, nil)
+}
+
+func TestGetMyLoginsUserEmpty(t *testing.T) {
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID})
+	_, logins := test.GetMyLoginsUserOK(t, ctx, service, ctrl, nil, nil)
+	if len(logins) != 0 {
+		t.Errorf("Expected empty login history, got %d logins", len(logins))
+	}
+}
+
+func TestMFAUserFlow(t *testing.T) {
+	userID := "5df2103b5f1b640001142d43"
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: userID})
+
+	test.ConfirmTotpUserBadRequest(t, ctx, service, ctrl, &app.MFACodePayload{Code: "123456"})
+
+	_, enrollment := test.EnrollTotpUserOK(t, ctx, service, ctrl)
+	if !strings.Contains(enrollment.URI, enrollment.Secret) {
+		t.Errorf("Expected the secret in the otpauth URI, got %s", enrollment.URI)
+	}
+	key, err := totpEncoding.DecodeString(enrollment.Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter := helpers.CurrentTimeMilliseconds() / 1000 / totpPeriod
+
+	test.ConfirmTotpUserBadRequest(t, ctx, service, ctrl, &app.MFACodePayload{Code: "000000x"})
+	_, recovery := test.ConfirmTotpUserOK(t, ctx, service, ctrl, &app.MFACodePayload{Code: totpCode(key, counter)})
+	if len(recovery.RecoveryCodes) != 10 {
+		t.Fatalf("Expected 10 recovery codes, got %d", len(recovery.RecoveryCodes))
+	}
+
+	_, user := test.GetMeUserOK(t, ctx, service, ctrl)
+	if user.MfaEnabled == nil || !*user.MfaEnabled {
+		t.Error("Expected MFA to be enabled")
+	}
+	test.EnrollTotpUserBadRequest(t, ctx, service, ctrl)
+
+	// the password alone does not complete the login of a user with MFA enabled
+	test.FindUserOK(t, context.Background(), service, ctrl, &app.Credentials{
+		Email:    "keitaro-user8@gmail.com",
+		Password: "keitaro",
+	})
+	if _, logins := test.GetLoginsUserOK(t, context.Background(), service, ctrl, userID, nil, nil); len(logins) != 0 {
+		t.Errorf("Expected no login before the second factor is verified, got %v", logins)
+	}
+
+	// the code used for the confirmation cannot be used again
+	test.VerifyMfaUserForbidden(t, context.Background(), service, ctrl, &app.MFAVerifyPayload{
+		UserID: userID,
+		Code:   totpCode(key, counter),
+	})
+	test.VerifyMfaUserOK(t, context.Background(), service, ctrl, &app.MFAVerifyPayload{
+		UserID: userID,
+		Code:   totpCode(key, counter+1),
+	})
+	_, logins := test.GetLoginsUserOK(t, context.Background(), service, ctrl, userID, nil, nil)
+	outcomes := []string{}
+	for _, login := range logins {
+		outcomes = append(outcomes, login.Outcome)
+	}
+	if len(outcomes) != 2 || !strings.Contains(strings.Join(outcomes, ","), store.LoginSuccess) {
+		t.Errorf("Expected the login to be successful once the second factor is verified, got %v", outcomes)
+	}
+	test.VerifyMfaUserOK(t, context.Background(), service, ctrl, &app.MFAVerifyPayload{
+		UserID: userID,
+		Code:   recovery.RecoveryCodes[0],
+	})
+	test.VerifyMfaUserForbidden(t, context.Background(), service, ctrl, &app.MFAVerifyPayload{
+		UserID: userID,
+		Code:   recovery.RecoveryCodes[0],
+	})
+
+	_, user = test.ResetMfaUserOK(t, context.Background(), service, ctrl, userID)
+	if user.MfaEnabled == nil || *user.MfaEnabled {
+		t.Error("Expected MFA to be disabled")
+	}
+	test.VerifyMfaUserBadRequest(t, context.Background(), service, ctrl, &app.MFAVerifyPayload{
+		UserID: userID,
+		Code:   recovery.RecoveryCodes[1],
+	})
+}
+
+func TestVerifyMfaConcurrent(t *testing.T) {
+	mfaDB := store.NewDB()
+	mfaCtrl := NewUserController(service, mfaDB, nil, nil, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d43"
+
+	codes, hashes, err := generateRecoveryCodes(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update := map[string]interface{}{
+		"mfaEnabled":    true,
+		"totpSecret":    "JBSWY3DPEHPK3PXP",
+		"recoveryCodes": hashes,
+	}
+	if _, err = mfaDB.Users.Save(&update, backends.NewFilter().Match("id", userID)); err != nil {
+		t.Fatal(err)
+	}
+	stale := &staleRepository{Repository: mfaDB.Users, field: "id", stale: &store.UserRecord{}}
+	if _, err = mfaDB.Users.GetOne(backends.NewFilter().Match("id", userID), stale.stale); err != nil {
+		t.Fatal(err)
+	}
+
+	test.VerifyMfaUserOK(t, context.Background(), service, mfaCtrl, &app.MFAVerifyPayload{UserID: userID, Code: codes[0]})
+
+	// a request that read the user before the recovery code was used cannot use it again
+	staleDB := mfaDB
+	staleDB.Users = stale
+	staleCtrl := NewUserController(service, staleDB, nil, nil, passwordPolicy, passwordHashing)
+	test.VerifyMfaUserForbidden(t, context.Background(), service, staleCtrl, &app.MFAVerifyPayload{UserID: userID, Code: codes[0]})
+}
+
+func TestResetMfaUserNotFound(t *testing.T) {
+	test.ResetMfaUserNotFound(t, context.Background(), service, ctrl, notFoundID)
+
+	mfaDB := store.NewDB()
+	mfaCtrl := NewUserController(service, mfaDB, nil, nil, passwordPolicy, passwordHashing)
+	deletedID := "5df2103b5f1b640001142d3e"
+	update := map[string]interface{}{"status": store.StatusDeleted}
+	if _, err := mfaDB.Users.Save(&update, backends.NewFilter().Match("id", deletedID)); err != nil {
+		t.Fatal(err)
+	}
+	test.ResetMfaUserNotFound(t, context.Background(), service, mfaCtrl, deletedID)
+}
+
+func TestAccessTokens(t *testing.T) {
+	userID := "5df2103b5f1b640001142d42"
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: userID})
+
+	past := int(helpers.CurrentTimeMilliseconds() - 1000)
+	test.CreateTokenUserBadRequest(t, ctx, service, ctrl, &app.CreateAccessTokenPayload{
+		Name:      "expired",
+		ExpiresAt: &past,
+	})
+
+	_, created := test.CreateTokenUserCreated(t, ctx, service, ctrl, &app.CreateAccessTokenPayload{
+		Name:   "ci",
+		Scopes: []string{"read", "write"},
+	})
+	if created.Token == nil || !strings.HasPrefix(*created.Token, created.Prefix) {
+		t.Fatalf("Expected the token to be returned on creation, got %v", created.Token)
+	}
+
+	record := &store.AccessTokenRecord{}
+	if _, err := db.AccessTokens.GetOne(backends.NewFilter().Match("id", created.ID), record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Hash != ctrl.TokenHasher.Hash(*created.Token) {
+		t.Errorf("Expected the keyed hash of the token to be stored, got %s", record.Hash)
+	}
+
+	_, tokens := test.ListTokensUserOK(t, ctx, service, ctrl)
+	if len(tokens) != 1 || tokens[0].ID != created.ID || tokens[0].Token != nil {
+		t.Fatalf("Expected the token to be listed without the token value, got %v", tokens)
+	}
+
+	rw, user := test.FindByTokenUserOK(t, context.Background(), service, ctrl, &app.AccessTokenPayload{Token: *created.Token})
+	if user.ID != userID {
+		t.Errorf("Expected user %s, got %s", userID, user.ID)
+	}
+	if scopes := rw.Header().Get("X-Token-Scopes"); scopes != "read,write" {
+		t.Errorf("Expected the token scopes in the header, got %s", scopes)
+	}
+
+	_, tokens = test.ListTokensUserOK(t, ctx, service, ctrl)
+	if len(tokens) != 1 || tokens[0].LastUsedAt == nil {
+		t.Error("Expected the last used time to be recorded")
+	}
+
+	otherCtx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID})
+	test.RevokeTokenUserNotFound(t, otherCtx, service, ctrl, created.ID)
+
+	test.RevokeTokenUserNoContent(t, ctx, service, ctrl, created.ID)
+	test.FindByTokenUserNotFound(t, context.Background(), service, ctrl, &app.AccessTokenPayload{Token: *created.Token})
+}
+
+func TestFindByTokenUserNotFound(t *testing.T) {
+	test.FindByTokenUserNotFound(t, context.Background(), service, ctrl, &app.AccessTokenPayload{Token: "mkp_unknown"})
+}
+
+func TestVerifyUserExpiredToken(t *testing.T) {
+	token := map[string]interface{}{
+		"email":     "keitaro-user1@gmail.com",
+		"token":     ctrl.TokenHasher.Hash("expired-verification-token"),
+		"issuedAt":  int64(1000),
+		"expiresAt": int64(2000),
+	}
+	if _, err := db.Tokens.Save(&token, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expired := "expired-verification-token"
+	test.VerifyUserBadRequest(t, context.Background(), service, ctrl, &expired)
+
+	// the expired token is deleted
+	record := &store.TokenRecord{}
+	if _, err := db.Tokens.GetOne(backends.NewFilter().Match("token", ctrl.TokenHasher.Hash(expired)), record); !backends.IsErrNotFound(err) {
+		t.Errorf("Expected the expired token to be deleted, got %v", err)
+	}
+}
+
+func TestVerifyUserInactiveStatus(t *testing.T) {
+	verifyDB := store.NewDB()
+	verifyCtrl := NewUserController(service, verifyDB, nil, nil, passwordPolicy, passwordHashing)
+
+	for _, status := range []string{store.StatusSuspended, store.StatusDeleted} {
+		email := "keitaro-user3@gmail.com"
+		update := map[string]interface{}{"status": status}
+		if _, err := verifyDB.Users.Save(&update, backends.NewFilter().Match("id", "5df2103b5f1b640001142d3e")); err != nil {
+			t.Fatal(err)
+		}
+		token := map[string]interface{}{
+			"email":     email,
+			"token":     verifyCtrl.TokenHasher.Hash("replayed-token-" + status),
+			"expiresAt": helpers.CurrentTimeMilliseconds() + 60000,
+		}
+		if _, err := verifyDB.Tokens.Save(&token, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		replayed := "replayed-token-" + status
+		if status == store.StatusDeleted {
+			test.VerifyUserNotFound(t, context.Background(), service, verifyCtrl, &replayed)
+		} else {
+			test.VerifyUserBadRequest(t, context.Background(), service, verifyCtrl, &replayed)
+		}
+
+		user := &store.UserRecord{}
+		if _, err := verifyDB.Users.GetOne(backends.NewFilter().Match("email", email), user); err != nil {
+			t.Fatal(err)
+		}
+		if user.CurrentStatus() != status {
+			t.Errorf("Expected the %s user not to be reactivated, got %s", status, user.CurrentStatus())
+		}
+	}
+}
+
+func TestResetVerificationTokenExpiry(t *testing.T) {
+	ttlCtrl := NewUserController(service, db, &recordingChannel{}, &config.ServiceConfig{
+		VerificationToken: &config.VerificationToken{TTL: 60},
+	}, passwordPolicy, passwordHashing)
+
+	before := helpers.CurrentTimeMilliseconds()
+	_, resetToken := test.ResetVerificationTokenUserOK(t, context.Background(), service, ttlCtrl, &app.EmailPayload{
+		Email: "keitaro-user2@gmail.com",
+	})
+	if resetToken.ExpiresAt == nil || int64(*resetToken.ExpiresAt) < before+60000 || int64(*resetToken.ExpiresAt) > helpers.CurrentTimeMilliseconds()+60000 {
+		t.Errorf("Expected the token to expire in 60 seconds, got %v", resetToken.ExpiresAt)
+	}
+}
+
+func TestForgotPasswordStoresTokenHash(t *testing.T) {
+	channel := &recordingChannel{}
+	rmqCtrl := NewUserController(service, db, channel, nil, passwordPolicy, passwordHashing)
+	email := "keitaro-user7@gmail.com"
+
+	test.ForgotPasswordUserOK(t, context.Background(), service, rmqCtrl, &app.EmailPayload{Email: email})
+	if len(channel.messages) != 1 || channel.messages[0].Data["token"] == "" {
+		t.Fatalf("Expected forgotPassword email with the token, got %v", channel.messages)
+	}
+	token := channel.messages[0].Data["token"]
+
+	user := &store.UserRecord{}
+	if _, err := db.Users.GetOne(backends.NewFilter().Match("email", email), user); err != nil {
+		t.Fatal(err)
+	}
+	if user.FPToken.Token == token || !isTokenHash(user.FPToken.Token) {
+		t.Errorf("Expected the token to be stored hashed, got %s", user.FPToken.Token)
+	}
+
+	// the stored hash cannot be used as token
+	test.ForgotPasswordUpdateUserNotFound(t, context.Background(), service, rmqCtrl, &app.ForgotPasswordPayload{
+		Password: "hash-keitaro-password",
+		Token:    user.FPToken.Token,
+	})
+	test.FindUserNotFound(t, context.Background(), service, ctrl, &app.Credentials{
+		Email:    email,
+		Password: "hash-keitaro-password",
+	})
+
+	test.ForgotPasswordUpdateUserOK(t, context.Background(), service, rmqCtrl, &app.ForgotPasswordPayload{
+		Password: "new-keitaro-password",
+		Token:    token,
+	})
+	test.FindUserOK(t, context.Background(), service, ctrl, &app.Credentials{
+		Email:    email,
+		Password: "new-keitaro-password",
+	})
+
+	// the used token has expired
+	_, err := test.ForgotPasswordUpdateUserBadRequest(t, context.Background(), service, rmqCtrl, &app.ForgotPasswordPayload{
+		Password: "newer-keitaro-password",
+		Token:    token,
+	})
+	if detail := err.(*goa.ErrorResponse).Detail; detail != "invalid or expired token" {
+		t.Errorf("Expected an invalid token error, got %q", detail)
+	}
+}
+
+func TestForgotPasswordInactiveStatus(t *testing.T) {
+	fpDB := store.NewDB()
+	channel := &recordingChannel{}
+	fpCtrl := NewUserController(service, fpDB, channel, nil, passwordPolicy, passwordHashing)
+	email := "keitaro-user7@gmail.com"
+
+	for _, status := range []string{store.StatusSuspended, store.StatusDeleted} {
+		update := map[string]interface{}{
+			"status": status,
+			"forgotPasswordTokens": map[string]interface{}{
+				"token":   fpCtrl.TokenHasher.Hash("reset-token-" + status),
+				"expDate": generateExpDate(),
+			},
+		}
+		if _, err := fpDB.Users.Save(&update, backends.NewFilter().Match("id", "5df2103b5f1b640001142d42")); err != nil {
+			t.Fatal(err)
+		}
+
+		test.ForgotPasswordUserOK(t, context.Background(), service, fpCtrl, &app.EmailPayload{Email: email})
+		if len(channel.messages) != 0 {
+			t.Errorf("Expected no email for a %s user, got %v", status, channel.messages)
+		}
+
+		test.ForgotPasswordUpdateUserNotFound(t, context.Background(), service, fpCtrl, &app.ForgotPasswordPayload{
+			Password: "new-keitaro-password",
+			Token:    "reset-token-" + status,
+		})
+	}
+}
+
+func TestMigratePlaintextTokens(t *testing.T) {
+	legacyDB := store.NewDB()
+	legacyCtrl := NewUserController(service, legacyDB, nil, &config.ServiceConfig{TokenSecret: "secret"}, passwordPolicy, passwordHashing)
+
+	legacyToken := map[string]interface{}{
+		"email": "keitaro-user1@gmail.com",
+		"token": "legacy-verification-token",
+	}
+	if _, err := legacyDB.Tokens.Save(&legacyToken, nil); err != nil {
+		t.Fatal(err)
+	}
+	update := map[string]interface{}{
+		"forgotPasswordTokens": store.FPToken{Token: "legacy-reset-token", ExpDate: generateExpDate()},
+	}
+	if _, err := legacyDB.Users.Save(&update, backends.NewFilter().Match("id", ID)); err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, err := migratePlaintextTokens(legacyDB, legacyCtrl.TokenHasher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated != 2 {
+		t.Errorf("Expected 2 migrated tokens, got %d", migrated)
+	}
+	if migrated, _ = migratePlaintextTokens(legacyDB, legacyCtrl.TokenHasher); migrated != 0 {
+		t.Errorf("Expected the migration to be idempotent, got %d migrated tokens", migrated)
+	}
+
+	token := "legacy-verification-token"
+	test.VerifyUserOK(t, context.Background(), service, legacyCtrl, &token)
+
+	user := &store.UserRecord{}
+	if _, err := legacyDB.Users.GetOne(backends.NewFilter().Match("id", ID), user); err != nil {
+		t.Fatal(err)
+	}
+	if !legacyCtrl.TokenHasher.Matches(user.FPToken.Token, "legacy-reset-token") {
+		t.Errorf("Expected the reset token to be hashed, got %s", user.FPToken.Token)
+	}
+}
+
+func TestCreateUserSendsVerificationEmail(t *testing.T) {
+	channel := &recordingChannel{}
+	rmqCtrl := NewUserController(service, db, channel, nil, passwordPolicy, passwordHashing)
+	password := "keitaro"
+	extID := "some-id"
+	email := "keitaro-user9@gmail.com"
+
+	test.CreateUserCreated(t, context.Background(), service, rmqCtrl, &app.CreateUserPayload{
+		Email:      email,
+		Password:   &password,
+		ExternalID: &extID,
+	})
+	if len(channel.messages) != 1 || channel.messages[0].TemplateName != "verification" {
+		t.Fatalf("Expected verification email, got %v", channel.messages)
+	}
+	message := channel.messages[0]
+	if message.Email != email || message.Data["email"] != email || message.Data["token"] == "" {
+		t.Errorf("Expected the email and the token in the message, got %v", message.Data)
+	}
+
+	_, resetToken := test.ResetVerificationTokenUserOK(t, context.Background(), service, rmqCtrl, &app.EmailPayload{Email: email})
+	if resetToken.Token != nil {
+		t.Error("Expected the token not to be returned when the service sends the email")
+	}
+	if len(channel.messages) != 2 || channel.messages[1].TemplateName != "verification" {
+		t.Fatalf("Expected a new verification email, got %v", channel.messages)
+	}
+
+	token := channel.messages[1].Data["token"]
+	test.VerifyUserOK(t, context.Background(), service, rmqCtrl, &token)
+}
+
+func TestCreateUserKeepsOnlyTokenHash(t *testing.T) {
+	tokenDB := store.NewDB()
+	tokenCtrl := NewUserController(service, tokenDB, nil, nil, passwordPolicy, passwordHashing)
+	password := "keitaro"
+	token := "caller-verification-token"
+	email := "caller-token@example.com"
+
+	test.CreateUserCreated(t, context.Background(), service, tokenCtrl, &app.CreateUserPayload{
+		Email:    email,
+		Password: &password,
+		Token:    &token,
+	})
+
+	user := &store.UserRecord{}
+	if _, err := tokenDB.Users.GetOne(backends.NewFilter().Match("email", email), user); err != nil {
+		t.Fatal(err)
+	}
+	if user.Token != "" {
+		t.Errorf("Expected the token not to be stored on the user, got %s", user.Token)
+	}
+	test.VerifyUserOK(t, context.Background(), service, tokenCtrl, &token)
+}
+
+func TestResetVerificationTokenExternalEmail(t *testing.T) {
+	channel := &recordingChannel{}
+	externalCtrl := NewUserController(service, db, channel, &config.ServiceConfig{
+		VerificationToken: &config.VerificationToken{ExternalEmail: true},
+	}, passwordPolicy, passwordHashing)
+
+	_, resetToken := test.ResetVerificationTokenUserOK(t, context.Background(), service, externalCtrl, &app.EmailPayload{
+		Email: "keitaro-user2@gmail.com",
+	})
+	if resetToken.Token == nil || *resetToken.Token == "" {
+		t.Error("Expected the token to be returned to the caller")
+	}
+	if len(channel.messages) != 0 {
+		t.Errorf("Expected no email, got %v", channel.messages)
+	}
+}
+
+func TestRegisterUser(t *testing.T) {
+	channel := &recordingChannel{}
+	registerCtrl := NewUserController(service, db, channel, &config.ServiceConfig{
+		Registration: &config.Registration{Mode: config.RegistrationOpen},
+	}, passwordPolicy, passwordHashing)
+	email := "keitaro-user10@gmail.com"
+
+	test.RegisterUserCreated(t, context.Background(), service, registerCtrl, &app.RegisterPayload{
+		Email:    email,
+		Password: "keitaro",
+	})
+
+	user := &store.UserRecord{}
+	if _, err := db.Users.GetOne(backends.NewFilter().Match("email", email), user); err != nil {
+		t.Fatal(err)
+	}
+	if user.Active || user.Status != store.StatusPendingVerification {
+		t.Errorf("Expected an inactive user pending verification, got active=%v status=%s", user.Active, user.Status)
+	}
+	if len(user.Roles) != 1 || user.Roles[0] != "user" {
+		t.Errorf("Expected only the user role, got %v", user.Roles)
+	}
+	if len(channel.messages) != 1 || channel.messages[0].TemplateName != "verification" {
+		t.Fatalf("Expected verification email, got %v", channel.messages)
+	}
+
+	token := channel.messages[0].Data["token"]
+	test.VerifyUserOK(t, context.Background(), service, registerCtrl, &token)
+}
+
+func TestRegisterUserForbidden(t *testing.T) {
+	for _, registration := range []*config.Registration{
+		nil,
+		{Mode: config.RegistrationClosed},
+		{Mode: config.RegistrationInviteOnly},
+		{Mode: config.RegistrationOpen, DeniedDomains: []string{"gmail.com"}},
+		{Mode: config.RegistrationOpen, AllowedDomains: []string{"example.com"}},
+	} {
+		registerCtrl := NewUserController(service, db, nil, &config.ServiceConfig{
+			Registration: registration,
+		}, passwordPolicy, passwordHashing)
+
+		test.RegisterUserForbidden(t, context.Background(), service, registerCtrl, &app.RegisterPayload{
+			Email:    "keitaro-user11@gmail.com",
+			Password: "keitaro",
+		})
+	}
+}
+
+func TestRegisterUserBadRequestWeakPassword(t *testing.T) {
+	registerCtrl := NewUserController(service, db, nil, &config.ServiceConfig{
+		Registration: &config.Registration{Mode: config.RegistrationOpen},
+	}, passwordPolicy, passwordHashing)
+
+	test.RegisterUserBadRequest(t, context.Background(), service, registerCtrl, &app.RegisterPayload{
+		Email:    "keitaro-user11@gmail.com",
+		Password: "pass",
+	})
+}
+
+func TestRegisterUserExisting(t *testing.T) {
+	registerDB := store.NewDB()
+	channel := &recordingChannel{}
+	registerCtrl := NewUserController(service, registerDB, channel, &config.ServiceConfig{
+		Registration: &config.Registration{Mode: config.RegistrationOpen},
+	}, passwordPolicy, passwordHashing)
+
+	_, user := test.RegisterUserCreated(t, context.Background(), service, registerCtrl, &app.RegisterPayload{
+		Email:    "Keitaro-User5@gmail.com",
+		Password: "keitaro",
+	})
+	if user.Email != "keitaro-user5@gmail.com" || user.ID == "5df2103b5f1b640001142d40" {
+		t.Errorf("Expected the response of a new registration, got %s %s", user.ID, user.Email)
+	}
+	if len(channel.messages) != 1 || channel.messages[0].TemplateName != "registrationAttempt" {
+		t.Errorf("Expected the owner of the email to be notified, got %v", channel.messages)
+	}
+
+	existing := &store.UserRecord{}
+	if _, err := registerDB.Users.GetOne(backends.NewFilter().Match("email", "keitaro-user5@gmail.com"), existing); err != nil {
+		t.Fatal(err)
+	}
+	if existing.ID.Hex() != "5df2103b5f1b640001142d40" || existing.Status == store.StatusPendingVerification {
+		t.Errorf("Expected the existing user to be kept, got %s %s", existing.ID.Hex(), existing.Status)
+	}
+}
+
+func TestRegisterUserProfile(t *testing.T) {
+	registerDB := store.NewDB()
+	registerCtrl := NewUserController(service, registerDB, nil, &config.ServiceConfig{
+		Registration: &config.Registration{Mode: config.RegistrationOpen},
+		Profile: map[string]config.ProfileAttribute{
+			"firstName": {Required: true},
+		},
+	}, passwordPolicy, passwordHashing)
+
+	test.RegisterUserBadRequest(t, context.Background(), service, registerCtrl, &app.RegisterPayload{
+		Email:    "profile-user@example.com",
+		Password: "keitaro",
+		Profile:  map[string]interface{}{"lastName": "Doe"},
+	})
+	_, user := test.RegisterUserCreated(t, context.Background(), service, registerCtrl, &app.RegisterPayload{
+		Email:    "profile-user@example.com",
+		Password: "keitaro",
+		Profile:  map[string]interface{}{"firstName": "Ana"},
+	})
+	if user.Profile["firstName"] != "Ana" {
+		t.Errorf("Expected the registered profile, got %v", user.Profile)
+	}
+}
+
+func TestRegisterUserInviteOnly(t *testing.T) {
+	inviteDB := store.NewDB()
+	channel := &recordingChannel{}
+	inviteCtrl := NewUserController(service, inviteDB, channel, &config.ServiceConfig{
+		Registration: &config.Registration{Mode: config.RegistrationInviteOnly},
+	}, passwordPolicy, passwordHashing)
+	email := "invited-user@example.com"
+
+	test.CreateInvitationUserCreated(t, context.Background(), service, inviteCtrl, &app.InvitationPayload{
+		Email: email,
+		Roles: []string{"user", "editor"},
+	})
+	token := channel.messages[0].Data["token"]
+	unknown := "unknown-invitation-token"
+
+	test.RegisterUserForbidden(t, context.Background(), service, inviteCtrl, &app.RegisterPayload{
+		Email:           email,
+		Password:        "keitaro",
+		InvitationToken: &unknown,
+	})
+	test.RegisterUserForbidden(t, context.Background(), service, inviteCtrl, &app.RegisterPayload{
+		Email:           "other-user@example.com",
+		Password:        "keitaro",
+		InvitationToken: &token,
+	})
+
+	_, user := test.RegisterUserCreated(t, context.Background(), service, inviteCtrl, &app.RegisterPayload{
+		Email:           email,
+		Password:        "keitaro",
+		InvitationToken: &token,
+	})
+	if user.Status == nil || *user.Status != store.StatusActive || len(user.Roles) != 2 {
+		t.Errorf("Expected an active user with the invited roles, got %v %v", user.Status, user.Roles)
+	}
+
+	// the invitation is accepted by the registration
+	test.AcceptInvitationUserNotFound(t, context.Background(), service, inviteCtrl, &app.AcceptInvitationPayload{
+		Token:    token,
+		Password: "keitaro",
+	})
+}
+
+func TestInvitationFlow(t *testing.T) {
+	channel := &recordingChannel{}
+	rmqCtrl := NewUserController(service, db, channel, nil, passwordPolicy, passwordHashing)
+	email := "keitaro-user12@gmail.com"
+
+	_, invitation := test.CreateInvitationUserCreated(t, context.Background(), service, rmqCtrl, &app.InvitationPayload{
+		Email:         "Keitaro-User12@gmail.com",
+		Organizations: []string{"keitaro"},
+		Namespaces:    []string{"microkubes"},
+	})
+	if len(invitation.Roles) != 1 || invitation.Roles[0] != "user" {
+		t.Errorf("Expected the default user role, got %v", invitation.Roles)
+	}
+	if len(channel.messages) != 1 || channel.messages[0].TemplateName != "invitation" {
+		t.Fatalf("Expected invitation email, got %v", channel.messages)
+	}
+	token := channel.messages[0].Data["token"]
+
+	_, invitations := test.ListInvitationsUserOK(t, context.Background(), service, rmqCtrl)
+	if len(invitations) != 1 || invitations[0].ID != invitation.ID {
+		t.Fatalf("Expected the invitation to be listed, got %v", invitations)
+	}
+
+	test.AcceptInvitationUserBadRequest(t, context.Background(), service, rmqCtrl, &app.AcceptInvitationPayload{
+		Token:    token,
+		Password: "pass",
+	})
+	test.AcceptInvitationUserCreated(t, context.Background(), service, rmqCtrl, &app.AcceptInvitationPayload{
+		Token:    token,
+		Password: "keitaro",
+	})
+
+	user := &store.UserRecord{}
+	if _, err := db.Users.GetOne(backends.NewFilter().Match("email", email), user); err != nil {
+		t.Fatal(err)
+	}
+	if !user.Active || user.Status != store.StatusActive {
+		t.Errorf("Expected an active user, got active=%v status=%s", user.Active, user.Status)
+	}
+	if user.DisplayEmail != "Keitaro-User12@gmail.com" {
+		t.Errorf("Expected the invited email as entered to be kept, got %q", user.DisplayEmail)
+	}
+	if len(user.Organizations) != 1 || user.Organizations[0] != "keitaro" || len(user.Namespaces) != 1 || user.Namespaces[0] != "microkubes" {
+		t.Errorf("Expected the invited memberships, got %v %v", user.Organizations, user.Namespaces)
+	}
+
+	// the invitation can be accepted only once
+	test.AcceptInvitationUserNotFound(t, context.Background(), service, rmqCtrl, &app.AcceptInvitationPayload{
+		Token:    token,
+		Password: "keitaro",
+	})
+	test.CreateInvitationUserBadRequest(t, context.Background(), service, rmqCtrl, &app.InvitationPayload{
+		Email: email,
+	})
+}
+
+func TestAcceptInvitationProfile(t *testing.T) {
+	inviteDB := store.NewDB()
+	channel := &recordingChannel{}
+	inviteCtrl := NewUserController(service, inviteDB, channel, &config.ServiceConfig{
+		Profile: map[string]config.ProfileAttribute{
+			"firstName": {Required: true},
+		},
+	}, passwordPolicy, passwordHashing)
+
+	test.CreateInvitationUserCreated(t, context.Background(), service, inviteCtrl, &app.InvitationPayload{
+		Email: "invited-profile@example.com",
+	})
+	token := channel.messages[0].Data["token"]
+
+	test.AcceptInvitationUserBadRequest(t, context.Background(), service, inviteCtrl, &app.AcceptInvitationPayload{
+		Token:    token,
+		Password: "keitaro",
+	})
+	_, user := test.AcceptInvitationUserCreated(t, context.Background(), service, inviteCtrl, &app.AcceptInvitationPayload{
+		Token:    token,
+		Password: "keitaro",
+		Profile:  map[string]interface{}{"firstName": "Ana"},
+	})
+	if user.Profile["firstName"] != "Ana" {
+		t.Errorf("Expected the profile of the invitee, got %v", user.Profile)
+	}
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	_, invitation := test.CreateInvitationUserCreated(t, context.Background(), service, ctrl, &app.InvitationPayload{
+		Email: "keitaro-user13@gmail.com",
+	})
+
+	test.RevokeInvitationUserNoContent(t, context.Background(), service, ctrl, invitation.ID)
+	test.RevokeInvitationUserNotFound(t, context.Background(), service, ctrl, invitation.ID)
+}
+
+func TestAcceptInvitationExpired(t *testing.T) {
+	invitation := &store.InvitationRecord{
+		Email:     "keitaro-user14@gmail.com",
+		Roles:     []string{"user"},
+		Token:     ctrl.TokenHasher.Hash("expired-invitation-token"),
+		CreatedAt: 1000,
+		ExpiresAt: 2000,
+	}
+	if _, err := db.Invitations.Save(invitation, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	_, invitations := test.ListInvitationsUserOK(t, context.Background(), service, ctrl)
+	for _, listed := range invitations {
+		if listed.Email == invitation.Email {
+			t.Errorf("Expected the expired invitation not to be listed, got %v", listed)
+		}
+	}
+
+	test.AcceptInvitationUserBadRequest(t, context.Background(), service, ctrl, &app.AcceptInvitationPayload{
+		Token:    "expired-invitation-token",
+		Password: "keitaro",
+	})
+	test.AcceptInvitationUserNotFound(t, context.Background(), service, ctrl, &app.AcceptInvitationPayload{
+		Token:    "expired-invitation-token",
+		Password: "keitaro",
+	})
+}
+
+func TestEmailChangeFlow(t *testing.T) {
+	emailDB := store.NewDB()
+	channel := &recordingChannel{}
+	emailCtrl := NewUserController(service, emailDB, channel, nil, passwordPolicy, passwordHashing)
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: "5df2103b5f1b640001142d40"})
+	newEmail := "keitaro-user5-new@gmail.com"
+
+	test.RequestEmailChangeUserForbidden(t, ctx, service, emailCtrl, &app.ChangeEmailPayload{
+		Email:           newEmail,
+		CurrentPassword: "wrong-password",
+	})
+	test.RequestEmailChangeUserBadRequest(t, ctx, service, emailCtrl, &app.ChangeEmailPayload{
+		Email:           "keitaro-user3@gmail.com",
+		CurrentPassword: "keitaro",
+	})
+	test.RequestEmailChangeUserOK(t, ctx, service, emailCtrl, &app.ChangeEmailPayload{
+		Email:           newEmail,
+		CurrentPassword: "keitaro",
+	})
+
+	if len(channel.messages) != 2 {
+		t.Fatalf("Expected two emails, got %v", channel.messages)
+	}
+	if channel.messages[0].TemplateName != "emailChange" || channel.messages[0].Email != newEmail {
+		t.Errorf("Expected the token to be sent to the new email, got %v", channel.messages[0])
+	}
+	if channel.messages[1].TemplateName != "emailChangeRequested" || channel.messages[1].Email != "keitaro-user5@gmail.com" {
+		t.Errorf("Expected the old email to be notified, got %v", channel.messages[1])
+	}
+
+	// the email is not changed until confirmed
+	_, user := test.GetMeUserOK(t, ctx, service, emailCtrl)
+	if user.Email != "keitaro-user5@gmail.com" || user.PendingEmail == nil || *user.PendingEmail != newEmail {
+		t.Errorf("Expected the new email to be pending, got %s %v", user.Email, user.PendingEmail)
+	}
+
+	token := channel.messages[0].Data["token"]
+	_, user = test.ConfirmEmailChangeUserOK(t, context.Background(), service, emailCtrl, &app.TokenPayload{Token: token})
+	if user.Email != newEmail || user.PendingEmail != nil {
+		t.Errorf("Expected the email to be changed, got %s %v", user.Email, user.PendingEmail)
+	}
+
+	test.ConfirmEmailChangeUserNotFound(t, context.Background(), service, emailCtrl, &app.TokenPayload{Token: token})
+}
+
+func TestConfirmEmailChangeExpired(t *testing.T) {
+	emailDB := store.NewDB()
+	emailCtrl := NewUserController(service, emailDB, nil, nil, passwordPolicy, passwordHashing)
+
+	update := map[string]interface{}{
+		"pendingEmail":          "keitaro-user5-new@gmail.com",
+		"pendingEmailToken":     emailCtrl.TokenHasher.Hash("expired-email-token"),
+		"pendingEmailExpiresAt": 2000,
+	}
+	if _, err := emailDB.Users.Save(&update, backends.NewFilter().Match("id", "5df2103b5f1b640001142d40")); err != nil {
+		t.Fatal(err)
+	}
+
+	test.ConfirmEmailChangeUserBadRequest(t, context.Background(), service, emailCtrl, &app.TokenPayload{Token: "expired-email-token"})
+
+	user := &store.UserRecord{}
+	if _, err := emailDB.Users.GetOne(backends.NewFilter().Match("id", "5df2103b5f1b640001142d40"), user); err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != "keitaro-user5@gmail.com" || user.PendingEmail != "" {
+		t.Errorf("Expected the pending email to be cleared, got %s %s", user.Email, user.PendingEmail)
+	}
+}
+
+func TestUpdateUserEmailBadRequest(t *testing.T) {
+	email := "keitaro-user1-new@gmail.com"
+	test.UpdateUserBadRequest(t, context.Background(), service, ctrl, ID, nil, &app.UpdateUserPayload{
+		Email: &email,
+	})
+}
+
+func TestCreateUserNormalizesEmail(t *testing.T) {
+	password := "keitaro"
+	extID := "some-id"
+	_, user := test.CreateUserCreated(t, context.Background(), service, ctrl, &app.CreateUserPayload{
+		Email:      "Keitaro-User15@GMAIL.com",
+		Password:   &password,
+		ExternalID: &extID,
+	})
+	if user.Email != "keitaro-user15@gmail.com" {
+		t.Errorf("Expected the normalized email, got %s", user.Email)
+	}
+	if user.DisplayEmail == nil || *user.DisplayEmail != "Keitaro-User15@GMAIL.com" {
+		t.Errorf("Expected the email as entered to be kept, got %v", user.DisplayEmail)
+	}
+
+	_, found := test.FindByEmailUserOK(t, context.Background(), service, ctrl, &app.EmailPayload{
+		Email: "KEITARO-USER15@gmail.com",
+	})
+	if found.Email != "keitaro-user15@gmail.com" {
+		t.Errorf("Expected the user to be found regardless of the case, got %s", found.Email)
+	}
+}
+
+// failingSaveRepository fails to save changes of the existing records.
+type failingSaveRepository struct {
+	backends.Repository
+}
+
+func (r *failingSaveRepository) Save(object interface{}, filter backends.Filter) (interface{}, error) {
+	if filter != nil {
+		return nil, backends.ErrBackendError("save failed")
+	}
+	return r.Repository.Save(object, filter)
+}
+
+func TestMergeUsers(t *testing.T) {
+	mergeDB := store.NewDB()
+	mergeCtrl := NewUserController(service, mergeDB, nil, nil, passwordPolicy, passwordHashing)
+
+	ids := []string{}
+	for _, user := range []map[string]interface{}{
+		{"email": "Keitaro-User16@gmail.com", "roles": []string{"user"}, "organizations": []string{"keitaro"}, "active": true},
+		{"email": "keitaro-user16@GMAIL.com", "roles": []string{"user", "admin"}, "namespaces": []string{"microkubes"}, "active": true},
+	} {
+		result, err := mergeDB.Users.Save(&user, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, (*result.(*map[string]interface{}))["id"].(string))
+	}
+
+	_, duplicates := test.GetDuplicatesUserOK(t, context.Background(), service, mergeCtrl)
+	if len(duplicates) != 1 || duplicates[0].Email != "keitaro-user16@gmail.com" || len(duplicates[0].Users) != 2 {
+		t.Fatalf("Expected one group of duplicates, got %v", duplicates)
+	}
+
+	payload := &app.MergeUsersPayload{
+		UserID:       ids[0],
+		DuplicateIds: []string{ids[1]},
+	}
+	test.MergeUsersUserForbidden(t, context.Background(), service, mergeCtrl, payload)
+
+	adminCtx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"admin"}})
+	test.MergeUsersUserBadRequest(t, adminCtx, service, mergeCtrl, &app.MergeUsersPayload{
+		UserID:       ids[0],
+		DuplicateIds: []string{ID},
+	})
+
+	// the duplicates are kept when the merged memberships cannot be saved
+	failingDB := mergeDB
+	failingDB.Users = &failingSaveRepository{Repository: mergeDB.Users}
+	failingCtrl := NewUserController(service, failingDB, nil, nil, passwordPolicy, passwordHashing)
+	test.MergeUsersUserInternalServerError(t, adminCtx, service, failingCtrl, payload)
+	test.GetUserOK(t, context.Background(), service, mergeCtrl, ids[1])
+
+	_, user := test.MergeUsersUserOK(t, adminCtx, service, mergeCtrl, payload)
+	if user.Email != "keitaro-user16@gmail.com" || user.DisplayEmail == nil || *user.DisplayEmail != "Keitaro-User16@gmail.com" {
+		t.Errorf("Expected the normalized email, got %s %v", user.Email, user.DisplayEmail)
+	}
+	if len(user.Roles) != 2 || len(user.Organizations) != 1 || len(user.Namespaces) != 1 {
+		t.Errorf("Expected the roles and memberships to be merged, got %v %v %v", user.Roles, user.Organizations, user.Namespaces)
+	}
+
+	test.GetUserNotFound(t, context.Background(), service, mergeCtrl, ids[1])
+	_, duplicates = test.GetDuplicatesUserOK(t, context.Background(), service, mergeCtrl)
+	if len(duplicates) != 0 {
+		t.Errorf("Expected no duplicates, got %v", duplicates)
+	}
+}
+
+func TestMagicLinkFlow(t *testing.T) {
+	magicDB := store.NewDB()
+	channel := &recordingChannel{}
+	magicCtrl := NewUserController(service, magicDB, channel, nil, passwordPolicy, passwordHashing)
+
+	test.RequestMagicLinkUserOK(t, context.Background(), service, magicCtrl, &app.EmailPayload{Email: notFonundEmail})
+	if len(channel.messages) != 0 {
+		t.Fatalf("Expected no email for an unknown user, got %v", channel.messages)
+	}
+
+	test.RequestMagicLinkUserOK(t, context.Background(), service, magicCtrl, &app.EmailPayload{Email: "Keitaro-User5@gmail.com"})
+	if len(channel.messages) != 1 || channel.messages[0].TemplateName != "magicLink" || channel.messages[0].Email != "keitaro-user5@gmail.com" {
+		t.Fatalf("Expected magic link email, got %v", channel.messages)
+	}
+	token := channel.messages[0].Data["token"]
+
+	_, user := test.FindByMagicLinkUserOK(t, context.Background(), service, magicCtrl, &app.TokenPayload{Token: token})
+	if user.Email != "keitaro-user5@gmail.com" || user.LastLoginAt == nil {
+		t.Errorf("Expected the user with the login recorded, got %s %v", user.Email, user.LastLoginAt)
+	}
+
+	// the token is single-use
+	test.FindByMagicLinkUserNotFound(t, context.Background(), service, magicCtrl, &app.TokenPayload{Token: token})
+}
+
+// staleRepository returns the user as it was before a change the first time it is looked up by the given
+// field, as a concurrent request that read the user at the same time would see it.
+type staleRepository struct {
+	backends.Repository
+	field string
+	stale *store.UserRecord
+}
+
+func (r *staleRepository) GetOne(filter backends.Filter, result interface{}) (interface{}, error) {
+	if _, ok := filter[r.field]; ok && r.stale != nil {
+		*result.(*store.UserRecord) = *r.stale
+		r.stale = nil
+		return result, nil
+	}
+	return r.Repository.GetOne(filter, result)
+}
+
+func TestFindByMagicLinkConcurrent(t *testing.T) {
+	magicDB := store.NewDB()
+	magicCtrl := NewUserController(service, magicDB, nil, nil, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d40"
+
+	update := map[string]interface{}{
+		"magicLinkToken":     magicCtrl.TokenHasher.Hash("concurrent-magic-link-token"),
+		"magicLinkExpiresAt": helpers.CurrentTimeMilliseconds() + 60000,
+	}
+	if _, err := magicDB.Users.Save(&update, backends.NewFilter().Match("id", userID)); err != nil {
+		t.Fatal(err)
+	}
+	stale := &staleRepository{Repository: magicDB.Users, field: "magicLinkToken", stale: &store.UserRecord{}}
+	if _, err := magicDB.Users.GetOne(backends.NewFilter().Match("id", userID), stale.stale); err != nil {
+		t.Fatal(err)
+	}
+
+	test.FindByMagicLinkUserOK(t, context.Background(), service, magicCtrl, &app.TokenPayload{Token: "concurrent-magic-link-token"})
+
+	staleDB := magicDB
+	staleDB.Users = stale
+	staleCtrl := NewUserController(service, staleDB, nil, nil, passwordPolicy, passwordHashing)
+	test.FindByMagicLinkUserNotFound(t, context.Background(), service, staleCtrl, &app.TokenPayload{Token: "concurrent-magic-link-token"})
+}
+
+func TestFindByMagicLinkMFA(t *testing.T) {
+	magicDB := store.NewDB()
+	magicCtrl := NewUserController(service, magicDB, nil, nil, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d43"
+
+	update := map[string]interface{}{
+		"mfaEnabled":         true,
+		"failedLogins":       2,
+		"magicLinkToken":     magicCtrl.TokenHasher.Hash("mfa-magic-link-token"),
+		"magicLinkExpiresAt": helpers.CurrentTimeMilliseconds() + 60000,
+	}
+	if _, err := magicDB.Users.Save(&update, backends.NewFilter().Match("id", userID)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, user := test.FindByMagicLinkUserOK(t, context.Background(), service, magicCtrl, &app.TokenPayload{Token: "mfa-magic-link-token"})
+	if user.LastLoginAt != nil {
+		t.Errorf("Expected no login before the second factor is verified, got %v", *user.LastLoginAt)
+	}
+
+	saved := &store.UserRecord{}
+	if _, err := magicDB.Users.GetOne(backends.NewFilter().Match("id", userID), saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.FailedLogins != 2 {
+		t.Errorf("Expected the failed logins to be kept until the second factor is verified, got %d", saved.FailedLogins)
+	}
+	if _, logins := test.GetLoginsUserOK(t, context.Background(), service, magicCtrl, userID, nil, nil); len(logins) != 0 {
+		t.Errorf("Expected no login in the history, got %v", logins)
+	}
+}
+
+func TestFindByMagicLinkLocked(t *testing.T) {
+	magicDB := store.NewDB()
+	magicCtrl := NewUserController(service, magicDB, nil, nil, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d40"
+
+	user := &store.UserRecord{}
+	if _, err := magicDB.Users.GetOne(backends.NewFilter().Match("id", userID), user); err != nil {
+		t.Fatal(err)
+	}
+	now := helpers.CurrentTimeMilliseconds()
+	update, err := user.StatusUpdate(store.StatusLocked, "too many failed logins", "system", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update["lockedUntil"] = now + 60000
+	update["magicLinkToken"] = magicCtrl.TokenHasher.Hash("locked-magic-link-token")
+	update["magicLinkExpiresAt"] = now + 60000
+	if _, err = magicDB.Users.Save(&update, backends.NewFilter().Match("id", userID)); err != nil {
+		t.Fatal(err)
+	}
+
+	test.FindByMagicLinkUserLocked(t, context.Background(), service, magicCtrl, &app.TokenPayload{Token: "locked-magic-link-token"})
+}
+
+func TestFindByMagicLinkExpired(t *testing.T) {
+	magicDB := store.NewDB()
+	magicCtrl := NewUserController(service, magicDB, nil, nil, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d40"
+
+	update := map[string]interface{}{
+		"magicLinkToken":     magicCtrl.TokenHasher.Hash("expired-magic-link-token"),
+		"magicLinkExpiresAt": 2000,
+	}
+	if _, err := magicDB.Users.Save(&update, backends.NewFilter().Match("id", userID)); err != nil {
+		t.Fatal(err)
+	}
+
+	test.FindByMagicLinkUserNotFound(t, context.Background(), service, magicCtrl, &app.TokenPayload{Token: "expired-magic-link-token"})
+
+	user := &store.UserRecord{}
+	if _, err := magicDB.Users.GetOne(backends.NewFilter().Match("id", userID), user); err != nil {
+		t.Fatal(err)
+	}
+	if user.MagicLinkToken != "" {
+		t.Error("Expected the expired token to be consumed")
+	}
+}
+
+func TestUpdateUserIfMatch(t *testing.T) {
+	versionDB := store.NewDB()
+	versionCtrl := NewUserController(service, versionDB, nil, nil, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d40"
+
+	rw, _ := test.GetUserOK(t, context.Background(), service, versionCtrl, userID)
+	etag := rw.Header().Get("ETag")
+	if etag != `"0"` {
+		t.Fatalf("Expected the ETag of an unversioned user, got %s", etag)
+	}
+
+	rw, user := test.UpdateUserOK(t, context.Background(), service, versionCtrl, userID, &etag, &app.UpdateUserPayload{
+		Active: true,
+		Roles:  []string{"user", "admin"},
+	})
+	if rw.Header().Get("ETag") != `"1"` || len(user.Roles) != 2 {
+		t.Fatalf("Expected the updated user with a new ETag, got %s %v", rw.Header().Get("ETag"), user.Roles)
+	}
+
+	// the ETag read before the update is stale
+	test.UpdateUserPreconditionFailed(t, context.Background(), service, versionCtrl, userID, &etag, &app.UpdateUserPayload{
+		Active: true,
+		Roles:  []string{"user"},
+	})
+
+	_, user = test.GetUserOK(t, context.Background(), service, versionCtrl, userID)
+	if len(user.Roles) != 2 {
+		t.Errorf("Expected the rejected update not to be saved, got %v", user.Roles)
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	patchDB := store.NewDB()
+	patchCtrl := NewUserController(service, patchDB, nil, nil, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d40"
+
+	_, user := test.PatchUserOK(t, context.Background(), service, patchCtrl, userID, nil, app.PatchUserPayload{
+		"externalId": "ext-5",
+		"roles":      []interface{}{"user", "admin"},
+	})
+	if !user.Active || user.ExternalID == nil || *user.ExternalID != "ext-5" || len(user.Roles) != 2 {
+		t.Fatalf("Expected only the patched fields to change, got %v %v %v", user.Active, user.ExternalID, user.Roles)
+	}
+
+	_, user = test.PatchUserOK(t, context.Background(), service, patchCtrl, userID, nil, app.PatchUserPayload{
+		"externalId":    nil,
+		"organizations": nil,
+	})
+	if user.ExternalID != nil || len(user.Organizations) != 0 || len(user.Roles) != 2 {
+		t.Errorf("Expected the null fields to be cleared, got %v %v %v", user.ExternalID, user.Organizations, user.Roles)
+	}
+
+	test.PatchUserBadRequest(t, context.Background(), service, patchCtrl, userID, nil, app.PatchUserPayload{"active": nil})
+	test.PatchUserBadRequest(t, context.Background(), service, patchCtrl, userID, nil, app.PatchUserPayload{"roles": "admin"})
+	test.PatchUserBadRequest(t, context.Background(), service, patchCtrl, userID, nil, app.PatchUserPayload{"status": "active"})
+	test.PatchUserNotFound(t, context.Background(), service, patchCtrl, notFoundID, nil, app.PatchUserPayload{"active": true})
+}
+
+func TestUpdateUserFieldPolicy(t *testing.T) {
+	policyDB := store.NewDB()
+	policyCtrl := NewUserController(service, policyDB, nil, nil, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d40"
+	ownerCtx := auth.SetAuth(context.Background(), &auth.Auth{UserID: userID, Roles: []string{"user"}})
+	otherCtx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"user"}})
+	adminCtx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"admin"}})
+
+	// "active" defaults to false, so the owner would deactivate the account
+	_, err := test.UpdateUserForbidden(t, ownerCtx, service, policyCtrl, userID, nil, &app.UpdateUserPayload{
+		Roles: []string{"user", "admin"},
+	})
+	fields := err.(*goa.ErrorResponse).Meta["fields"]
+	if !reflect.DeepEqual(fields, []string{"active", "roles"}) {
+		t.Errorf("Expected the active and roles fields to be rejected, got %v", fields)
+	}
+
+	password := "owner-password-1"
+	test.UpdateUserOK(t, ownerCtx, service, policyCtrl, userID, nil, &app.UpdateUserPayload{
+		Active:   true,
+		Password: &password,
+	})
+
+	password = "other-password-1"
+	test.UpdateUserForbidden(t, otherCtx, service, policyCtrl, userID, nil, &app.UpdateUserPayload{
+		Active:   true,
+		Password: &password,
+	})
+
+	_, user := test.UpdateUserOK(t, adminCtx, service, policyCtrl, userID, nil, &app.UpdateUserPayload{
+		Active: true,
+		Roles:  []string{"user", "admin"},
+	})
+	if len(user.Roles) != 2 {
+		t.Errorf("Expected the admin to change the roles, got %v", user.Roles)
+	}
+}
+
+func TestUpdateMe(t *testing.T) {
+	meDB := store.NewDB()
+	channel := &recordingChannel{}
+	meCtrl := NewUserController(service, meDB, channel, nil, passwordPolicy, passwordHashing)
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: "5df2103b5f1b640001142d40", Roles: []string{"user"}})
+
+	newEmail := "keitaro-user5-me@gmail.com"
+	test.UpdateMeUserForbidden(t, ctx, service, meCtrl, nil, &app.UpdateMePayload{Email: &newEmail})
+
+	currentPassword := "keitaro"
+	_, user := test.UpdateMeUserOK(t, ctx, service, meCtrl, nil, &app.UpdateMePayload{
+		Email:           &newEmail,
+		CurrentPassword: &currentPassword,
+	})
+	if user.Email != "keitaro-user5@gmail.com" || user.PendingEmail == nil || *user.PendingEmail != newEmail {
+		t.Errorf("Expected the email change to wait for confirmation, got %s %v", user.Email, user.PendingEmail)
+	}
+	if len(channel.messages) == 0 || channel.messages[0].TemplateName != "emailChange" {
+		t.Errorf("Expected the email change confirmation to be sent, got %v", channel.messages)
+	}
+
+	_, err := test.PatchMeUserForbidden(t, ctx, service, meCtrl, nil, app.PatchMeUserPayload{
+		"roles":  []interface{}{"admin"},
+		"active": true,
+	})
+	if fields := err.(*goa.ErrorResponse).Meta["fields"]; !reflect.DeepEqual(fields, []string{"active", "roles"}) {
+		t.Errorf("Expected the active and roles fields to be rejected, got %v", fields)
+	}
+
+	// nothing is saved when any of the changes is rejected
+	test.PatchMeUserForbidden(t, ctx, service, meCtrl, nil, app.PatchMeUserPayload{
+		"profile":         map[string]interface{}{"firstName": "Ana"},
+		"password":        "new-password-5",
+		"currentPassword": "wrong-password",
+	})
+	test.PatchMeUserBadRequest(t, ctx, service, meCtrl, nil, app.PatchMeUserPayload{
+		"profile":         map[string]interface{}{"firstName": "Ana"},
+		"password":        "short",
+		"currentPassword": currentPassword,
+	})
+	_, user = test.GetMeUserOK(t, ctx, service, meCtrl)
+	if len(user.Profile) != 0 {
+		t.Errorf("Expected the profile not to be changed by a rejected update, got %v", user.Profile)
+	}
+
+	test.PatchMeUserOK(t, ctx, service, meCtrl, nil, app.PatchMeUserPayload{
+		"password":        "new-password-5",
+		"currentPassword": currentPassword,
+	})
+	test.PatchMeUserForbidden(t, ctx, service, meCtrl, nil, app.PatchMeUserPayload{
+		"password":        "newer-password-5",
+		"currentPassword": currentPassword,
+	})
+}
+
+func TestUserProfile(t *testing.T) {
+	profileDB := store.NewDB()
+	profileCtrl := NewUserController(service, profileDB, nil, &config.ServiceConfig{
+		Profile: map[string]config.ProfileAttribute{
+			"firstName": {Visibility: config.VisibilityPublic},
+			"phone":     {Visibility: config.VisibilitySelf},
+			"tier":      {Visibility: config.VisibilityAdmin, Enum: []interface{}{"gold", "silver"}},
+		},
+	}, passwordPolicy, passwordHashing)
+	userID := "5df2103b5f1b640001142d40"
+	ownerCtx := auth.SetAuth(context.Background(), &auth.Auth{UserID: userID, Roles: []string{"user"}})
+	otherCtx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"user"}})
+	adminCtx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"admin"}})
+
+	test.CreateUserBadRequest(t, adminCtx, service, profileCtrl, &app.CreateUserPayload{
+		Email:      "profile-user@example.com",
+		ExternalID: &userID,
+		Profile:    map[string]interface{}{"tier": "bronze"},
+	})
+
+	_, user := test.PatchUserOK(t, adminCtx, service, profileCtrl, userID, nil, app.PatchUserPayload{
+		"profile": map[string]interface{}{"firstName": "Ana", "phone": "+38970", "tier": "gold"},
+	})
+	if len(user.Profile) != 3 {
+		t.Fatalf("Expected all the attributes for the admin, got %v", user.Profile)
+	}
+
+	_, user = test.GetUserOK(t, otherCtx, service, profileCtrl, userID)
+	if !reflect.DeepEqual(user.Profile, map[string]interface{}{"firstName": "Ana"}) {
+		t.Errorf("Expected only the public attributes for other users, got %v", user.Profile)
+	}
+	_, user = test.GetMeUserOK(t, ownerCtx, service, profileCtrl)
+	if !reflect.DeepEqual(user.Profile, map[string]interface{}{"firstName": "Ana", "phone": "+38970"}) {
+		t.Errorf("Expected the public and self attributes for the user, got %v", user.Profile)
+	}
+
+	_, err := test.PatchMeUserForbidden(t, ownerCtx, service, profileCtrl, nil, app.PatchMeUserPayload{
+		"profile": map[string]interface{}{"tier": "silver"},
+	})
+	if fields := err.(*goa.ErrorResponse).Meta["fields"]; !reflect.DeepEqual(fields, []string{"profile.tier"}) {
+		t.Errorf("Expected the admin attribute to be rejected, got %v", fields)
+	}
+	_, user = test.PatchMeUserOK(t, ownerCtx, service, profileCtrl, nil, app.PatchMeUserPayload{
+		"profile": map[string]interface{}{"phone": nil},
+	})
+	if !reflect.DeepEqual(user.Profile, map[string]interface{}{"firstName": "Ana"}) {
+		t.Errorf("Expected the phone to be removed, got %v", user.Profile)
+	}
+
+	ana, gold := "Ana", "gold"
+	_, page := test.FindUsersUserOK(t, otherCtx, service, profileCtrl, &app.FilterPayload{
+		Page:     1,
+		PageSize: 10,
+		Filter:   []*app.FilterProperty{{Property: "profile.firstName", Operator: "eq", Value: &ana}},
+	})
+	if len(page.Items) != 1 || page.Items[0].ID != userID {
+		t.Errorf("Expected the user to be found by the public attribute, got %v", page.Items)
+	}
+	test.FindUsersUserBadRequest(t, otherCtx, service, profileCtrl, &app.FilterPayload{
+		Page:     1,
+		PageSize: 10,
+		Filter:   []*app.FilterProperty{{Property: "profile.tier", Operator: "eq", Value: &gold}},
+	})
+}
+
+func TestFindUsersFilter(t *testing.T) {
+	filterDB := store.NewDB()
+	filterCtrl := NewUserController(service, filterDB, nil, nil, passwordPolicy, passwordHashing)
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"admin"}})
+
+	findEmails := func(payload *app.FilterPayload) []string {
+		payload.Page = 1
+		payload.PageSize = 10
+		_, page := test.FindUsersUserOK(t, ctx, service, filterCtrl, payload)
+		emails := []string{}
+		for _, user := range page.Items {
+			emails = append(emails, user.Email)
+		}
+		return emails
+	}
+
+	prefix, user5, user6 := "keitaro-user", "keitaro-user5@gmail.com", "keitaro-user6@gmail.com"
+	emails := findEmails(&app.FilterPayload{
+		Filter: []*app.FilterProperty{
+			{Property: "email", Operator: "prefix", Value: &prefix},
+			{Property: "email", Operator: "nin", Values: []string{user5, user6}},
+		},
+		Sort: &app.OrderSpec{Property: "email", Direction: "desc"},
+	})
+	expected := []string{"keitaro-user8@gmail.com", "keitaro-user7@gmail.com", "keitaro-user4@gmail.com", "keitaro-user3@gmail.com"}
+	if !reflect.DeepEqual(emails, expected) {
+		t.Errorf("Expected %v, got %v", expected, emails)
+	}
+
+	role, user7, user8 := "user", "user7", "user8"
+	emails = findEmails(&app.FilterPayload{
+		Filter: []*app.FilterProperty{{Property: "roles", Operator: "eq", Value: &role}},
+		AnyOf: []*app.FilterGroup{
+			{Filter: []*app.FilterProperty{{Property: "email", Operator: "contains", Value: &user7}}},
+			{Filter: []*app.FilterProperty{{Property: "email", Operator: "contains", Value: &user8}}},
+		},
+		Sort: &app.OrderSpec{Property: "email", Direction: "asc"},
+	})
+	if !reflect.DeepEqual(emails, []string{"keitaro-user7@gmail.com", "keitaro-user8@gmail.com"}) {
+		t.Errorf("Expected the users of either group, got %v", emails)
+	}
+
+	for _, property := range []*app.FilterProperty{
+		{Property: "password", Operator: "eq", Value: &prefix},
+		{Property: "forgotPasswordTokens.token", Operator: "exists"},
+		{Property: "email", Operator: "gt", Value: &prefix},
+		{Property: "createdAt", Operator: "gte", Value: &prefix},
+		{Property: "roles", Operator: "in"},
+	} {
+		test.FindUsersUserBadRequest(t, ctx, service, filterCtrl, &app.FilterPayload{
+			Page:     1,
+			PageSize: 10,
+			Filter:   []*app.FilterProperty{property},
+		})
+	}
+	test.FindUsersUserBadRequest(t, ctx, service, filterCtrl, &app.FilterPayload{
+		Page:     1,
+		PageSize: 10,
+		Sort:     &app.OrderSpec{Property: "password", Direction: "asc"},
+	})
+}
+
+func TestFindUsersPagination(t *testing.T) {
+	pageDB := store.NewDB()
+	pageCtrl := NewUserController(service, pageDB, nil, nil, passwordPolicy, passwordHashing)
+	ctx := auth.SetAuth(context.Background(), &auth.Auth{UserID: ID, Roles: []string{"admin"}})
+	byID := &app.OrderSpec{Property: "id", Direction: "asc"}
+
+	// the inactive user is left out in the query, so the pages are full
+	_, page := test.FindUsersUserOK(t, ctx, service, pageCtrl, &app.FilterPayload{Page: 1, PageSize: 4, Sort: byID, IncludeTotal: true})
+	if len(page.Items) != 4 || *page.Total != 6 || *page.Page != 1 || page.NextCursor != nil {
+		t.Fatalf("Expected 4 of 6 users on the first page, got %d of %d", len(page.Items), *page.Total)
+	}
+	// the users are counted only on request
+	_, page = test.FindUsersUserOK(t, ctx, service, pageCtrl, &app.FilterPayload{Page: 2, PageSize: 4, Sort: byID})
+	if len(page.Items) != 2 || page.Total != nil || page.Items[0].ID != "5df2103b5f1b640001142d42" {
+		t.Fatalf("Expected the last 2 users on the second page, got %v", page.Items)
+	}
+
+	cursor := ""
+	_, page = test.FindUsersUserOK(t, ctx, service, pageCtrl, &app.FilterPayload{PageSize: 4, Cursor: &cursor, IncludeTotal: true})
+	if len(page.Items) != 4 || *page.Total != 6 || page.Page != nil || page.NextCursor == nil {
+		t.Fatalf("Expected the first 4 users with a next cursor, got %v", page.Items)
+	}
+	last := page.Items[3].ID
+
+	// a user added while iterating comes after the cursor
+	added := bson.NewObjectId()
+	if _, err := pageDB.Users.Save(&store.UserRecord{ID: added, Email: "added@example.com", Roles: []string{"user"}, Status: store.StatusActive}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	_, page = test.FindUsersUserOK(t, ctx, service, pageCtrl, &app.FilterPayload{PageSize: 4, Cursor: page.NextCursor, IncludeTotal: true})
+	ids := []string{}
+	for _, user := range page.Items {
+		if user.ID <= last {
+			t.Errorf("Expected the users after %s, got %s", last, user.ID)
+		}
+		ids = append(ids, user.ID)
+	}
+	if len(ids) != 3 || *page.Total != 7 || page.NextCursor != nil {
+		t.Errorf("Expected the last 3 users without a next cursor, got %v", ids)
+	}
+
+	invalid := "not-a-cursor"
+	test.FindUsersUserBadRequest(t, ctx, service, pageCtrl, &app.FilterPayload{PageSize: 4, Cursor: &invalid})
+	test.FindUsersUserBadRequest(t, ctx, service, pageCtrl, &app.FilterPayload{
+		PageSize: 4,
+		Cursor:   &cursor,
+		Sort:     &app.OrderSpec{Property: "email", Direction: "asc"},
+	})
+}
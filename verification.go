@@ -0,0 +1,120 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/config"
+	"github.com/Microkubes/microservice-user/helpers"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+)
+
+// tokenSweepBatchSize is the number of tokens processed at once by the expired tokens sweeper.
+const tokenSweepBatchSize = 100
+
+// saveVerificationToken saves the hash of a new email verification token for the user with the given email.
+// The token expires after the configured TTL.
+func (c *UserController) saveVerificationToken(email, token string) (interface{}, error) {
+	now := helpers.CurrentTimeMilliseconds()
+	tokenPayload := map[string]interface{}{
+		"email":     email,
+		"token":     c.TokenHasher.Hash(token),
+		"issuedAt":  now,
+		"expiresAt": now + int64(c.Config.GetVerificationToken().TTL)*1000,
+	}
+	return c.Store.Tokens.Save(&tokenPayload, nil)
+}
+
+// sendVerificationEmail publishes the "verification" email with the token, unless the callers are configured
+// to send their own email.
+func (c *UserController) sendVerificationEmail(userID, email, token string) error {
+	if c.Config.GetVerificationToken().ExternalEmail {
+		return nil
+	}
+	return c.sendEmail(email, "verification", map[string]string{
+		"id":    userID,
+		"email": email,
+		"token": token,
+	})
+}
+
+// sweepExpiredTokens deletes the expired verification tokens, the ones expiring first are processed first.
+// Tokens issued before the expiry was introduced get an expiry of one TTL from now. Returns the number of
+// deleted tokens.
+func sweepExpiredTokens(tokens backends.Repository, ttl, now int64) (int, error) {
+	deleted := 0
+	for {
+		var typeHint map[string]interface{}
+		result, err := tokens.GetAll(backends.NewFilter(), typeHint, "expiresAt", "asc", tokenSweepBatchSize, 0)
+		if err != nil {
+			if backends.IsErrNotFound(err) {
+				return deleted, nil
+			}
+			return deleted, err
+		}
+
+		records := []*store.TokenRecord{}
+		if result != nil {
+			if err = backends.MapToInterface(result, &records); err != nil {
+				return deleted, err
+			}
+		}
+
+		done := len(records) < tokenSweepBatchSize
+		for _, record := range records {
+			if record.ExpiresAt == 0 {
+				update := map[string]interface{}{
+					"issuedAt":  now,
+					"expiresAt": now + ttl,
+				}
+				if _, err = tokens.Save(&update, backends.NewFilter().Match("id", record.ID)); err != nil {
+					return deleted, err
+				}
+				continue
+			}
+			if !record.IsExpired(now) {
+				done = true
+				continue
+			}
+			if err = tokens.DeleteOne(backends.NewFilter().Match("id", record.ID)); err != nil && !backends.IsErrNotFound(err) {
+				return deleted, err
+			}
+			deleted++
+		}
+
+		if done {
+			return deleted, nil
+		}
+	}
+}
+
+// startTokenSweeper periodically deletes the expired verification tokens, for backends that do not expire
+// records natively. Returns a function that stops the sweeper.
+func startTokenSweeper(service *goa.Service, tokens backends.Repository, cfg config.VerificationToken) func() {
+	ticker := time.NewTicker(time.Duration(cfg.SweepInterval) * time.Second)
+	stop := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				deleted, err := sweepExpiredTokens(tokens, int64(cfg.TTL)*1000, helpers.CurrentTimeMilliseconds())
+				if err != nil {
+					service.LogError("Failed to delete expired verification tokens.", "err", err.Error())
+					continue
+				}
+				if deleted > 0 {
+					service.LogInfo("Deleted expired verification tokens.", "count", deleted)
+				}
+			case <-stop:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/store"
+)
+
+func TestSweepExpiredTokens(t *testing.T) {
+	tokens := store.NewDB().Tokens
+	now := int64(1600000000000)
+
+	for token, expiresAt := range map[string]int64{
+		"expired-token": now - 1000,
+		"valid-token":   now + 1000,
+	} {
+		record := map[string]interface{}{
+			"email":     "keitaro-user1@gmail.com",
+			"token":     token,
+			"issuedAt":  now - 5000,
+			"expiresAt": expiresAt,
+		}
+		if _, err := tokens.Save(&record, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := sweepExpiredTokens(tokens, 60000, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deleted token, got %d", deleted)
+	}
+
+	for token, expected := range map[string]int64{
+		"valid-token": now + 1000,
+		NewTokenHasher(nil).Hash("sdaewefdc234erfdd123erfdxc23edx"): now + 60000, // issued before the expiry was introduced
+		"expired-token": 0,
+	} {
+		record := &store.TokenRecord{}
+		_, err := tokens.GetOne(backends.NewFilter().Match("token", token), record)
+		if expected == 0 {
+			if !backends.IsErrNotFound(err) {
+				t.Errorf("Expected %s to be deleted, got %v", token, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.ExpiresAt != expected {
+			t.Errorf("Expected %s to expire at %d, got %d", token, expected, record.ExpiresAt)
+		}
+	}
+}
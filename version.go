@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/keitaroinc/goa"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// errPreconditionFailed is returned when the If-Match header does not match the current version of the user.
+var errPreconditionFailed = goa.NewErrorClass("precondition_failed", 412)
+
+// errVersionConflict is returned when the user has been changed since it was read.
+var errVersionConflict = errors.New("the user has been modified concurrently")
+
+// userETag returns the ETag of the user: the quoted version.
+func userETag(user *store.UserRecord) string {
+	return strconv.Quote(strconv.FormatInt(user.Version, 10))
+}
+
+// matchesETag checks the value of an If-Match header against the ETag of the user. The ETags are compared
+// strongly, so weak ETags never match.
+func matchesETag(ifMatch string, user *store.UserRecord) bool {
+	etag := userETag(user)
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// maxUpdateAttempts limits the attempts of updateUser to save an update in spite of concurrent changes.
+const maxUpdateAttempts = 5
+
+// updateUser saves the update that build returns for the user, as saveUser. On a version conflict the user is
+// read again and the update built anew, so build must check the preconditions of the update on the user it
+// gets. A nil update leaves the user as it is. Returns the saved user.
+func (c *UserController) updateUser(user *store.UserRecord, build func(user *store.UserRecord) (map[string]interface{}, error)) (*store.UserRecord, error) {
+	id := user.ID.Hex()
+	for attempt := 1; ; attempt++ {
+		update, err := build(user)
+		if err != nil || update == nil {
+			return user, err
+		}
+		updated, err := c.saveUser(user, update)
+		if err != errVersionConflict || attempt == maxUpdateAttempts {
+			return updated, err
+		}
+		user = &store.UserRecord{}
+		if _, err = c.Store.Users.GetOne(backends.NewFilter().Match("id", id), user); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// unversioned matches the users saved before versioning, which have no version yet.
+var unversioned = map[string]interface{}{"$in": []interface{}{nil, 0}}
+
+// saveUser saves the update of the user only if the user still has the version it was read with, and
+// increments the version. Returns errVersionConflict if the user has been changed in the meantime. Every
+// change of a user goes through saveUser, so that the changes never overwrite each other.
+func (c *UserController) saveUser(user *store.UserRecord, update map[string]interface{}) (*store.UserRecord, error) {
+	return saveUserRecord(c.Store.Users, user, update)
+}
+
+// saveUserRecord runs saveUser on the users repository.
+func saveUserRecord(users backends.Repository, user *store.UserRecord, update map[string]interface{}) (*store.UserRecord, error) {
+	update["version"] = user.Version + 1
+
+	var result interface{}
+	var err error
+	switch repository := users.(type) {
+	case *backends.MongoSession:
+		result, err = saveMongoUser(repository, user, update)
+	case *backends.DynamoCollection:
+		result, err = saveDynamoUser(repository, user, update)
+	default:
+		result, err = saveUserIfVersion(users, user, update)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &store.UserRecord{}
+	if err = backends.MapToInterface(result, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// saveUserIfVersion saves the update through the repository with a filter on the version. Used for the
+// repositories that check the whole filter before the update and report a mismatch as not found.
+func saveUserIfVersion(users backends.Repository, user *store.UserRecord, update map[string]interface{}) (interface{}, error) {
+	filter := backends.NewFilter().Match("id", user.ID.Hex())
+	if user.Version > 0 {
+		filter.Match("version", user.Version)
+	} else {
+		filter["version"] = unversioned
+	}
+
+	result, err := users.Save(&update, filter)
+	if err != nil {
+		if backends.IsErrNotFound(err) {
+			return nil, errVersionConflict
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// saveMongoUser saves the update with a MongoDB condition on the version. The backend reads the saved record
+// back with the filter of the update, which no longer matches once the version is incremented, so the
+// collection is updated directly and the record is read back by id.
+func saveMongoUser(repository *backends.MongoSession, user *store.UserRecord, update map[string]interface{}) (interface{}, error) {
+	session, collection := repository.GetCollection()
+	defer session.Close()
+
+	selector := bson.M{"_id": user.ID, "version": user.Version}
+	if user.Version == 0 {
+		selector["version"] = unversioned
+	}
+	if err := collection.Update(selector, bson.M{"$set": update}); err != nil {
+		if err == mgo.ErrNotFound {
+			return nil, errVersionConflict
+		}
+		return nil, err
+	}
+
+	updated := map[string]interface{}{}
+	if err := collection.FindId(user.ID).One(&updated); err != nil {
+		return nil, err
+	}
+	updated["id"] = user.ID.Hex()
+	delete(updated, "_id")
+	return updated, nil
+}
+
+// saveDynamoUser saves the update with a DynamoDB condition on the version. The backend updates records with
+// a separate read, so the table is updated directly to have the check and the update in one request.
+func saveDynamoUser(table *backends.DynamoCollection, user *store.UserRecord, update map[string]interface{}) (interface{}, error) {
+	fields, err := userFields(user)
+	if err != nil {
+		return nil, err
+	}
+	values, err := dynamoValues(update)
+	if err != nil {
+		return nil, err
+	}
+	hashKey := table.GetHashKey()
+	query := table.Update(hashKey, fields[hashKey])
+	for key, value := range values {
+		if key != hashKey {
+			query = query.Set(key, value)
+		}
+	}
+	if user.Version > 0 {
+		query = query.If("$ = ?", "version", user.Version)
+	} else {
+		query = query.If("(attribute_not_exists($) OR $ = ?)", "version", "version", 0)
+	}
+
+	updated := map[string]interface{}{}
+	if err = query.Value(&updated); err != nil {
+		if backends.IsConditionalCheckErr(err) {
+			return nil, errVersionConflict
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// dynamoValues converts the values of the update to their JSON form. The dynamo package names the fields of
+// structs by the Go field names, not by the json tags the users are read with.
+func dynamoValues(update map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err = json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// sameJSON checks whether two values have the same JSON form.
+func sameJSON(a, b interface{}) bool {
+	var x, y interface{}
+	ja, err := json.Marshal(a)
+	if err != nil || json.Unmarshal(ja, &x) != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil || json.Unmarshal(jb, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Microkubes/backends"
+	"github.com/Microkubes/microservice-user/store"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/guregu/dynamo"
+)
+
+func TestMatchesETag(t *testing.T) {
+	user := &store.UserRecord{Version: 3}
+
+	for ifMatch, expected := range map[string]bool{
+		`"3"`:        true,
+		`"2", "3"`:   true,
+		`*`:          true,
+		`"2"`:        false,
+		`W/"3"`:      false,
+		`3`:          false,
+		`"3-stale"`:  false,
+		`"2",W/"3"`:  false,
+		` "3" , "4"`: true,
+	} {
+		if matchesETag(ifMatch, user) != expected {
+			t.Errorf("Expected %v for If-Match %s", expected, ifMatch)
+		}
+	}
+}
+
+func TestSaveUserVersion(t *testing.T) {
+	db := store.NewDB()
+	versionCtrl := NewUserController(service, db, nil, nil, passwordPolicy, passwordHashing)
+
+	user := &store.UserRecord{}
+	if _, err := db.Users.GetOne(backends.NewFilter().Match("id", "5df2103b5f1b640001142d40"), user); err != nil {
+		t.Fatal(err)
+	}
+
+	// a user saved before versioning gets the first version
+	updated, err := versionCtrl.saveUser(user, map[string]interface{}{"roles": []string{"user", "admin"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Version != 1 {
+		t.Fatalf("Expected version 1, got %d", updated.Version)
+	}
+
+	saved, err := versionCtrl.saveUser(updated, map[string]interface{}{"roles": []string{"user"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Version != 2 || len(saved.Roles) != 1 {
+		t.Errorf("Expected version 2 with the new roles, got %d %v", saved.Version, saved.Roles)
+	}
+
+	if _, err = versionCtrl.saveUser(updated, map[string]interface{}{"roles": []string{"admin"}}); err != errVersionConflict {
+		t.Errorf("Expected a version conflict for a stale user, got %v", err)
+	}
+}
+
+func TestUpdateUserRetriesConflicts(t *testing.T) {
+	db := store.NewDB()
+	retryCtrl := NewUserController(service, db, nil, nil, passwordPolicy, passwordHashing)
+
+	stale := &store.UserRecord{}
+	if _, err := db.Users.GetOne(backends.NewFilter().Match("id", "5df2103b5f1b640001142d40"), stale); err != nil {
+		t.Fatal(err)
+	}
+	// the user is changed after it has been read
+	if _, err := retryCtrl.saveUser(stale, map[string]interface{}{"failedLogins": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := 0
+	updated, err := retryCtrl.updateUser(stale, func(user *store.UserRecord) (map[string]interface{}, error) {
+		attempts++
+		return map[string]interface{}{"failedLogins": user.FailedLogins + 1}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 || updated.FailedLogins != 2 || updated.Version != 2 {
+		t.Errorf("Expected the update to be built again on the changed user, got %d attempts, %d failed logins, version %d", attempts, updated.FailedLogins, updated.Version)
+	}
+}
+
+func TestDynamoValues(t *testing.T) {
+	user := &store.UserRecord{Status: store.StatusActive}
+	update, err := user.StatusUpdate(store.StatusSuspended, "suspended", "system", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update["forgotPasswordTokens"] = store.FPToken{Token: "token-hash", ExpDate: "2000"}
+
+	values, err := dynamoValues(update)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the values as DynamoDB saves them and the backend reads them back
+	item := map[string]*dynamodb.AttributeValue{}
+	for key, value := range values {
+		if item[key], err = dynamo.Marshal(value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	record := map[string]interface{}{}
+	if err = dynamo.UnmarshalItem(item, &record); err != nil {
+		t.Fatal(err)
+	}
+	// the users are looked up by the forgot password token
+	if token, _ := record["forgotPasswordTokens"].(map[string]interface{}); token["token"] != "token-hash" {
+		t.Errorf("Expected the forgot password token to be saved under its JSON names, got %v", record["forgotPasswordTokens"])
+	}
+	saved := &store.UserRecord{}
+	if err = backends.MapToInterface(&record, saved); err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.FPToken.Token != "token-hash" || saved.FPToken.ExpDate != "2000" {
+		t.Errorf("Expected the forgot password token to be read back, got %v", saved.FPToken)
+	}
+	if len(saved.StatusHistory) != 1 || saved.StatusHistory[0].To != store.StatusSuspended || saved.StatusHistory[0].At != 1000 {
+		t.Errorf("Expected the status history to be read back, got %v", saved.StatusHistory)
+	}
+}